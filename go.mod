@@ -11,6 +11,7 @@ require (
 	github.com/ghodss/yaml v1.0.0
 	github.com/google/go-cmp v0.4.0
 	github.com/mitchellh/go-homedir v1.1.0
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/projectriff/system v0.0.0-20200117214235-79653e435821
 	github.com/spf13/cobra v0.0.5
 	github.com/spf13/viper v1.6.2
@@ -44,6 +44,7 @@ knative runtime.
 
 	cmd.AddCommand(NewApplicationListCommand(ctx, c))
 	cmd.AddCommand(NewApplicationCreateCommand(ctx, c))
+	cmd.AddCommand(NewApplicationUpdateCommand(ctx, c))
 	cmd.AddCommand(NewApplicationDeleteCommand(ctx, c))
 	cmd.AddCommand(NewApplicationStatusCommand(ctx, c))
 	cmd.AddCommand(NewApplicationTailCommand(ctx, c))
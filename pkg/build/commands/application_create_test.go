@@ -28,6 +28,7 @@ import (
 	"github.com/projectriff/cli/pkg/build/commands"
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/k8s"
+	riffkail "github.com/projectriff/cli/pkg/kail"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	kailtesting "github.com/projectriff/cli/pkg/testing/kail"
 	packtesting "github.com/projectriff/cli/pkg/testing/pack"
@@ -321,6 +322,32 @@ func TestApplicationCreateCommand(t *testing.T) {
 			},
 			ExpectOutput: `
 Created application "my-application"
+`,
+		},
+		{
+			Name: "create with labels and annotations",
+			Args: []string{applicationName, cli.ImageFlagName, imageTag, cli.GitRepoFlagName, gitRepo, cli.LabelFlagName, "team=payments", cli.AnnotationFlagName, "owner=jane@example.com"},
+			ExpectCreates: []runtime.Object{
+				&buildv1alpha1.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   defaultNamespace,
+						Name:        applicationName,
+						Labels:      map[string]string{"team": "payments"},
+						Annotations: map[string]string{"owner": "jane@example.com"},
+					},
+					Spec: buildv1alpha1.ApplicationSpec{
+						Image: imageTag,
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      gitRepo,
+								Revision: gitMaster,
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created application "my-application"
 `,
 		},
 		{
@@ -887,7 +914,7 @@ Created application "my-application"
 							},
 						},
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -947,7 +974,7 @@ Application "my-application" is ready
 							},
 						},
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(k8s.ErrWaitTimeout).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(k8s.ErrWaitTimeout).Run(func(args mock.Arguments) {
 					ctx := args[0].(context.Context)
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 					// wait for context to be cancelled
@@ -1018,7 +1045,7 @@ To continue watching logs run: riff application tail my-application --namespace
 							},
 						},
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(fmt.Errorf("kail error"))
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
 				return ctx, nil
 			},
 			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
@@ -27,7 +27,6 @@ import (
 	"github.com/projectriff/cli/pkg/cli/printers"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -50,18 +49,15 @@ func (opts *ApplicationListOptions) Validate(ctx context.Context) cli.FieldError
 }
 
 func (opts *ApplicationListOptions) Exec(ctx context.Context, c *cli.Config) error {
-	applications, err := c.Build().Applications(opts.Namespace).List(metav1.ListOptions{})
+	listOptions := opts.K8sListOptions()
+	applications, err := c.Build().Applications(opts.Namespace).List(listOptions)
 	if err != nil {
 		return err
 	}
 
-	if len(applications.Items) == 0 {
-		c.Infof("No applications found.\n")
-		return nil
-	}
-
 	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{
 		WithNamespace: opts.AllNamespaces,
+		Wide:          opts.Output == printers.OutputFormatWide,
 	}).With(func(h printers.PrintHandler) {
 		columns := opts.printColumns()
 		h.TableHandler(columns, opts.printList)
@@ -71,7 +67,25 @@ func (opts *ApplicationListOptions) Exec(ctx context.Context, c *cli.Config) err
 	applications = applications.DeepCopy()
 	cli.SortByNamespaceAndName(applications.Items)
 
-	return tablePrinter.PrintObj(applications, c.Stdout)
+	if len(applications.Items) == 0 && printers.IsHumanReadable(opts.Output) {
+		c.Infof("No applications found.\n")
+	} else if err := printers.PrintList(opts.Output, tablePrinter, applications, buildv1alpha1.SchemeGroupVersion.WithKind("Application"), c.Stdout); err != nil {
+		return err
+	}
+
+	if opts.Watch {
+		return cli.WatchList(ctx, c, applications, cli.WatchListOptions{
+			Client:       c.Build().RESTClient(),
+			Resource:     "applications",
+			Namespace:    opts.Namespace,
+			ListOptions:  listOptions,
+			Output:       opts.Output,
+			TablePrinter: tablePrinter,
+			ItemKind:     buildv1alpha1.SchemeGroupVersion.WithKind("Application"),
+		})
+	}
+
+	return nil
 }
 
 func NewApplicationListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -90,12 +104,17 @@ For detail regarding the status of a single application, run:
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s application list", c.Name),
 			fmt.Sprintf("%s application list %s", c.Name, cli.AllNamespacesFlagName),
+			fmt.Sprintf("%s application list %s json", c.Name, cli.OutputFlagName),
+			fmt.Sprintf("%s application list %s", c.Name, cli.WatchFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.AllNamespacesFlag(cmd, c, &opts.Namespace, &opts.AllNamespaces)
+	cli.OutputFlag(cmd, &opts.Output)
+	cli.WatchFlag(cmd, &opts.Watch)
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
 
 	return cmd
 }
@@ -112,8 +131,13 @@ func (opts *ApplicationListOptions) printList(applications *buildv1alpha1.Applic
 	return rows, nil
 }
 
-func (opts *ApplicationListOptions) print(application *buildv1alpha1.Application, _ printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+func (opts *ApplicationListOptions) print(application *buildv1alpha1.Application, printOpts printers.PrintOptions) ([]metav1beta1.TableRow, error) {
 	now := time.Now()
+	var gitRepo, gitRevision string
+	if application.Spec.Source != nil && application.Spec.Source.Git != nil {
+		gitRepo = application.Spec.Source.Git.URL
+		gitRevision = application.Spec.Source.Git.Revision
+	}
 	row := metav1beta1.TableRow{
 		Object: runtime.RawExtension{Object: application},
 	}
@@ -123,6 +147,12 @@ func (opts *ApplicationListOptions) print(application *buildv1alpha1.Application
 		cli.FormatConditionStatus(application.Status.GetCondition(buildv1alpha1.ApplicationConditionReady)),
 		cli.FormatTimestampSince(application.CreationTimestamp, now),
 	)
+	if printOpts.Wide {
+		row.Cells = append(row.Cells,
+			cli.FormatEmptyString(gitRepo),
+			cli.FormatEmptyString(gitRevision),
+		)
+	}
 	return []metav1beta1.TableRow{row}, nil
 }
 
@@ -132,5 +162,7 @@ func (opts *ApplicationListOptions) printColumns() []metav1beta1.TableColumnDefi
 		{Name: "Latest Image", Type: "string"},
 		{Name: "Status", Type: "string"},
 		{Name: "Age", Type: "string"},
+		{Name: "Git Repo", Type: "string", Priority: 1},
+		{Name: "Git Revision", Type: "string", Priority: 1},
 	}
 }
@@ -155,6 +155,37 @@ other-namespace   test-other-application   <empty>        <unknown>   <unknown>
 			ExpectOutput: `
 NAME        LATEST IMAGE                              STATUS   AGE
 petclinic   projectriff/petclinic@sah256:abcdef1234   Ready    <unknown>
+`,
+		},
+		{
+			Name: "wide output",
+			Args: []string{cli.OutputFlagName, "wide"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      applicationName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+NAME               LATEST IMAGE   STATUS      AGE         GIT REPO   GIT REVISION
+test-application   <empty>        <unknown>   <unknown>   <empty>    <empty>
+`,
+		},
+		{
+			Name: "name output",
+			Args: []string{cli.OutputFlagName, "name"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      applicationName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+application/test-application
 `,
 		},
 		{
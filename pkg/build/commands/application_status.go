@@ -23,6 +23,8 @@ import (
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/projectriff/cli/pkg/validation"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -31,6 +33,8 @@ import (
 
 type ApplicationStatusOptions struct {
 	options.ResourceOptions
+
+	Output string
 }
 
 var (
@@ -43,6 +47,8 @@ func (opts *ApplicationStatusOptions) Validate(ctx context.Context) cli.FieldErr
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
 
+	errs = errs.Also(validation.StructuredOutputFormat(opts.Output, cli.OutputFlagName))
+
 	return errs
 }
 
@@ -56,10 +62,25 @@ func (opts *ApplicationStatusOptions) Exec(ctx context.Context, c *cli.Config) e
 		return cli.SilenceError(err)
 	}
 
+	if opts.Output != "" {
+		return printers.PrintObject(opts.Output, application, buildv1alpha1.SchemeGroupVersion.WithKind("Application"), c.Stdout)
+	}
+
 	ready := application.Status.GetCondition(buildv1alpha1.ApplicationConditionReady)
 	cli.PrintResourceStatus(c, application.Name, ready)
 
-	return nil
+	return cli.PrintResourceStatusDetails(c, cli.ResourceStatusDetails{
+		GroupVersionKind: application.GetGroupVersionKind(),
+		Namespace:        application.Namespace,
+		Name:             application.Name,
+		Conditions:       application.Status.Conditions,
+		Details: []cli.StatusDetail{
+			{Name: "Latest Image", Value: application.Status.LatestImage},
+			{Name: "Target Image", Value: application.Status.TargetImage},
+			{Name: "Kpack Image", Value: cli.StatusRefName(application.Status.KpackImageRef)},
+		},
+		PodSelector: fmt.Sprintf("%s=%s", buildv1alpha1.ApplicationLabelKey, application.Name),
+	})
 }
 
 func NewApplicationStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -75,9 +96,13 @@ The Ready condition is shown which should include a reason code and a
 descriptive message when the status is not "True". The status for the condition
 may be: "True", "False" or "Unknown". An "Unknown" status is common while the
 application roll out is processed or a build is in progress.
+
+Key fields from the status, every condition and the most recent events for
+the application are shown after the Ready condition.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s application status my-application", c.Name),
+			fmt.Sprintf("%s application status my-application %s jsonpath={.status.latestImage}", c.Name, cli.OutputFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -88,6 +113,7 @@ application roll out is processed or a build is in progress.
 	)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cli.StructuredOutputFlag(cmd, &opts.Output)
 
 	return cmd
 }
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/projectriff/cli/pkg/build/commands"
+	"github.com/projectriff/cli/pkg/cli"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	"github.com/projectriff/system/pkg/apis"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
@@ -45,6 +46,14 @@ func TestApplicationStatusOptions(t *testing.T) {
 			},
 			ShouldValidate: true,
 		},
+		{
+			Name: "invalid output",
+			Options: &commands.ApplicationStatusOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Output:          "xml",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("xml", cli.OutputFlagName),
+		},
 	}
 
 	table.Run(t)
@@ -96,7 +105,76 @@ message: a hopefully informative message about what went wrong
 reason: OopsieDoodle
 status: "False"
 type: Ready
+
+Conditions:
+  TYPE    STATUS   REASON         LAST TRANSITION        MESSAGE
+  Ready   False    OopsieDoodle   2019-06-29T01:44:05Z   a hopefully informative message about what went wrong
+
+Events:
+  <none>
 `,
+		},
+		{
+			Name: "jsonpath output",
+			Args: []string{applicationName, cli.OutputFlagName, "jsonpath={.status.conditions[0].reason}"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      applicationName,
+						Namespace: defaultNamespace,
+					},
+					Status: buildv1alpha1.ApplicationStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:    apis.ConditionReady,
+									Status:  corev1.ConditionFalse,
+									Reason:  "OopsieDoodle",
+									Message: "a hopefully informative message about what went wrong",
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+OopsieDoodle`,
+		},
+		{
+			Name: "go-template output",
+			Args: []string{applicationName, cli.OutputFlagName, `go-template={{.metadata.name}}: {{range .status.conditions}}{{.type}}={{.status}}{{end}}`},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      applicationName,
+						Namespace: defaultNamespace,
+					},
+					Status: buildv1alpha1.ApplicationStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:    apis.ConditionReady,
+									Status:  corev1.ConditionFalse,
+									Reason:  "OopsieDoodle",
+									Message: "a hopefully informative message about what went wrong",
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+my-application: Ready=False`,
 		},
 		{
 			Name: "not found",
@@ -24,6 +24,7 @@ import (
 
 	"github.com/projectriff/cli/pkg/build/commands"
 	"github.com/projectriff/cli/pkg/cli"
+	riffkail "github.com/projectriff/cli/pkg/kail"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	kailtesting "github.com/projectriff/cli/pkg/testing/kail"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
@@ -91,7 +92,7 @@ func TestApplicationTailCommand(t *testing.T) {
 			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
 				kail := &kailtesting.Logger{}
 				c.Kail = kail
-				kail.On("ApplicationLogs", mock.Anything, application, cli.TailSinceDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				kail.On("ApplicationLogs", mock.Anything, application, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -114,7 +115,7 @@ func TestApplicationTailCommand(t *testing.T) {
 			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
 				kail := &kailtesting.Logger{}
 				c.Kail = kail
-				kail.On("ApplicationLogs", mock.Anything, application, time.Hour, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				kail.On("ApplicationLogs", mock.Anything, application, riffkail.LogOptions{Since: time.Hour, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -142,7 +143,7 @@ func TestApplicationTailCommand(t *testing.T) {
 			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
 				kail := &kailtesting.Logger{}
 				c.Kail = kail
-				kail.On("ApplicationLogs", mock.Anything, application, cli.TailSinceDefault, mock.Anything).Return(fmt.Errorf("kail error"))
+				kail.On("ApplicationLogs", mock.Anything, application, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
 				return ctx, nil
 			},
 			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
@@ -0,0 +1,243 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/parsers"
+	"github.com/projectriff/cli/pkg/race"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ApplicationUpdateOptions struct {
+	options.ResourceOptions
+	options.TailOptions
+
+	Image     string
+	CacheSize string
+
+	GitRepo     string
+	GitRevision string
+	SubPath     string
+
+	Env []string
+
+	LimitCPU    string
+	LimitMemory string
+
+	Tail        bool
+	WaitTimeout string
+
+	DryRun bool
+}
+
+var (
+	_ cli.Validatable = (*ApplicationUpdateOptions)(nil)
+	_ cli.Executable  = (*ApplicationUpdateOptions)(nil)
+	_ cli.DryRunable  = (*ApplicationUpdateOptions)(nil)
+)
+
+func (opts *ApplicationUpdateOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+
+	if opts.Image == "" && opts.CacheSize == "" &&
+		opts.GitRepo == "" && opts.GitRevision == "" && opts.SubPath == "" &&
+		len(opts.Env) == 0 && opts.LimitCPU == "" && opts.LimitMemory == "" {
+		errs = errs.Also(cli.ErrNothingToUpdate(
+			cli.ImageFlagName, cli.CacheSizeFlagName,
+			cli.GitRepoFlagName, cli.GitRevisionFlagName, cli.SubPathFlagName,
+			cli.EnvFlagName, cli.LimitCPUFlagName, cli.LimitMemoryFlagName,
+		))
+	}
+
+	errs = errs.Also(buildFields{
+		CacheSize:   opts.CacheSize,
+		Env:         opts.Env,
+		LimitCPU:    opts.LimitCPU,
+		LimitMemory: opts.LimitMemory,
+	}.Validate(ctx))
+	errs = errs.Also(tailFields{
+		Tail:        opts.Tail,
+		WaitTimeout: opts.WaitTimeout,
+		DryRun:      opts.DryRun,
+	}.Validate(ctx))
+	errs = errs.Also(opts.TailOptions.Validate(ctx, cli.TailOutputFlagName))
+
+	return errs
+}
+
+func (opts *ApplicationUpdateOptions) Exec(ctx context.Context, c *cli.Config) error {
+	existing, err := c.Build().Applications(opts.Namespace).Get(opts.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		c.Errorf("Application %q not found\n", fmt.Sprintf("%s/%s", opts.Namespace, opts.Name))
+		return cli.SilenceError(err)
+	}
+
+	application := existing.DeepCopy()
+	if opts.Image != "" {
+		application.Spec.Image = opts.Image
+	}
+	if opts.CacheSize != "" {
+		quantity := resource.MustParse(opts.CacheSize)
+		application.Spec.CacheSize = &quantity
+	}
+
+	if opts.GitRepo != "" || opts.GitRevision != "" || opts.SubPath != "" {
+		if application.Spec.Source == nil || application.Spec.Source.Git == nil {
+			if opts.GitRepo == "" {
+				return fmt.Errorf("application %q is not built from a git repository, %s is required", opts.Name, cli.GitRepoFlagName)
+			}
+			if application.Spec.Source == nil {
+				application.Spec.Source = &buildv1alpha1.Source{}
+			}
+			application.Spec.Source.Git = &buildv1alpha1.Git{
+				Revision: "master",
+			}
+		}
+		if opts.GitRepo != "" {
+			application.Spec.Source.Git.URL = opts.GitRepo
+		}
+		if opts.GitRevision != "" {
+			application.Spec.Source.Git.Revision = opts.GitRevision
+		}
+		if opts.SubPath != "" {
+			application.Spec.Source.SubPath = opts.SubPath
+		}
+	}
+
+	if len(opts.Env) > 0 {
+		env := make([]corev1.EnvVar, len(opts.Env))
+		for i, envvar := range opts.Env {
+			env[i] = parsers.EnvVar(envvar)
+		}
+		application.Spec.Build.Env = k8s.MergeEnvVars(application.Spec.Build.Env, env...)
+	}
+
+	if (opts.LimitCPU != "" || opts.LimitMemory != "") && application.Spec.Build.Resources.Limits == nil {
+		application.Spec.Build.Resources.Limits = corev1.ResourceList{}
+	}
+	if opts.LimitCPU != "" {
+		// parse errors are handled by the opt validation
+		application.Spec.Build.Resources.Limits[corev1.ResourceCPU] = resource.MustParse(opts.LimitCPU)
+	}
+	if opts.LimitMemory != "" {
+		// parse errors are handled by the opt validation
+		application.Spec.Build.Resources.Limits[corev1.ResourceMemory] = resource.MustParse(opts.LimitMemory)
+	}
+
+	if opts.DryRun {
+		cli.DryRunResource(ctx, application, application.GetGroupVersionKind())
+	} else {
+		application, err = c.Build().Applications(opts.Namespace).Update(application)
+		if err != nil {
+			return err
+		}
+	}
+	c.Successf("Updated application %q\n", application.Name)
+	if opts.Tail {
+		// err guarded by Validate()
+		timeout, _ := time.ParseDuration(opts.WaitTimeout)
+		err := race.Run(ctx, timeout,
+			func(ctx context.Context) error {
+				return k8s.WaitUntilReady(ctx, c.Build().RESTClient(), "applications", application)
+			},
+			func(ctx context.Context) error {
+				return c.Kail.ApplicationLogs(ctx, application, opts.LogOptions(cli.TailSinceCreateDefault), c.Stdout)
+			},
+		)
+		if err == context.DeadlineExceeded {
+			c.Errorf("Timeout after %q waiting for %q to become ready\n", opts.WaitTimeout, opts.Name)
+			c.Infof("To view status run: %s application list %s %s\n", c.Name, cli.NamespaceFlagName, opts.Namespace)
+			c.Infof("To continue watching logs run: %s application tail %s %s %s\n", c.Name, opts.Name, cli.NamespaceFlagName, opts.Namespace)
+			err = cli.SilenceError(err)
+		}
+		if err != nil {
+			return err
+		}
+		c.Successf("Application %q is ready\n", application.Name)
+	}
+	return nil
+}
+
+func (opts *ApplicationUpdateOptions) IsDryRun() bool {
+	return opts.DryRun
+}
+
+func NewApplicationUpdateCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ApplicationUpdateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "update an application built from source",
+		Long: strings.TrimSpace(`
+Update an existing application in place, preserving the application's build
+history.
+
+Only the properties set by flags are changed, all other properties of the
+application are left as is. Environment variables are merged with the existing
+build environment, a variable with the same name as an existing variable
+replaces its value.
+
+Update does not read a local directory. An application created from a local
+directory is rebuilt by deleting and creating it again, or by setting
+` + cli.GitRepoFlagName + ` to build it from a git repository instead.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s application update my-app %s v1.0.1", c.Name, cli.GitRevisionFlagName),
+			fmt.Sprintf("%s application update my-app %s MY_VAR=my-value", c.Name, cli.EnvFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+	)
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().StringVar(&opts.Image, cli.StripDash(cli.ImageFlagName), "", "`repository` where the built images are pushed")
+	cmd.Flags().StringVar(&opts.CacheSize, cli.StripDash(cli.CacheSizeFlagName), "", "`size` of persistent volume to cache resources between builds")
+	cmd.Flags().StringVar(&opts.GitRepo, cli.StripDash(cli.GitRepoFlagName), "", "git `url` to remote source code")
+	cmd.Flags().StringVar(&opts.GitRevision, cli.StripDash(cli.GitRevisionFlagName), "", "`refspec` within the git repo to checkout")
+	cmd.Flags().StringVar(&opts.SubPath, cli.StripDash(cli.SubPathFlagName), "", "path to `directory` within the git repo to checkout")
+	cmd.Flags().StringArrayVar(&opts.Env, cli.StripDash(cli.EnvFlagName), []string{}, fmt.Sprintf("environment `variable` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s MY_VAR=my-value", cli.EnvFlagName)))
+	cmd.Flags().StringVar(&opts.LimitCPU, cli.StripDash(cli.LimitCPUFlagName), "", "the maximum amount of cpu allowed, in CPU `cores` (500m = .5 cores)")
+	cmd.Flags().StringVar(&opts.LimitMemory, cli.StripDash(cli.LimitMemoryFlagName), "", "the maximum amount of memory allowed, in `bytes` (500Mi = 500MiB = 500 * 1024 * 1024)")
+	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch build logs")
+	cli.TailFlags(cmd, cli.TailOutputFlagName, &opts.Output, &opts.Grep, &opts.Timestamps)
+	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the application to become ready when watching logs")
+	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+
+	return cmd
+}
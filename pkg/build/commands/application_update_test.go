@@ -0,0 +1,603 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/build/commands"
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
+	riffkail "github.com/projectriff/cli/pkg/kail"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	kailtesting "github.com/projectriff/cli/pkg/testing/kail"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	cachetesting "k8s.io/client-go/tools/cache/testing"
+)
+
+func TestApplicationUpdateOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "invalid resource",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.InvalidResourceOptions,
+				Image:           "example.com/repo:tag",
+			},
+			ExpectFieldErrors: rifftesting.InvalidResourceOptionsFieldError,
+		},
+		{
+			Name: "valid",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "nothing to update",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Tail:            true,
+				WaitTimeout:     "10m",
+			},
+			ExpectFieldErrors: cli.ErrNothingToUpdate(
+				cli.ImageFlagName, cli.CacheSizeFlagName, cli.GitRepoFlagName, cli.GitRevisionFlagName, cli.SubPathFlagName, cli.EnvFlagName, cli.LimitCPUFlagName, cli.LimitMemoryFlagName,
+			),
+		},
+		{
+			Name: "with git source",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				GitRepo:         "https://example.com/repo.git",
+				GitRevision:     "v1.0.1",
+				SubPath:         "some/directory",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with cache",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				CacheSize:       "8Gi",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid cache",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				CacheSize:       "X",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("X", cli.CacheSizeFlagName),
+		},
+		{
+			Name: "with env",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Env:             []string{"VAR1=foo", "VAR2=bar"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with invalid env",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Env:             []string{"=foo"},
+			},
+			ExpectFieldErrors: cli.ErrInvalidArrayValue("=foo", cli.EnvFlagName, 0),
+		},
+		{
+			Name: "with limits",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				LimitCPU:        "500m",
+				LimitMemory:     "512Mi",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with invalid limits",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				LimitCPU:        "50%",
+				LimitMemory:     "NaN",
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidValue("50%", cli.LimitCPUFlagName),
+				cli.ErrInvalidValue("NaN", cli.LimitMemoryFlagName),
+			),
+		},
+		{
+			Name: "tail",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				Tail:            true,
+				WaitTimeout:     "10m",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "tail missing timeout",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				Tail:            true,
+			},
+			ExpectFieldErrors: cli.ErrMissingField(cli.WaitTimeoutFlagName),
+		},
+		{
+			Name: "tail invalid timeout",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				Tail:            true,
+				WaitTimeout:     "d",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("d", cli.WaitTimeoutFlagName),
+		},
+		{
+			Name: "dry run",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				DryRun:          true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "dry run, tail",
+			Options: &commands.ApplicationUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				Tail:            true,
+				WaitTimeout:     "10m",
+				DryRun:          true,
+			},
+			ExpectFieldErrors: cli.ErrMultipleOneOf(cli.DryRunFlagName, cli.TailFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestApplicationUpdateCommand(t *testing.T) {
+	defaultNamespace := "default"
+	applicationName := "my-application"
+	imageTag := "registry.example.com/repo:tag"
+	newImageTag := "registry.example.com/new-repo:tag"
+	gitRepo := "https://example.com/repo.git"
+	newGitRepo := "https://example.com/new-repo.git"
+	gitMaster := "master"
+	gitSha := "deadbeefdeadbeefdeadbeefdeadbeef"
+	subPath := "some/path"
+	cacheSize := "8Gi"
+	cacheSizeQuantity := resource.MustParse(cacheSize)
+
+	givenApplication := &buildv1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      applicationName,
+		},
+		Spec: buildv1alpha1.ApplicationSpec{
+			Image: imageTag,
+			Source: &buildv1alpha1.Source{
+				Git: &buildv1alpha1.Git{
+					URL:      gitRepo,
+					Revision: gitMaster,
+				},
+			},
+			Build: buildv1alpha1.ImageBuild{
+				Env: []corev1.EnvVar{
+					{Name: "MY_VAR1", Value: "value1"},
+				},
+			},
+		},
+	}
+	givenLocalApplication := &buildv1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      applicationName,
+		},
+		Spec: buildv1alpha1.ApplicationSpec{
+			Image: imageTag,
+		},
+	}
+	withRevision := givenApplication.DeepCopy()
+	withRevision.Spec.Source.Git.Revision = gitSha
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name: "git revision",
+			Args: []string{applicationName, cli.GitRevisionFlagName, gitSha},
+			GivenObjects: []runtime.Object{
+				givenApplication.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				withRevision.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated application "my-application"
+`,
+		},
+		{
+			Name: "git revision, dry run",
+			Args: []string{applicationName, cli.GitRevisionFlagName, gitSha, cli.DryRunFlagName},
+			GivenObjects: []runtime.Object{
+				givenApplication.DeepCopy(),
+			},
+			ExpectOutput: `
+---
+apiVersion: build.projectriff.io/v1alpha1
+kind: Application
+metadata:
+  creationTimestamp: null
+  name: my-application
+  namespace: default
+spec:
+  build:
+    env:
+    - name: MY_VAR1
+      value: value1
+    resources: {}
+  image: registry.example.com/repo:tag
+  source:
+    git:
+      revision: deadbeefdeadbeefdeadbeefdeadbeef
+      url: https://example.com/repo.git
+status: {}
+
+Updated application "my-application"
+`,
+		},
+		{
+			Name: "git repo and subpath",
+			Args: []string{applicationName, cli.GitRepoFlagName, newGitRepo, cli.SubPathFlagName, subPath},
+			GivenObjects: []runtime.Object{
+				givenApplication.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				&buildv1alpha1.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      applicationName,
+					},
+					Spec: buildv1alpha1.ApplicationSpec{
+						Image: imageTag,
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      newGitRepo,
+								Revision: gitMaster,
+							},
+							SubPath: subPath,
+						},
+						Build: buildv1alpha1.ImageBuild{
+							Env: []corev1.EnvVar{
+								{Name: "MY_VAR1", Value: "value1"},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Updated application "my-application"
+`,
+		},
+		{
+			Name: "git repo for local source",
+			Args: []string{applicationName, cli.GitRepoFlagName, gitRepo},
+			GivenObjects: []runtime.Object{
+				givenLocalApplication.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				&buildv1alpha1.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      applicationName,
+					},
+					Spec: buildv1alpha1.ApplicationSpec{
+						Image: imageTag,
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      gitRepo,
+								Revision: gitMaster,
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Updated application "my-application"
+`,
+		},
+		{
+			Name: "git revision for local source",
+			Args: []string{applicationName, cli.GitRevisionFlagName, gitSha},
+			GivenObjects: []runtime.Object{
+				givenLocalApplication.DeepCopy(),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "image and cache",
+			Args: []string{applicationName, cli.ImageFlagName, newImageTag, cli.CacheSizeFlagName, cacheSize},
+			GivenObjects: []runtime.Object{
+				givenApplication.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				&buildv1alpha1.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      applicationName,
+					},
+					Spec: buildv1alpha1.ApplicationSpec{
+						Image:     newImageTag,
+						CacheSize: &cacheSizeQuantity,
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      gitRepo,
+								Revision: gitMaster,
+							},
+						},
+						Build: buildv1alpha1.ImageBuild{
+							Env: []corev1.EnvVar{
+								{Name: "MY_VAR1", Value: "value1"},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Updated application "my-application"
+`,
+		},
+		{
+			Name: "env",
+			Args: []string{applicationName, cli.EnvFlagName, "MY_VAR1=new-value1", cli.EnvFlagName, "MY_VAR2=value2"},
+			GivenObjects: []runtime.Object{
+				givenApplication.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				&buildv1alpha1.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      applicationName,
+					},
+					Spec: buildv1alpha1.ApplicationSpec{
+						Image: imageTag,
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      gitRepo,
+								Revision: gitMaster,
+							},
+						},
+						Build: buildv1alpha1.ImageBuild{
+							Env: []corev1.EnvVar{
+								{Name: "MY_VAR1", Value: "new-value1"},
+								{Name: "MY_VAR2", Value: "value2"},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Updated application "my-application"
+`,
+		},
+		{
+			Name: "limits",
+			Args: []string{applicationName, cli.LimitCPUFlagName, "100m", cli.LimitMemoryFlagName, "128Mi"},
+			GivenObjects: []runtime.Object{
+				givenApplication.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				&buildv1alpha1.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      applicationName,
+					},
+					Spec: buildv1alpha1.ApplicationSpec{
+						Image: imageTag,
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      gitRepo,
+								Revision: gitMaster,
+							},
+						},
+						Build: buildv1alpha1.ImageBuild{
+							Env: []corev1.EnvVar{
+								{Name: "MY_VAR1", Value: "value1"},
+							},
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("100m"),
+									corev1.ResourceMemory: resource.MustParse("128Mi"),
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Updated application "my-application"
+`,
+		},
+		{
+			Name: "not found",
+			Args: []string{applicationName, cli.GitRevisionFlagName, gitSha},
+			ExpectOutput: `
+Application "default/my-application" not found
+`,
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if actual := err; !errors.Is(err, cli.SilentError) {
+					t.Errorf("expected error to be silent, actual %#v", actual)
+				}
+			},
+		},
+		{
+			Name: "get error",
+			Args: []string{applicationName, cli.GitRevisionFlagName, gitSha},
+			GivenObjects: []runtime.Object{
+				givenApplication.DeepCopy(),
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "applications"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "error during update",
+			Args: []string{applicationName, cli.GitRevisionFlagName, gitSha},
+			GivenObjects: []runtime.Object{
+				givenApplication.DeepCopy(),
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("update", "applications"),
+			},
+			ExpectUpdates: []runtime.Object{
+				withRevision.DeepCopy(),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "tail logs",
+			Args: []string{applicationName, cli.GitRevisionFlagName, gitSha, cli.TailFlagName},
+			GivenObjects: []runtime.Object{
+				givenApplication.DeepCopy(),
+			},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				lw := cachetesting.NewFakeControllerSource()
+				ctx = k8s.WithListerWatcher(ctx, lw)
+
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("ApplicationLogs", mock.Anything, withRevision.DeepCopy(), riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fmt.Fprintf(c.Stdout, "...log output...\n")
+				})
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*cachetesting.FakeControllerSource); ok {
+					lw.Shutdown()
+				}
+
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			ExpectUpdates: []runtime.Object{
+				withRevision.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated application "my-application"
+...log output...
+Application "my-application" is ready
+`,
+		},
+		{
+			Name: "tail timeout",
+			Args: []string{applicationName, cli.GitRevisionFlagName, gitSha, cli.TailFlagName, cli.WaitTimeoutFlagName, "5ms"},
+			GivenObjects: []runtime.Object{
+				givenApplication.DeepCopy(),
+			},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				lw := cachetesting.NewFakeControllerSource()
+				ctx = k8s.WithListerWatcher(ctx, lw)
+
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("ApplicationLogs", mock.Anything, withRevision.DeepCopy(), riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(k8s.ErrWaitTimeout).Run(func(args mock.Arguments) {
+					ctx := args[0].(context.Context)
+					fmt.Fprintf(c.Stdout, "...log output...\n")
+					// wait for context to be cancelled
+					<-ctx.Done()
+				})
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*cachetesting.FakeControllerSource); ok {
+					lw.Shutdown()
+				}
+
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			ExpectUpdates: []runtime.Object{
+				withRevision.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated application "my-application"
+...log output...
+Timeout after "5ms" waiting for "my-application" to become ready
+To view status run: riff application list --namespace default
+To continue watching logs run: riff application tail my-application --namespace default
+`,
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if actual := err; !errors.Is(err, cli.SilentError) {
+					t.Errorf("expected error to be silent, actual %#v", actual)
+				}
+			},
+		},
+		{
+			Name: "tail error",
+			Args: []string{applicationName, cli.GitRevisionFlagName, gitSha, cli.TailFlagName},
+			GivenObjects: []runtime.Object{
+				givenApplication.DeepCopy(),
+			},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				lw := cachetesting.NewFakeControllerSource()
+				ctx = k8s.WithListerWatcher(ctx, lw)
+
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("ApplicationLogs", mock.Anything, withRevision.DeepCopy(), riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*cachetesting.FakeControllerSource); ok {
+					lw.Shutdown()
+				}
+
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			ExpectUpdates: []runtime.Object{
+				withRevision.DeepCopy(),
+			},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewApplicationUpdateCommand)
+}
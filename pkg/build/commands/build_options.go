@@ -0,0 +1,83 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/validation"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// buildFields are the properties of a function or application build shared by
+// the create and update commands.
+type buildFields struct {
+	CacheSize   string
+	Env         []string
+	LimitCPU    string
+	LimitMemory string
+}
+
+func (f buildFields) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if f.CacheSize != "" {
+		// must parse as a resource quantity
+		if _, err := resource.ParseQuantity(f.CacheSize); err != nil {
+			errs = errs.Also(cli.ErrInvalidValue(f.CacheSize, cli.CacheSizeFlagName))
+		}
+	}
+
+	errs = errs.Also(validation.EnvVars(f.Env, cli.EnvFlagName))
+
+	if f.LimitCPU != "" {
+		errs = errs.Also(validation.Quantity(f.LimitCPU, cli.LimitCPUFlagName))
+	}
+	if f.LimitMemory != "" {
+		errs = errs.Also(validation.Quantity(f.LimitMemory, cli.LimitMemoryFlagName))
+	}
+
+	return errs
+}
+
+// tailFields control waiting for a build to become ready while tailing its
+// logs, shared by the create and update commands.
+type tailFields struct {
+	Tail        bool
+	WaitTimeout string
+	DryRun      bool
+}
+
+func (f tailFields) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if f.Tail {
+		if f.WaitTimeout == "" {
+			errs = errs.Also(cli.ErrMissingField(cli.WaitTimeoutFlagName))
+		} else if _, err := time.ParseDuration(f.WaitTimeout); err != nil {
+			errs = errs.Also(cli.ErrInvalidValue(f.WaitTimeout, cli.WaitTimeoutFlagName))
+		}
+	}
+
+	if f.DryRun && f.Tail {
+		errs = errs.Also(cli.ErrMultipleOneOf(cli.DryRunFlagName, cli.TailFlagName))
+	}
+
+	return errs
+}
@@ -39,6 +39,7 @@ container image may then be deployed to core or knative runtime.
 
 	cmd.AddCommand(NewContainerListCommand(ctx, c))
 	cmd.AddCommand(NewContainerCreateCommand(ctx, c))
+	cmd.AddCommand(NewContainerUpdateCommand(ctx, c))
 	cmd.AddCommand(NewContainerDeleteCommand(ctx, c))
 	cmd.AddCommand(NewContainerStatusCommand(ctx, c))
 
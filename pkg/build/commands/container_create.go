@@ -25,7 +25,9 @@ import (
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
 	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/parsers"
 	"github.com/projectriff/cli/pkg/race"
+	"github.com/projectriff/cli/pkg/validation"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +36,9 @@ import (
 type ContainerCreateOptions struct {
 	options.ResourceOptions
 
+	Labels      []string
+	Annotations []string
+
 	Image string
 
 	Tail        bool
@@ -52,22 +57,18 @@ func (opts *ContainerCreateOptions) Validate(ctx context.Context) cli.FieldError
 	errs := cli.FieldErrors{}
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+	errs = errs.Also(validation.Labels(opts.Labels, cli.LabelFlagName))
+	errs = errs.Also(validation.Annotations(opts.Annotations, cli.AnnotationFlagName))
 
 	if opts.Image == "" {
 		errs = errs.Also(cli.ErrMissingField(cli.ImageFlagName))
 	}
 
-	if opts.Tail {
-		if opts.WaitTimeout == "" {
-			errs = errs.Also(cli.ErrMissingField(cli.WaitTimeoutFlagName))
-		} else if _, err := time.ParseDuration(opts.WaitTimeout); err != nil {
-			errs = errs.Also(cli.ErrInvalidValue(opts.WaitTimeout, cli.WaitTimeoutFlagName))
-		}
-	}
-
-	if opts.DryRun && opts.Tail {
-		errs = errs.Also(cli.ErrMultipleOneOf(cli.DryRunFlagName, cli.TailFlagName))
-	}
+	errs = errs.Also(tailFields{
+		Tail:        opts.Tail,
+		WaitTimeout: opts.WaitTimeout,
+		DryRun:      opts.DryRun,
+	}.Validate(ctx))
 
 	return errs
 }
@@ -75,8 +76,10 @@ func (opts *ContainerCreateOptions) Validate(ctx context.Context) cli.FieldError
 func (opts *ContainerCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
 	container := &buildv1alpha1.Container{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: opts.Namespace,
-			Name:      opts.Name,
+			Namespace:   opts.Namespace,
+			Name:        opts.Name,
+			Labels:      parsers.KeyValues(opts.Labels),
+			Annotations: parsers.KeyValues(opts.Annotations),
 		},
 		Spec: buildv1alpha1.ContainerSpec{
 			Image: opts.Image,
@@ -145,6 +148,8 @@ for containers.
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch build logs")
 	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the container to become ready when watching logs")
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().StringArrayVar(&opts.Labels, cli.StripDash(cli.LabelFlagName), []string{}, fmt.Sprintf("`label` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s team=payments", cli.LabelFlagName)))
+	cmd.Flags().StringArrayVar(&opts.Annotations, cli.StripDash(cli.AnnotationFlagName), []string{}, fmt.Sprintf("`annotation` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s owner=jane@example.com", cli.AnnotationFlagName)))
 
 	return cmd
 }
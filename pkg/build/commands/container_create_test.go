@@ -132,6 +132,26 @@ func TestContainerCreateCommand(t *testing.T) {
 			},
 			ExpectOutput: `
 Created container "my-container"
+`,
+		},
+		{
+			Name: "create with labels and annotations",
+			Args: []string{containerName, cli.ImageFlagName, imageTag, cli.LabelFlagName, "team=payments", cli.AnnotationFlagName, "owner=jane@example.com"},
+			ExpectCreates: []runtime.Object{
+				&buildv1alpha1.Container{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   defaultNamespace,
+						Name:        containerName,
+						Labels:      map[string]string{"team": "payments"},
+						Annotations: map[string]string{"owner": "jane@example.com"},
+					},
+					Spec: buildv1alpha1.ContainerSpec{
+						Image: imageTag,
+					},
+				},
+			},
+			ExpectOutput: `
+Created container "my-container"
 `,
 		},
 		{
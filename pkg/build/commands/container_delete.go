@@ -20,11 +20,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type ContainerDeleteOptions struct {
@@ -46,13 +47,22 @@ func (opts *ContainerDeleteOptions) Validate(ctx context.Context) cli.FieldError
 
 func (opts *ContainerDeleteOptions) Exec(ctx context.Context, c *cli.Config) error {
 	client := c.Build().Containers(opts.Namespace)
+	deleted := options.DeletedResource{
+		Client:   c.Build().RESTClient(),
+		Resource: "containers",
+		ObjType:  &buildv1alpha1.Container{},
+	}
 
 	if opts.All {
-		if err := client.DeleteCollection(nil, metav1.ListOptions{}); err != nil {
+		names, err := opts.DeletedNames(ctx, deleted, opts.K8sListOptions())
+		if err != nil {
+			return err
+		}
+		if err := client.DeleteCollection(nil, opts.K8sListOptions()); err != nil {
 			return err
 		}
 		c.Successf("Deleted containers in namespace %q\n", opts.Namespace)
-		return nil
+		return opts.WaitForDeletion(ctx, c, deleted, names)
 	}
 
 	for _, name := range opts.Names {
@@ -62,7 +72,7 @@ func (opts *ContainerDeleteOptions) Exec(ctx context.Context, c *cli.Config) err
 		c.Successf("Deleted container %q\n", name)
 	}
 
-	return nil
+	return opts.WaitForDeletion(ctx, c, deleted, opts.Names)
 }
 
 func NewContainerDeleteCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -78,7 +88,9 @@ Deleting a container prevents resolution of new images.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s container delete my-container", c.Name),
+			fmt.Sprintf("%s container delete my-container %s", c.Name, cli.WaitFlagName),
 			fmt.Sprintf("%s container delete %s", c.Name, cli.AllFlagName),
+			fmt.Sprintf("%s container delete %s %s team=payments", c.Name, cli.AllFlagName, cli.SelectorFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -90,6 +102,9 @@ Deleting a container prevents resolution of new images.
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all containers within the namespace")
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
+	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(cli.WaitFlagName), false, "wait for the containers to be removed")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), time.Minute, "`duration` to wait for the containers to be removed")
 
 	return cmd
 }
@@ -27,7 +27,6 @@ import (
 	"github.com/projectriff/cli/pkg/cli/printers"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -50,18 +49,15 @@ func (opts *ContainerListOptions) Validate(ctx context.Context) cli.FieldErrors
 }
 
 func (opts *ContainerListOptions) Exec(ctx context.Context, c *cli.Config) error {
-	containers, err := c.Build().Containers(opts.Namespace).List(metav1.ListOptions{})
+	listOptions := opts.K8sListOptions()
+	containers, err := c.Build().Containers(opts.Namespace).List(listOptions)
 	if err != nil {
 		return err
 	}
 
-	if len(containers.Items) == 0 {
-		c.Infof("No containers found.\n")
-		return nil
-	}
-
 	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{
 		WithNamespace: opts.AllNamespaces,
+		Wide:          opts.Output == printers.OutputFormatWide,
 	}).With(func(h printers.PrintHandler) {
 		columns := opts.printColumns()
 		h.TableHandler(columns, opts.printList)
@@ -71,7 +67,25 @@ func (opts *ContainerListOptions) Exec(ctx context.Context, c *cli.Config) error
 	containers = containers.DeepCopy()
 	cli.SortByNamespaceAndName(containers.Items)
 
-	return tablePrinter.PrintObj(containers, c.Stdout)
+	if len(containers.Items) == 0 && printers.IsHumanReadable(opts.Output) {
+		c.Infof("No containers found.\n")
+	} else if err := printers.PrintList(opts.Output, tablePrinter, containers, buildv1alpha1.SchemeGroupVersion.WithKind("Container"), c.Stdout); err != nil {
+		return err
+	}
+
+	if opts.Watch {
+		return cli.WatchList(ctx, c, containers, cli.WatchListOptions{
+			Client:       c.Build().RESTClient(),
+			Resource:     "containers",
+			Namespace:    opts.Namespace,
+			ListOptions:  listOptions,
+			Output:       opts.Output,
+			TablePrinter: tablePrinter,
+			ItemKind:     buildv1alpha1.SchemeGroupVersion.WithKind("Container"),
+		})
+	}
+
+	return nil
 }
 
 func NewContainerListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -90,12 +104,17 @@ For detail regarding the status of a single container, run:
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s container list", c.Name),
 			fmt.Sprintf("%s container list %s", c.Name, cli.AllNamespacesFlagName),
+			fmt.Sprintf("%s container list %s json", c.Name, cli.OutputFlagName),
+			fmt.Sprintf("%s container list %s", c.Name, cli.WatchFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.AllNamespacesFlag(cmd, c, &opts.Namespace, &opts.AllNamespaces)
+	cli.OutputFlag(cmd, &opts.Output)
+	cli.WatchFlag(cmd, &opts.Watch)
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
 
 	return cmd
 }
@@ -112,7 +131,7 @@ func (opts *ContainerListOptions) printList(containers *buildv1alpha1.ContainerL
 	return rows, nil
 }
 
-func (opts *ContainerListOptions) print(container *buildv1alpha1.Container, _ printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+func (opts *ContainerListOptions) print(container *buildv1alpha1.Container, printOpts printers.PrintOptions) ([]metav1beta1.TableRow, error) {
 	now := time.Now()
 	row := metav1beta1.TableRow{
 		Object: runtime.RawExtension{Object: container},
@@ -123,6 +142,11 @@ func (opts *ContainerListOptions) print(container *buildv1alpha1.Container, _ pr
 		cli.FormatConditionStatus(container.Status.GetCondition(buildv1alpha1.ContainerConditionReady)),
 		cli.FormatTimestampSince(container.CreationTimestamp, now),
 	)
+	if printOpts.Wide {
+		row.Cells = append(row.Cells,
+			cli.FormatEmptyString(container.Spec.Image),
+		)
+	}
 	return []metav1beta1.TableRow{row}, nil
 }
 
@@ -132,5 +156,6 @@ func (opts *ContainerListOptions) printColumns() []metav1beta1.TableColumnDefini
 		{Name: "Latest Image", Type: "string"},
 		{Name: "Status", Type: "string"},
 		{Name: "Age", Type: "string"},
+		{Name: "Image", Type: "string", Priority: 1},
 	}
 }
@@ -155,6 +155,37 @@ other-namespace   test-other-container   <empty>        <unknown>   <unknown>
 			ExpectOutput: `
 NAME        LATEST IMAGE                              STATUS   AGE
 petclinic   projectriff/petclinic@sah256:abcdef1234   Ready    <unknown>
+`,
+		},
+		{
+			Name: "wide output",
+			Args: []string{cli.OutputFlagName, "wide"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Container{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      containerName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+NAME             LATEST IMAGE   STATUS      AGE         IMAGE
+test-container   <empty>        <unknown>   <unknown>   <empty>
+`,
+		},
+		{
+			Name: "name output",
+			Args: []string{cli.OutputFlagName, "name"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Container{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      containerName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+container/test-container
 `,
 		},
 		{
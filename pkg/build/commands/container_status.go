@@ -23,6 +23,8 @@ import (
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/projectriff/cli/pkg/validation"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -31,6 +33,8 @@ import (
 
 type ContainerStatusOptions struct {
 	options.ResourceOptions
+
+	Output string
 }
 
 var (
@@ -43,6 +47,8 @@ func (opts *ContainerStatusOptions) Validate(ctx context.Context) cli.FieldError
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
 
+	errs = errs.Also(validation.StructuredOutputFormat(opts.Output, cli.OutputFlagName))
+
 	return errs
 }
 
@@ -56,10 +62,23 @@ func (opts *ContainerStatusOptions) Exec(ctx context.Context, c *cli.Config) err
 		return cli.SilenceError(err)
 	}
 
+	if opts.Output != "" {
+		return printers.PrintObject(opts.Output, container, buildv1alpha1.SchemeGroupVersion.WithKind("Container"), c.Stdout)
+	}
+
 	ready := container.Status.GetCondition(buildv1alpha1.ContainerConditionReady)
 	cli.PrintResourceStatus(c, container.Name, ready)
 
-	return nil
+	return cli.PrintResourceStatusDetails(c, cli.ResourceStatusDetails{
+		GroupVersionKind: container.GetGroupVersionKind(),
+		Namespace:        container.Namespace,
+		Name:             container.Name,
+		Conditions:       container.Status.Conditions,
+		Details: []cli.StatusDetail{
+			{Name: "Latest Image", Value: container.Status.LatestImage},
+			{Name: "Target Image", Value: container.Status.TargetImage},
+		},
+	})
 }
 
 func NewContainerStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -75,9 +94,13 @@ The Ready condition is shown which should include a reason code and a
 descriptive message when the status is not "True". The status for the condition
 may be: "True", "False" or "Unknown". An "Unknown" status is common while the
 container is processed or a build is in progress.
+
+Key fields from the status, every condition and the most recent events for
+the container are shown after the Ready condition.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s container status my-container", c.Name),
+			fmt.Sprintf("%s container status my-container %s jsonpath={.status.latestImage}", c.Name, cli.OutputFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -88,6 +111,7 @@ container is processed or a build is in progress.
 	)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cli.StructuredOutputFlag(cmd, &opts.Output)
 
 	return cmd
 }
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/race"
+	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ContainerUpdateOptions struct {
+	options.ResourceOptions
+
+	Image string
+
+	Tail        bool
+	WaitTimeout string
+
+	DryRun bool
+}
+
+var (
+	_ cli.Validatable = (*ContainerUpdateOptions)(nil)
+	_ cli.Executable  = (*ContainerUpdateOptions)(nil)
+	_ cli.DryRunable  = (*ContainerUpdateOptions)(nil)
+)
+
+func (opts *ContainerUpdateOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+
+	if opts.Image == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.ImageFlagName))
+	}
+
+	errs = errs.Also(tailFields{
+		Tail:        opts.Tail,
+		WaitTimeout: opts.WaitTimeout,
+		DryRun:      opts.DryRun,
+	}.Validate(ctx))
+
+	return errs
+}
+
+func (opts *ContainerUpdateOptions) Exec(ctx context.Context, c *cli.Config) error {
+	existing, err := c.Build().Containers(opts.Namespace).Get(opts.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		c.Errorf("Container %q not found\n", fmt.Sprintf("%s/%s", opts.Namespace, opts.Name))
+		return cli.SilenceError(err)
+	}
+
+	container := existing.DeepCopy()
+	container.Spec.Image = opts.Image
+
+	if opts.DryRun {
+		cli.DryRunResource(ctx, container, container.GetGroupVersionKind())
+	} else {
+		container, err = c.Build().Containers(opts.Namespace).Update(container)
+		if err != nil {
+			return err
+		}
+	}
+	c.Successf("Updated container %q\n", container.Name)
+	if opts.Tail {
+		// err guarded by Validate()
+		timeout, _ := time.ParseDuration(opts.WaitTimeout)
+		err := race.Run(ctx, timeout,
+			func(ctx context.Context) error {
+				return k8s.WaitUntilReady(ctx, c.Build().RESTClient(), "containers", container)
+			},
+		)
+		if err == context.DeadlineExceeded {
+			c.Errorf("Timeout after %q waiting for %q to become ready\n", opts.WaitTimeout, opts.Name)
+			c.Infof("To view status run: %s container list %s %s\n", c.Name, cli.NamespaceFlagName, opts.Namespace)
+			c.Infof("To continue watching logs run: %s container tail %s %s %s\n", c.Name, opts.Name, cli.NamespaceFlagName, opts.Namespace)
+			err = cli.SilenceError(err)
+		}
+		if err != nil {
+			return err
+		}
+		c.Successf("Container %q is ready\n", container.Name)
+	}
+	return nil
+}
+
+func (opts *ContainerUpdateOptions) IsDryRun() bool {
+	return opts.DryRun
+}
+
+func NewContainerUpdateCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ContainerUpdateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "change the repository watched for new images",
+		Long: strings.TrimSpace(`
+Update an existing container to watch a different repository for the latest
+image.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s container update my-app %s registry.example.com/image", c.Name, cli.ImageFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+	)
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().StringVar(&opts.Image, cli.StripDash(cli.ImageFlagName), "", "`repository` to watch for the latest image")
+	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch build logs")
+	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the container to become ready when watching logs")
+	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+
+	return cmd
+}
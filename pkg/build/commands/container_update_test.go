@@ -0,0 +1,241 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/build/commands"
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	cachetesting "k8s.io/client-go/tools/cache/testing"
+)
+
+func TestContainerUpdateOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "invalid resource",
+			Options: &commands.ContainerUpdateOptions{
+				ResourceOptions: rifftesting.InvalidResourceOptions,
+			},
+			ExpectFieldErrors: rifftesting.InvalidResourceOptionsFieldError.Also(
+				cli.ErrMissingField(cli.ImageFlagName),
+			),
+		},
+		{
+			Name: "image",
+			Options: &commands.ContainerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "tail",
+			Options: &commands.ContainerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				Tail:            true,
+				WaitTimeout:     "10m",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "tail missing timeout",
+			Options: &commands.ContainerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				Tail:            true,
+			},
+			ExpectFieldErrors: cli.ErrMissingField(cli.WaitTimeoutFlagName),
+		},
+		{
+			Name: "tail invalid timeout",
+			Options: &commands.ContainerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				Tail:            true,
+				WaitTimeout:     "d",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("d", cli.WaitTimeoutFlagName),
+		},
+		{
+			Name: "dry run",
+			Options: &commands.ContainerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				DryRun:          true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "dry run, tail",
+			Options: &commands.ContainerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				Tail:            true,
+				WaitTimeout:     "10m",
+				DryRun:          true,
+			},
+			ExpectFieldErrors: cli.ErrMultipleOneOf(cli.DryRunFlagName, cli.TailFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestContainerUpdateCommand(t *testing.T) {
+	defaultNamespace := "default"
+	containerName := "my-container"
+	imageTag := "registry.example.com/repo:tag"
+	newImageTag := "registry.example.com/new-repo:tag"
+
+	givenContainer := &buildv1alpha1.Container{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      containerName,
+		},
+		Spec: buildv1alpha1.ContainerSpec{
+			Image: imageTag,
+		},
+	}
+	updatedContainer := givenContainer.DeepCopy()
+	updatedContainer.Spec.Image = newImageTag
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name: "image",
+			Args: []string{containerName, cli.ImageFlagName, newImageTag},
+			GivenObjects: []runtime.Object{
+				givenContainer.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				updatedContainer.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated container "my-container"
+`,
+		},
+		{
+			Name: "image dry run",
+			Args: []string{containerName, cli.ImageFlagName, newImageTag, cli.DryRunFlagName},
+			GivenObjects: []runtime.Object{
+				givenContainer.DeepCopy(),
+			},
+			ExpectOutput: `
+---
+apiVersion: build.projectriff.io/v1alpha1
+kind: Container
+metadata:
+  creationTimestamp: null
+  name: my-container
+  namespace: default
+spec:
+  image: registry.example.com/new-repo:tag
+status: {}
+
+Updated container "my-container"
+`,
+		},
+		{
+			Name: "not found",
+			Args: []string{containerName, cli.ImageFlagName, newImageTag},
+			ExpectOutput: `
+Container "default/my-container" not found
+`,
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if actual := err; !errors.Is(err, cli.SilentError) {
+					t.Errorf("expected error to be silent, actual %#v", actual)
+				}
+			},
+		},
+		{
+			Name: "get error",
+			Args: []string{containerName, cli.ImageFlagName, newImageTag},
+			GivenObjects: []runtime.Object{
+				givenContainer.DeepCopy(),
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "containers"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "error during update",
+			Args: []string{containerName, cli.ImageFlagName, newImageTag},
+			GivenObjects: []runtime.Object{
+				givenContainer.DeepCopy(),
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("update", "containers"),
+			},
+			ExpectUpdates: []runtime.Object{
+				updatedContainer.DeepCopy(),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "tail timeout",
+			Args: []string{containerName, cli.ImageFlagName, newImageTag, cli.TailFlagName, cli.WaitTimeoutFlagName, "5ms"},
+			GivenObjects: []runtime.Object{
+				givenContainer.DeepCopy(),
+			},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				lw := cachetesting.NewFakeControllerSource()
+				ctx = k8s.WithListerWatcher(ctx, lw)
+
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*cachetesting.FakeControllerSource); ok {
+					lw.Shutdown()
+				}
+
+				return nil
+			},
+			ExpectUpdates: []runtime.Object{
+				updatedContainer.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated container "my-container"
+Timeout after "5ms" waiting for "my-container" to become ready
+To view status run: riff container list --namespace default
+To continue watching logs run: riff container tail my-container --namespace default
+`,
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if actual := err; !errors.Is(err, cli.SilentError) {
+					t.Errorf("expected error to be silent, actual %#v", actual)
+				}
+			},
+		},
+	}
+
+	table.Run(t, commands.NewContainerUpdateCommand)
+}
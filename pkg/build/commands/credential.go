@@ -18,10 +18,23 @@ package commands
 
 import (
 	"context"
+	"net/url"
 	"strings"
 
 	"github.com/projectriff/cli/pkg/cli"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	riffBuildConfigMapName = "riff-build"
+	defaultImagePrefixKey  = "default-image-prefix"
+
+	credentialRegistryAnnotationKey = "build.pivotal.io/docker"
+	knativeRegistryAnnotationPrefix = "build.knative.dev/docker-"
 )
 
 func NewCredentialCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -49,6 +62,98 @@ namespace. To manage the default image prefix, read and write access to the
 	cmd.AddCommand(NewCredentialListCommand(ctx, c))
 	cmd.AddCommand(NewCredentialApplyCommand(ctx, c))
 	cmd.AddCommand(NewCredentialDeleteCommand(ctx, c))
+	cmd.AddCommand(NewCredentialStatusCommand(ctx, c))
 
 	return cmd
 }
+
+// credentialSelector restricts the label selector to secrets that are
+// credentials.
+func credentialSelector(selector string) string {
+	if selector == "" {
+		return buildv1alpha1.CredentialLabelKey
+	}
+	return buildv1alpha1.CredentialLabelKey + "," + selector
+}
+
+// redactCredential removes the username and password from a credential, and
+// any copy of them kept by kubectl, so the credential is safe to print.
+func redactCredential(credential *corev1.Secret) {
+	credential.Data = nil
+	credential.StringData = nil
+	delete(credential.Annotations, corev1.LastAppliedConfigAnnotation)
+}
+
+// credentialRegistry is the url of the registry for the credential.
+func credentialRegistry(credential *corev1.Secret) string {
+	return credential.Annotations[credentialRegistryAnnotationKey]
+}
+
+// credentialRegistryHost is the host of the registry for the credential, for
+// example "gcr.io".
+func credentialRegistryHost(credential *corev1.Secret) string {
+	return registryHost(credentialRegistry(credential))
+}
+
+// credentialDefaultImagePrefix returns the default image prefix when the image
+// prefix is for a registry the credential authenticates to, otherwise an empty
+// string.
+func credentialDefaultImagePrefix(credential *corev1.Secret, defaultImagePrefix string) string {
+	if defaultImagePrefix == "" {
+		return ""
+	}
+	prefixHost := normalizeRegistryHost(imageRegistryHost(defaultImagePrefix))
+	for key, value := range credential.Annotations {
+		if key != credentialRegistryAnnotationKey && !strings.HasPrefix(key, knativeRegistryAnnotationPrefix) {
+			continue
+		}
+		if normalizeRegistryHost(registryHost(value)) == prefixHost {
+			return defaultImagePrefix
+		}
+	}
+	return ""
+}
+
+// getDefaultImagePrefix is the default image prefix for the namespace from the
+// riff-build ConfigMap. A missing or inaccessible ConfigMap has no prefix.
+func getDefaultImagePrefix(c *cli.Config, namespace string) (string, error) {
+	riffBuildConfig, err := c.Core().ConfigMaps(namespace).Get(riffBuildConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if apierrs.IsNotFound(err) || apierrs.IsForbidden(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return riffBuildConfig.Data[defaultImagePrefixKey], nil
+}
+
+// registryHost is the host for a registry url. The scheme is optional.
+func registryHost(registry string) string {
+	if !strings.Contains(registry, "://") {
+		registry = "https://" + registry
+	}
+	u, err := url.Parse(registry)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// imageRegistryHost is the registry host for an image repository, images
+// without a registry host are from Docker Hub.
+func imageRegistryHost(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 1 || (!strings.ContainsAny(parts[0], ".:") && parts[0] != "localhost") {
+		return "docker.io"
+	}
+	return parts[0]
+}
+
+// normalizeRegistryHost collapses the hosts for Docker Hub.
+func normalizeRegistryHost(host string) string {
+	switch host {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+	return host
+}
@@ -273,10 +273,7 @@ func makeCredential(opts *CredentialApplyOptions) (*corev1.Secret, string, error
 }
 
 func setDefaultImagePrefix(ctx context.Context, c *cli.Config, opts *CredentialApplyOptions, defaultImagePrefix string) error {
-	configMapName := "riff-build"
-	defaultImagePrefixKey := "default-image-prefix"
-
-	riffBuildConfig, err := c.Core().ConfigMaps(opts.Namespace).Get(configMapName, metav1.GetOptions{})
+	riffBuildConfig, err := c.Core().ConfigMaps(opts.Namespace).Get(riffBuildConfigMapName, metav1.GetOptions{})
 	if err != nil {
 		if !apierrs.IsNotFound(err) {
 			return err
@@ -286,7 +283,7 @@ func setDefaultImagePrefix(ctx context.Context, c *cli.Config, opts *CredentialA
 		riffBuildConfig := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: opts.Namespace,
-				Name:      configMapName,
+				Name:      riffBuildConfigMapName,
 			},
 			Data: map[string]string{
 				defaultImagePrefixKey: defaultImagePrefix,
@@ -20,12 +20,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
-	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 type CredentialDeleteOptions struct {
@@ -47,16 +47,25 @@ func (opts *CredentialDeleteOptions) Validate(ctx context.Context) cli.FieldErro
 
 func (opts *CredentialDeleteOptions) Exec(ctx context.Context, c *cli.Config) error {
 	client := c.Core().Secrets(opts.Namespace)
+	deleted := options.DeletedResource{
+		Client:   c.Core().RESTClient(),
+		Resource: "secrets",
+		ObjType:  &corev1.Secret{},
+	}
 
 	if opts.All {
-		err := client.DeleteCollection(nil, metav1.ListOptions{
-			LabelSelector: buildv1alpha1.CredentialLabelKey,
-		})
+		listOptions := opts.K8sListOptions()
+		listOptions.LabelSelector = credentialSelector(listOptions.LabelSelector)
+		names, err := opts.DeletedNames(ctx, deleted, listOptions)
+		if err != nil {
+			return err
+		}
+		err = client.DeleteCollection(nil, listOptions)
 		if err != nil {
 			return err
 		}
 		c.Successf("Deleted credentials in namespace %q\n", opts.Namespace)
-		return nil
+		return opts.WaitForDeletion(ctx, c, deleted, names)
 	}
 
 	for _, name := range opts.Names {
@@ -67,7 +76,7 @@ func (opts *CredentialDeleteOptions) Exec(ctx context.Context, c *cli.Config) er
 		c.Successf("Deleted credential %q\n", name)
 	}
 
-	return nil
+	return opts.WaitForDeletion(ctx, c, deleted, opts.Names)
 }
 
 func NewCredentialDeleteCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -84,7 +93,9 @@ unless another credential for the same registry is available.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s credential delete my-creds", c.Name),
+			fmt.Sprintf("%s credential delete my-creds %s", c.Name, cli.WaitFlagName),
 			fmt.Sprintf("%s credential delete %s ", c.Name, cli.AllFlagName),
+			fmt.Sprintf("%s credential delete %s %s team=payments", c.Name, cli.AllFlagName, cli.SelectorFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -96,6 +107,9 @@ unless another credential for the same registry is available.
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all credentials within the namespace")
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
+	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(cli.WaitFlagName), false, "wait for the credentials to be removed")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), time.Minute, "`duration` to wait for the credentials to be removed")
 
 	return cmd
 }
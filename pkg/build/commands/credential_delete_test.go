@@ -81,6 +81,28 @@ func TestCredentialDeleteCommand(t *testing.T) {
 			}},
 			ExpectOutput: `
 Deleted credentials in namespace "default"
+`,
+		},
+		{
+			Name: "delete all secrets with selector",
+			Args: []string{cli.AllFlagName, cli.SelectorFlagName, "team=payments"},
+			GivenObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      credentialName,
+						Namespace: defaultNamespace,
+						Labels:    map[string]string{credentialLabel: ""},
+					},
+					StringData: map[string]string{},
+				},
+			},
+			ExpectDeleteCollections: []rifftesting.DeleteCollectionRef{{
+				Resource:      "secrets",
+				Namespace:     defaultNamespace,
+				LabelSelector: credentialLabel + ",team=payments",
+			}},
+			ExpectOutput: `
+Deleted credentials in namespace "default"
 `,
 		},
 		{
@@ -28,13 +28,15 @@ import (
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type CredentialListOptions struct {
 	options.ListOptions
+
+	// defaultImagePrefixes by namespace
+	defaultImagePrefixes map[string]string
 }
 
 var (
@@ -51,20 +53,16 @@ func (opts *CredentialListOptions) Validate(ctx context.Context) cli.FieldErrors
 }
 
 func (opts *CredentialListOptions) Exec(ctx context.Context, c *cli.Config) error {
-	secrets, err := c.Core().Secrets(opts.Namespace).List(metav1.ListOptions{
-		LabelSelector: buildv1alpha1.CredentialLabelKey,
-	})
+	listOptions := opts.K8sListOptions()
+	listOptions.LabelSelector = credentialSelector(listOptions.LabelSelector)
+	secrets, err := c.Core().Secrets(opts.Namespace).List(listOptions)
 	if err != nil {
 		return err
 	}
 
-	if len(secrets.Items) == 0 {
-		c.Infof("No credentials found.\n")
-		return nil
-	}
-
 	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{
 		WithNamespace: opts.AllNamespaces,
+		Wide:          opts.Output == printers.OutputFormatWide,
 	}).With(func(h printers.PrintHandler) {
 		columns := opts.printColumns()
 		h.TableHandler(columns, opts.printList)
@@ -73,8 +71,50 @@ func (opts *CredentialListOptions) Exec(ctx context.Context, c *cli.Config) erro
 
 	secrets = secrets.DeepCopy()
 	cli.SortByNamespaceAndName(secrets.Items)
+	for i := range secrets.Items {
+		redactCredential(&secrets.Items[i])
+	}
+
+	opts.defaultImagePrefixes = map[string]string{}
+	if !opts.AllNamespaces {
+		// the namespace is known up front, credentials may be added while watching
+		if opts.defaultImagePrefixes[opts.Namespace], err = getDefaultImagePrefix(c, opts.Namespace); err != nil {
+			return err
+		}
+	}
+	for _, secret := range secrets.Items {
+		if _, ok := opts.defaultImagePrefixes[secret.Namespace]; ok {
+			continue
+		}
+		if opts.defaultImagePrefixes[secret.Namespace], err = getDefaultImagePrefix(c, secret.Namespace); err != nil {
+			return err
+		}
+	}
+
+	if len(secrets.Items) == 0 && printers.IsHumanReadable(opts.Output) {
+		c.Infof("No credentials found.\n")
+	} else if err := printers.PrintList(opts.Output, tablePrinter, secrets, corev1.SchemeGroupVersion.WithKind("Secret"), c.Stdout); err != nil {
+		return err
+	}
+
+	if opts.Watch {
+		return cli.WatchList(ctx, c, secrets, cli.WatchListOptions{
+			Client:       c.Core().RESTClient(),
+			Resource:     "secrets",
+			Namespace:    opts.Namespace,
+			ListOptions:  listOptions,
+			Output:       opts.Output,
+			TablePrinter: tablePrinter,
+			ItemKind:     corev1.SchemeGroupVersion.WithKind("Secret"),
+			Redact: func(obj runtime.Object) {
+				if credential, ok := obj.(*corev1.Secret); ok {
+					redactCredential(credential)
+				}
+			},
+		})
+	}
 
-	return tablePrinter.PrintObj(secrets, c.Stdout)
+	return nil
 }
 
 func NewCredentialListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -85,16 +125,27 @@ func NewCredentialListCommand(ctx context.Context, c *cli.Config) *cobra.Command
 		Short: "table listing of credentials",
 		Long: strings.TrimSpace(`
 List credentials in a namespace or across all namespaces.
+
+For each credential the type, the registry host and the namespace's default
+image prefix, when the prefix is for the credential's registry, are shown.
+
+Structured output includes the credential's secret without the username and
+password.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s credential list", c.Name),
 			fmt.Sprintf("%s credential list %s", c.Name, cli.AllNamespacesFlagName),
+			fmt.Sprintf("%s credential list %s json", c.Name, cli.OutputFlagName),
+			fmt.Sprintf("%s credential list %s", c.Name, cli.WatchFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.AllNamespacesFlag(cmd, c, &opts.Namespace, &opts.AllNamespaces)
+	cli.OutputFlag(cmd, &opts.Output)
+	cli.WatchFlag(cmd, &opts.Watch)
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
 
 	return cmd
 }
@@ -119,7 +170,8 @@ func (opts *CredentialListOptions) print(credential *corev1.Secret, _ printers.P
 	row.Cells = append(row.Cells,
 		credential.Name,
 		credential.Labels[buildv1alpha1.CredentialLabelKey],
-		credential.Annotations["build.pivotal.io/docker"],
+		cli.FormatEmptyString(credentialRegistryHost(credential)),
+		cli.FormatEmptyString(credentialDefaultImagePrefix(credential, opts.defaultImagePrefixes[credential.Namespace])),
 		cli.FormatTimestampSince(credential.CreationTimestamp, now),
 	)
 	return []metav1beta1.TableRow{row}, nil
@@ -130,6 +182,7 @@ func (opts *CredentialListOptions) printColumns() []metav1beta1.TableColumnDefin
 		{Name: "Name", Type: "string"},
 		{Name: "Type", Type: "string"},
 		{Name: "Registry", Type: "string"},
+		{Name: "Default Image Prefix", Type: "string"},
 		{Name: "Age", Type: "string"},
 	}
 }
@@ -18,15 +18,20 @@ package commands_test
 
 import (
 	"context"
+	"encoding/base64"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/projectriff/cli/pkg/build/commands"
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	cachetesting "k8s.io/client-go/tools/cache/testing"
 )
 
 func TestCredentialListOptions(t *testing.T) {
@@ -56,6 +61,7 @@ func TestCredentialListCommand(t *testing.T) {
 	defaultNamespace := "default"
 	otherNamespace := "other-namespace"
 	credentialLabel := buildv1alpha1.CredentialLabelKey
+	password := "my-password"
 
 	table := rifftesting.CommandTable{
 		{
@@ -92,8 +98,8 @@ No credentials found.
 				},
 			},
 			ExpectOutput: `
-NAME              TYPE         REGISTRY                      AGE
-test-credential   docker-hub   https://index.docker.io/v1/   <unknown>
+NAME              TYPE         REGISTRY          DEFAULT IMAGE PREFIX   AGE
+test-credential   docker-hub   index.docker.io   <empty>                <unknown>
 `,
 		},
 		{
@@ -114,12 +120,53 @@ test-credential   docker-hub   https://index.docker.io/v1/   <unknown>
 			},
 			ExpectOutput: `
 No credentials found.
+`,
+		},
+		{
+			Name: "filters by selector",
+			Args: []string{cli.SelectorFlagName, credentialLabel + "=gcr"},
+			GivenObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      credentialName,
+						Namespace: defaultNamespace,
+						Labels:    map[string]string{credentialLabel: "docker-hub"},
+						Annotations: map[string]string{
+							"build.knative.dev/docker-0": "https://index.docker.io/v1/",
+							"build.pivotal.io/docker":    "https://index.docker.io/v1/",
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "gcr",
+						Namespace: defaultNamespace,
+						Labels:    map[string]string{credentialLabel: "gcr"},
+						Annotations: map[string]string{
+							"build.knative.dev/docker-0": "https://gcr.io",
+							"build.pivotal.io/docker":    "https://gcr.io",
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+NAME   TYPE   REGISTRY   DEFAULT IMAGE PREFIX   AGE
+gcr    gcr    gcr.io     <empty>                <unknown>
 `,
 		},
 		{
 			Name: "table populates all columns",
 			Args: []string{},
 			GivenObjects: []runtime.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "riff-build",
+						Namespace: defaultNamespace,
+					},
+					Data: map[string]string{
+						"default-image-prefix": "gcr.io/my-project",
+					},
+				},
 				&corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "registry",
@@ -155,16 +202,25 @@ No credentials found.
 				},
 			},
 			ExpectOutput: `
-NAME         TYPE         REGISTRY                        AGE
-docker-hub   docker-hub   https://index.docker.io/v1/     <unknown>
-gcr          gcr          https://gcr.io                  <unknown>
-registry     basic-auth   https://registry.example.com/   <unknown>
+NAME         TYPE         REGISTRY               DEFAULT IMAGE PREFIX   AGE
+docker-hub   docker-hub   index.docker.io        <empty>                <unknown>
+gcr          gcr          gcr.io                 gcr.io/my-project      <unknown>
+registry     basic-auth   registry.example.com   <empty>                <unknown>
 `,
 		},
 		{
 			Name: "all namespace",
 			Args: []string{cli.AllNamespacesFlagName},
 			GivenObjects: []runtime.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "riff-build",
+						Namespace: otherNamespace,
+					},
+					Data: map[string]string{
+						"default-image-prefix": "docker.io/my-user",
+					},
+				},
 				&corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      credentialName,
@@ -189,9 +245,9 @@ registry     basic-auth   https://registry.example.com/   <unknown>
 				},
 			},
 			ExpectOutput: `
-NAMESPACE         NAME                    TYPE         REGISTRY                      AGE
-default           test-credential         docker-hub   https://index.docker.io/v1/   <unknown>
-other-namespace   test-other-credential   docker-hub   https://index.docker.io/v1/   <unknown>
+NAMESPACE         NAME                    TYPE         REGISTRY          DEFAULT IMAGE PREFIX   AGE
+default           test-credential         docker-hub   index.docker.io   <empty>                <unknown>
+other-namespace   test-other-credential   docker-hub   index.docker.io   docker.io/my-user      <unknown>
 `,
 		},
 		{
@@ -209,6 +265,122 @@ other-namespace   test-other-credential   docker-hub   https://index.docker.io/v
 No credentials found.
 `,
 		},
+		{
+			Name: "name output",
+			Args: []string{cli.OutputFlagName, "name"},
+			GivenObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      credentialName,
+						Namespace: defaultNamespace,
+						Labels:    map[string]string{credentialLabel: "docker-hub"},
+						Annotations: map[string]string{
+							"build.knative.dev/docker-0": "https://index.docker.io/v1/",
+							"build.pivotal.io/docker":    "https://index.docker.io/v1/",
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+secret/test-credential
+`,
+		},
+		{
+			Name: "json output redacts password",
+			Args: []string{cli.OutputFlagName, "json"},
+			GivenObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      credentialName,
+						Namespace: defaultNamespace,
+						Labels:    map[string]string{credentialLabel: "basic-auth"},
+						Annotations: map[string]string{
+							"build.knative.dev/docker-0": "https://registry.example.com",
+							"build.pivotal.io/docker":    "https://registry.example.com",
+						},
+					},
+					Type: corev1.SecretTypeBasicAuth,
+					Data: map[string][]byte{
+						"username": []byte("my-user"),
+						"password": []byte(password),
+					},
+				},
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if !strings.Contains(output, credentialName) {
+					t.Errorf("expected output to include the credential, actually %q", output)
+				}
+				for _, value := range []string{password, base64.StdEncoding.EncodeToString([]byte(password))} {
+					if strings.Contains(output, value) {
+						t.Errorf("expected output to not include the password, actually %q", output)
+					}
+				}
+			},
+		},
+		{
+			Name: "watch yaml output redacts password",
+			Args: []string{cli.OutputFlagName, "yaml", cli.WatchFlagName},
+			GivenObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      credentialName,
+						Namespace: defaultNamespace,
+						Labels:    map[string]string{credentialLabel: "basic-auth"},
+						Annotations: map[string]string{
+							"build.knative.dev/docker-0": "https://registry.example.com",
+							"build.pivotal.io/docker":    "https://registry.example.com",
+						},
+					},
+					Type: corev1.SecretTypeBasicAuth,
+					Data: map[string][]byte{
+						"username": []byte("my-user"),
+						"password": []byte(password),
+					},
+				},
+			},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				lw := cachetesting.NewFakeControllerSource()
+				ctx = k8s.WithListerWatcher(ctx, lw)
+				ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+				go func() {
+					defer cancel()
+					lw.Add(&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      credentialName,
+							Namespace: defaultNamespace,
+							Labels:    map[string]string{credentialLabel: "basic-auth"},
+							Annotations: map[string]string{
+								"build.knative.dev/docker-0": "https://registry.example.com",
+								"build.pivotal.io/docker":    "https://registry.example.com",
+							},
+						},
+						Type: corev1.SecretTypeBasicAuth,
+						Data: map[string][]byte{
+							"username": []byte("my-user"),
+							"password": []byte(password),
+						},
+					})
+					<-ctx.Done()
+				}()
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*cachetesting.FakeControllerSource); ok {
+					lw.Shutdown()
+				}
+				return nil
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if !strings.Contains(output, credentialName) {
+					t.Errorf("expected output to include the credential, actually %q", output)
+				}
+				for _, value := range []string{password, base64.StdEncoding.EncodeToString([]byte(password))} {
+					if strings.Contains(output, value) {
+						t.Errorf("expected output to not include the password, actually %q", output)
+					}
+				}
+			},
+		},
 		{
 			Name: "list error",
 			Args: []string{},
@@ -217,6 +389,27 @@ No credentials found.
 			},
 			ShouldError: true,
 		},
+		{
+			Name: "get riff-build error",
+			Args: []string{},
+			GivenObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      credentialName,
+						Namespace: defaultNamespace,
+						Labels:    map[string]string{credentialLabel: "docker-hub"},
+						Annotations: map[string]string{
+							"build.knative.dev/docker-0": "https://index.docker.io/v1/",
+							"build.pivotal.io/docker":    "https://index.docker.io/v1/",
+						},
+					},
+				},
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "configmaps"),
+			},
+			ShouldError: true,
+		},
 	}
 
 	table.Run(t, commands.NewCredentialListCommand)
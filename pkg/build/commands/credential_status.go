@@ -0,0 +1,298 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/system/pkg/apis"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CredentialStatusOptions struct {
+	options.ResourceOptions
+}
+
+var (
+	_ cli.Validatable = (*CredentialStatusOptions)(nil)
+	_ cli.Executable  = (*CredentialStatusOptions)(nil)
+)
+
+func (opts *CredentialStatusOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+
+	return errs
+}
+
+func (opts *CredentialStatusOptions) Exec(ctx context.Context, c *cli.Config) error {
+	credential, err := c.Core().Secrets(opts.Namespace).Get(opts.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		c.Errorf("Credential %q not found\n", fmt.Sprintf("%s/%s", opts.Namespace, opts.Name))
+		return cli.SilenceError(err)
+	}
+	if _, ok := credential.Labels[buildv1alpha1.CredentialLabelKey]; !ok {
+		return fmt.Errorf("credential %q exists, but is not owned by riff", opts.Name)
+	}
+
+	defaultImagePrefix, err := getDefaultImagePrefix(c, opts.Namespace)
+	if err != nil {
+		return err
+	}
+
+	registry := credentialRegistry(credential)
+	username := string(credential.Data[corev1.BasicAuthUsernameKey])
+	password := string(credential.Data[corev1.BasicAuthPasswordKey])
+
+	var ready *apis.Condition
+	switch {
+	case registry == "":
+		ready = credentialCondition(corev1.ConditionFalse, "InvalidCredential", "credential does not define a registry")
+	case username == "" || password == "":
+		ready = credentialCondition(corev1.ConditionFalse, "InvalidCredential", "credential does not define a username and password")
+	default:
+		ready = registryHandshake(ctx, registry, username, password)
+	}
+
+	cli.PrintResourceStatus(c, credential.Name, ready)
+	cli.PrintStatusDetails(c, []cli.StatusDetail{
+		{Name: "Type", Value: credential.Labels[buildv1alpha1.CredentialLabelKey]},
+		{Name: "Registry", Value: registry},
+		{Name: "Username", Value: username},
+		{Name: "Default Image Prefix", Value: credentialDefaultImagePrefix(credential, defaultImagePrefix)},
+	})
+
+	if ready.Status != corev1.ConditionTrue {
+		return cli.SilenceError(fmt.Errorf("credential %q is not able to authenticate: %s", opts.Name, ready.Message))
+	}
+	return nil
+}
+
+func NewCredentialStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &CredentialStatusOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "verify a credential authenticates to its registry",
+		Long: strings.TrimSpace(`
+Display status details for a credential.
+
+The username and password are read from the credential and used to
+authenticate to the registry with the same handshake as 'docker login',
+requesting a token for the registry's /v2/ API when the registry requires one.
+The password is never displayed.
+
+The Ready condition is "True" when the registry accepts the credential, or the
+registry does not require authentication. Otherwise the reason and message
+describe the failure and the command exits with a non-zero status.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s credential status my-creds", c.Name),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+	)
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+
+	return cmd
+}
+
+func credentialCondition(status corev1.ConditionStatus, reason, message string) *apis.Condition {
+	return &apis.Condition{
+		Type:    apis.ConditionReady,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// registryClient is used for requests to registries, the default transport
+// honors proxy environment variables
+var registryClient = &http.Client{Timeout: 30 * time.Second}
+
+// registryChallengeParam matches the key="value" parameters of a
+// WWW-Authenticate header
+var registryChallengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// registryHandshake authenticates to the registry's /v2/ API. Registries that
+// challenge for a bearer token are sent the username and password to request a
+// token, which is then used for the API. Registries that challenge for basic
+// auth are sent the username and password directly.
+func registryHandshake(ctx context.Context, registry, username, password string) *apis.Condition {
+	if !strings.Contains(registry, "://") {
+		registry = "https://" + registry
+	}
+	u, err := url.Parse(registry)
+	if err != nil {
+		return credentialCondition(corev1.ConditionFalse, "InvalidCredential", err.Error())
+	}
+	api := fmt.Sprintf("%s://%s/v2/", u.Scheme, u.Host)
+
+	res, err := registryRequest(ctx, api, nil)
+	if err != nil {
+		return credentialCondition(corev1.ConditionFalse, "RegistryUnreachable", err.Error())
+	}
+	switch res.StatusCode {
+	case http.StatusOK:
+		return credentialCondition(corev1.ConditionTrue, "AuthenticationNotRequired", fmt.Sprintf("registry %s does not require authentication", u.Host))
+	case http.StatusUnauthorized:
+		// the expected challenge
+	default:
+		return credentialCondition(corev1.ConditionFalse, "UnsupportedRegistry", fmt.Sprintf("registry responded to %s with %q", api, res.Status))
+	}
+
+	challenge := res.Header.Get("WWW-Authenticate")
+	authorization := ""
+	switch scheme := strings.ToLower(strings.SplitN(challenge, " ", 2)[0]); scheme {
+	case "basic":
+		authorization = basicAuthorization(username, password)
+	case "bearer":
+		params := map[string]string{}
+		for _, match := range registryChallengeParam.FindAllStringSubmatch(challenge, -1) {
+			params[strings.ToLower(match[1])] = match[2]
+		}
+		token, condition := registryToken(ctx, params["realm"], params["service"], username, password)
+		if condition != nil {
+			return condition
+		}
+		authorization = "Bearer " + token
+	default:
+		return credentialCondition(corev1.ConditionFalse, "UnsupportedRegistry", fmt.Sprintf("registry responded with an unsupported challenge %q", challenge))
+	}
+
+	res, err = registryRequest(ctx, api, func(req *http.Request) {
+		req.Header.Set("Authorization", authorization)
+	})
+	if err != nil {
+		return credentialCondition(corev1.ConditionFalse, "RegistryUnreachable", err.Error())
+	}
+	switch res.StatusCode {
+	case http.StatusOK:
+		return credentialCondition(corev1.ConditionTrue, "Authenticated", fmt.Sprintf("authenticated to registry %s as %q", u.Host, username))
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return credentialCondition(corev1.ConditionFalse, "Unauthorized", fmt.Sprintf("registry %s rejected the username and password", u.Host))
+	default:
+		return credentialCondition(corev1.ConditionFalse, "UnsupportedRegistry", fmt.Sprintf("registry responded to %s with %q", api, res.Status))
+	}
+}
+
+// registryToken requests a bearer token from the realm of a registry's
+// challenge. A condition is returned when a token is not issued.
+func registryToken(ctx context.Context, realm, service, username, password string) (string, *apis.Condition) {
+	tokenURL, err := url.Parse(realm)
+	if err != nil || realm == "" {
+		return "", credentialCondition(corev1.ConditionFalse, "UnsupportedRegistry", fmt.Sprintf("registry responded with an invalid token realm %q", realm))
+	}
+	query := tokenURL.Query()
+	if service != "" {
+		query.Set("service", service)
+	}
+	query.Set("account", username)
+	tokenURL.RawQuery = query.Encode()
+
+	res, err := registryRequest(ctx, tokenURL.String(), func(req *http.Request) {
+		req.Header.Set("Authorization", basicAuthorization(username, password))
+	})
+	if err != nil {
+		return "", credentialCondition(corev1.ConditionFalse, "RegistryUnreachable", err.Error())
+	}
+	switch res.StatusCode {
+	case http.StatusOK:
+		// the token is in the body
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", credentialCondition(corev1.ConditionFalse, "Unauthorized", fmt.Sprintf("token service %s rejected the username and password", tokenURL.Host))
+	default:
+		return "", credentialCondition(corev1.ConditionFalse, "UnsupportedRegistry", fmt.Sprintf("token service %s responded with %q", tokenURL.Host, res.Status))
+	}
+
+	body := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.Unmarshal(res.Body, &body); err != nil {
+		return "", credentialCondition(corev1.ConditionFalse, "UnsupportedRegistry", fmt.Sprintf("token service %s responded with an invalid token: %s", tokenURL.Host, err))
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", credentialCondition(corev1.ConditionFalse, "UnsupportedRegistry", fmt.Sprintf("token service %s did not issue a token", tokenURL.Host))
+}
+
+type registryResponse struct {
+	Status     string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// registryRequest sends a GET request and reads the response body
+func registryRequest(ctx context.Context, location string, prepare func(*http.Request)) (*registryResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	if prepare != nil {
+		prepare(req)
+	}
+	res, err := registryClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	return &registryResponse{
+		Status:     res.Status,
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Body:       body,
+	}, nil
+}
+
+func basicAuthorization(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
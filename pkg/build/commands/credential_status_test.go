@@ -0,0 +1,370 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/build/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCredentialStatusOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "invalid resource",
+			Options: &commands.CredentialStatusOptions{
+				ResourceOptions: rifftesting.InvalidResourceOptions,
+			},
+			ExpectFieldErrors: rifftesting.InvalidResourceOptionsFieldError,
+		},
+		{
+			Name: "valid resource",
+			Options: &commands.CredentialStatusOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+			},
+			ShouldValidate: true,
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestCredentialStatusCommand(t *testing.T) {
+	defaultNamespace := "default"
+	credentialName := "my-creds"
+	credentialLabel := buildv1alpha1.CredentialLabelKey
+	username := "my-user"
+	password := "my-password"
+
+	bearerRegistry := newRegistryStandIn("bearer", username, password)
+	defer bearerRegistry.Close()
+	basicRegistry := newRegistryStandIn("basic", username, password)
+	defer basicRegistry.Close()
+	anonymousRegistry := newRegistryStandIn("anonymous", username, password)
+	defer anonymousRegistry.Close()
+	notARegistry := newRegistryStandIn("", username, password)
+	defer notARegistry.Close()
+	unreachableRegistry := newRegistryStandIn("anonymous", username, password)
+	unreachableRegistry.Close()
+
+	credential := func(registry, username, password string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: defaultNamespace,
+				Name:      credentialName,
+				Labels:    map[string]string{credentialLabel: "basic-auth"},
+				Annotations: map[string]string{
+					"build.knative.dev/docker-0": registry,
+					"build.pivotal.io/docker":    registry,
+				},
+			},
+			Type: corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{
+				"username": []byte(username),
+				"password": []byte(password),
+			},
+		}
+	}
+	host := func(server *httptest.Server) string {
+		return strings.TrimPrefix(server.URL, "http://")
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name: "bearer token",
+			Args: []string{credentialName},
+			GivenObjects: []runtime.Object{
+				credential(bearerRegistry.URL, username, password),
+			},
+			ExpectOutput: fmt.Sprintf(`
+# my-creds: Ready
+---
+lastTransitionTime: null
+message: authenticated to registry %s as "my-user"
+reason: Authenticated
+status: "True"
+type: Ready
+
+Details:
+  Type:       basic-auth
+  Registry:   %s
+  Username:   my-user
+`, host(bearerRegistry), bearerRegistry.URL),
+		},
+		{
+			Name: "bearer token, rejected",
+			Args: []string{credentialName},
+			GivenObjects: []runtime.Object{
+				credential(bearerRegistry.URL, username, "bad-password"),
+			},
+			ShouldError: true,
+			ExpectOutput: fmt.Sprintf(`
+# my-creds: Unauthorized
+---
+lastTransitionTime: null
+message: token service %s rejected the username and password
+reason: Unauthorized
+status: "False"
+type: Ready
+
+Details:
+  Type:       basic-auth
+  Registry:   %s
+  Username:   my-user
+`, host(bearerRegistry), bearerRegistry.URL),
+		},
+		{
+			Name: "basic auth",
+			Args: []string{credentialName},
+			GivenObjects: []runtime.Object{
+				credential(basicRegistry.URL, username, password),
+			},
+			ExpectOutput: fmt.Sprintf(`
+# my-creds: Ready
+---
+lastTransitionTime: null
+message: authenticated to registry %s as "my-user"
+reason: Authenticated
+status: "True"
+type: Ready
+
+Details:
+  Type:       basic-auth
+  Registry:   %s
+  Username:   my-user
+`, host(basicRegistry), basicRegistry.URL),
+		},
+		{
+			Name: "basic auth, rejected",
+			Args: []string{credentialName},
+			GivenObjects: []runtime.Object{
+				credential(basicRegistry.URL, username, "bad-password"),
+			},
+			ShouldError: true,
+			ExpectOutput: fmt.Sprintf(`
+# my-creds: Unauthorized
+---
+lastTransitionTime: null
+message: registry %s rejected the username and password
+reason: Unauthorized
+status: "False"
+type: Ready
+
+Details:
+  Type:       basic-auth
+  Registry:   %s
+  Username:   my-user
+`, host(basicRegistry), basicRegistry.URL),
+		},
+		{
+			Name: "anonymous registry",
+			Args: []string{credentialName},
+			GivenObjects: []runtime.Object{
+				credential(anonymousRegistry.URL, username, password),
+			},
+			ExpectOutput: fmt.Sprintf(`
+# my-creds: Ready
+---
+lastTransitionTime: null
+message: registry %s does not require authentication
+reason: AuthenticationNotRequired
+status: "True"
+type: Ready
+
+Details:
+  Type:       basic-auth
+  Registry:   %s
+  Username:   my-user
+`, host(anonymousRegistry), anonymousRegistry.URL),
+		},
+		{
+			Name: "default image prefix",
+			Args: []string{credentialName},
+			GivenObjects: []runtime.Object{
+				credential(bearerRegistry.URL, username, password),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "riff-build",
+					},
+					Data: map[string]string{
+						"default-image-prefix": host(bearerRegistry) + "/my-user",
+					},
+				},
+			},
+			ExpectOutput: fmt.Sprintf(`
+# my-creds: Ready
+---
+lastTransitionTime: null
+message: authenticated to registry %s as "my-user"
+reason: Authenticated
+status: "True"
+type: Ready
+
+Details:
+  Type:                   basic-auth
+  Registry:               %s
+  Username:               my-user
+  Default Image Prefix:   %s/my-user
+`, host(bearerRegistry), bearerRegistry.URL, host(bearerRegistry)),
+		},
+		{
+			Name: "not a registry",
+			Args: []string{credentialName},
+			GivenObjects: []runtime.Object{
+				credential(notARegistry.URL, username, password),
+			},
+			ShouldError: true,
+			ExpectOutput: fmt.Sprintf(`
+# my-creds: UnsupportedRegistry
+---
+lastTransitionTime: null
+message: registry responded to %s/v2/ with "404 Not Found"
+reason: UnsupportedRegistry
+status: "False"
+type: Ready
+
+Details:
+  Type:       basic-auth
+  Registry:   %s
+  Username:   my-user
+`, notARegistry.URL, notARegistry.URL),
+		},
+		{
+			Name: "unreachable registry",
+			Args: []string{credentialName},
+			GivenObjects: []runtime.Object{
+				credential(unreachableRegistry.URL, username, password),
+			},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected := "# my-creds: RegistryUnreachable\n"; !strings.HasPrefix(output, expected) {
+					t.Errorf("expected output to start with %q, actually %q", expected, output)
+				}
+			},
+		},
+		{
+			Name: "missing password",
+			Args: []string{credentialName},
+			GivenObjects: []runtime.Object{
+				credential("https://registry.example.com", username, ""),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+# my-creds: InvalidCredential
+---
+lastTransitionTime: null
+message: credential does not define a username and password
+reason: InvalidCredential
+status: "False"
+type: Ready
+
+Details:
+  Type:       basic-auth
+  Registry:   https://registry.example.com
+  Username:   my-user
+`,
+		},
+		{
+			Name: "not owned by riff",
+			Args: []string{credentialName},
+			GivenObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      credentialName,
+					},
+				},
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "not found",
+			Args: []string{credentialName},
+			ExpectOutput: `
+Credential "default/my-creds" not found
+`,
+			ShouldError: true,
+		},
+		{
+			Name: "get error",
+			Args: []string{credentialName},
+			GivenObjects: []runtime.Object{
+				credential(bearerRegistry.URL, username, password),
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "secrets"),
+			},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewCredentialStatusCommand)
+}
+
+// newRegistryStandIn serves the /v2/ api of a registry that challenges for a
+// bearer token, for basic auth, allows anonymous access, or is not a registry.
+func newRegistryStandIn(challenge, username, password string) *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	token := "my-token"
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		switch challenge {
+		case "anonymous":
+			w.WriteHeader(http.StatusOK)
+		case "basic":
+			if u, p, ok := r.BasicAuth(); ok && u == username && p == password {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case "bearer":
+			if r.Header.Get("Authorization") == "Bearer "+token {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry.example.com"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != username || p != password || r.URL.Query().Get("service") != "registry.example.com" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"token": %q}`, token)
+	})
+	return server
+}
@@ -53,6 +53,7 @@ the source code. Unlike applications, functions:
 
 	cmd.AddCommand(NewFunctionListCommand(ctx, c))
 	cmd.AddCommand(NewFunctionCreateCommand(ctx, c))
+	cmd.AddCommand(NewFunctionUpdateCommand(ctx, c))
 	cmd.AddCommand(NewFunctionDeleteCommand(ctx, c))
 	cmd.AddCommand(NewFunctionStatusCommand(ctx, c))
 	cmd.AddCommand(NewFunctionTailCommand(ctx, c))
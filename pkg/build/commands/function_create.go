@@ -40,6 +40,10 @@ import (
 
 type FunctionCreateOptions struct {
 	options.ResourceOptions
+	options.TailOptions
+
+	Labels      []string
+	Annotations []string
 
 	Image     string
 	CacheSize string
@@ -74,18 +78,13 @@ func (opts *FunctionCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 	errs := cli.FieldErrors{}
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+	errs = errs.Also(validation.Labels(opts.Labels, cli.LabelFlagName))
+	errs = errs.Also(validation.Annotations(opts.Annotations, cli.AnnotationFlagName))
 
 	if opts.Image == "" {
 		errs = errs.Also(cli.ErrMissingField(cli.ImageFlagName))
 	}
 
-	if opts.CacheSize != "" {
-		// must parse as a resource quantity
-		if _, err := resource.ParseQuantity(opts.CacheSize); err != nil {
-			errs = errs.Also(cli.ErrInvalidValue(opts.CacheSize, cli.CacheSizeFlagName))
-		}
-	}
-
 	// git-repo and local-path are mutually exclusive
 	if opts.GitRepo == "" && opts.LocalPath == "" {
 		errs = errs.Also(cli.ErrMissingOneOf(cli.GitRepoFlagName, cli.LocalPathFlagName))
@@ -111,26 +110,18 @@ func (opts *FunctionCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 
 	// nothing to do for artifact, handler, and invoker
 
-	errs = errs.Also(validation.EnvVars(opts.Env, cli.EnvFlagName))
-
-	if opts.LimitCPU != "" {
-		errs = errs.Also(validation.Quantity(opts.LimitCPU, cli.LimitCPUFlagName))
-	}
-	if opts.LimitMemory != "" {
-		errs = errs.Also(validation.Quantity(opts.LimitMemory, cli.LimitMemoryFlagName))
-	}
-
-	if opts.Tail {
-		if opts.WaitTimeout == "" {
-			errs = errs.Also(cli.ErrMissingField(cli.WaitTimeoutFlagName))
-		} else if _, err := time.ParseDuration(opts.WaitTimeout); err != nil {
-			errs = errs.Also(cli.ErrInvalidValue(opts.WaitTimeout, cli.WaitTimeoutFlagName))
-		}
-	}
-
-	if opts.DryRun && opts.Tail {
-		errs = errs.Also(cli.ErrMultipleOneOf(cli.DryRunFlagName, cli.TailFlagName))
-	}
+	errs = errs.Also(buildFields{
+		CacheSize:   opts.CacheSize,
+		Env:         opts.Env,
+		LimitCPU:    opts.LimitCPU,
+		LimitMemory: opts.LimitMemory,
+	}.Validate(ctx))
+	errs = errs.Also(tailFields{
+		Tail:        opts.Tail,
+		WaitTimeout: opts.WaitTimeout,
+		DryRun:      opts.DryRun,
+	}.Validate(ctx))
+	errs = errs.Also(opts.TailOptions.Validate(ctx, cli.TailOutputFlagName))
 
 	if opts.LocalPath != "" && runtime.GOOS == "windows" {
 		errs = errs.Also(cli.ErrInvalidValue(fmt.Sprintf("%s is not available on Windows", cli.LocalPathFlagName), cli.LocalPathFlagName))
@@ -142,8 +133,10 @@ func (opts *FunctionCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 func (opts *FunctionCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
 	function := &buildv1alpha1.Function{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: opts.Namespace,
-			Name:      opts.Name,
+			Namespace:   opts.Namespace,
+			Name:        opts.Name,
+			Labels:      parsers.KeyValues(opts.Labels),
+			Annotations: parsers.KeyValues(opts.Annotations),
 		},
 		Spec: buildv1alpha1.FunctionSpec{
 			Image:    opts.Image,
@@ -247,7 +240,7 @@ func (opts *FunctionCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 				return k8s.WaitUntilReady(ctx, c.Build().RESTClient(), "functions", function)
 			},
 			func(ctx context.Context) error {
-				return c.Kail.FunctionLogs(ctx, function, cli.TailSinceCreateDefault, c.Stdout)
+				return c.Kail.FunctionLogs(ctx, function, opts.LogOptions(cli.TailSinceCreateDefault), c.Stdout)
 			},
 		)
 		if err == context.DeadlineExceeded {
@@ -329,8 +322,11 @@ The riff.toml file takes the form:
 	cmd.Flags().StringVar(&opts.LimitCPU, cli.StripDash(cli.LimitCPUFlagName), "", "the maximum amount of cpu allowed, in CPU `cores` (500m = .5 cores)")
 	cmd.Flags().StringVar(&opts.LimitMemory, cli.StripDash(cli.LimitMemoryFlagName), "", "the maximum amount of memory allowed, in `bytes` (500Mi = 500MiB = 500 * 1024 * 1024)")
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch build logs")
+	cli.TailFlags(cmd, cli.TailOutputFlagName, &opts.Output, &opts.Grep, &opts.Timestamps)
 	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the function to become ready when watching logs")
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().StringArrayVar(&opts.Labels, cli.StripDash(cli.LabelFlagName), []string{}, fmt.Sprintf("`label` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s team=payments", cli.LabelFlagName)))
+	cmd.Flags().StringArrayVar(&opts.Annotations, cli.StripDash(cli.AnnotationFlagName), []string{}, fmt.Sprintf("`annotation` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s owner=jane@example.com", cli.AnnotationFlagName)))
 
 	return cmd
 }
@@ -27,7 +27,9 @@ import (
 	"github.com/buildpacks/pack"
 	"github.com/projectriff/cli/pkg/build/commands"
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
 	"github.com/projectriff/cli/pkg/k8s"
+	riffkail "github.com/projectriff/cli/pkg/kail"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	kailtesting "github.com/projectriff/cli/pkg/testing/kail"
 	packtesting "github.com/projectriff/cli/pkg/testing/pack"
@@ -237,6 +239,42 @@ func TestFunctionCreateOptions(t *testing.T) {
 			},
 			ExpectFieldErrors: cli.ErrInvalidValue("d", cli.WaitTimeoutFlagName),
 		},
+		{
+			Name: "git source, tail json",
+			Options: &commands.FunctionCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				TailOptions: options.TailOptions{
+					Output:     riffkail.LogOutputJSON,
+					Grep:       "ERROR",
+					Timestamps: true,
+				},
+				Image:       "example.com/repo:tag",
+				GitRepo:     "https://example.com/repo.git",
+				GitRevision: "master",
+				Tail:        true,
+				WaitTimeout: "10m",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "git source, tail invalid output",
+			Options: &commands.FunctionCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				TailOptions: options.TailOptions{
+					Output: "yaml",
+					Grep:   "(",
+				},
+				Image:       "example.com/repo:tag",
+				GitRepo:     "https://example.com/repo.git",
+				GitRevision: "master",
+				Tail:        true,
+				WaitTimeout: "10m",
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidValue("yaml", cli.TailOutputFlagName),
+				cli.ErrInvalidValue("(", cli.GrepFlagName),
+			),
+		},
 		{
 			Name: "dry run",
 			Options: &commands.FunctionCreateOptions{
@@ -324,6 +362,32 @@ func TestFunctionCreateCommand(t *testing.T) {
 			},
 			ExpectOutput: `
 Created function "my-function"
+`,
+		},
+		{
+			Name: "create with labels and annotations",
+			Args: []string{functionName, cli.ImageFlagName, imageTag, cli.GitRepoFlagName, gitRepo, cli.LabelFlagName, "team=payments", cli.AnnotationFlagName, "owner=jane@example.com"},
+			ExpectCreates: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   defaultNamespace,
+						Name:        functionName,
+						Labels:      map[string]string{"team": "payments"},
+						Annotations: map[string]string{"owner": "jane@example.com"},
+					},
+					Spec: buildv1alpha1.FunctionSpec{
+						Image: imageTag,
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      gitRepo,
+								Revision: gitMaster,
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created function "my-function"
 `,
 		},
 		{
@@ -926,7 +990,7 @@ Created function "my-function"
 							},
 						},
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -986,7 +1050,7 @@ Function "my-function" is ready
 							},
 						},
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(k8s.ErrWaitTimeout).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(k8s.ErrWaitTimeout).Run(func(args mock.Arguments) {
 					ctx := args[0].(context.Context)
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 					// wait for context to be cancelled
@@ -1057,7 +1121,7 @@ To continue watching logs run: riff function tail my-function --namespace defaul
 							},
 						},
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(fmt.Errorf("kail error"))
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
 				return ctx, nil
 			},
 			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
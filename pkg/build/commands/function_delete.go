@@ -20,11 +20,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type FunctionDeleteOptions struct {
@@ -46,13 +47,23 @@ func (opts *FunctionDeleteOptions) Validate(ctx context.Context) cli.FieldErrors
 
 func (opts *FunctionDeleteOptions) Exec(ctx context.Context, c *cli.Config) error {
 	client := c.Build().Functions(opts.Namespace)
+	deleted := options.DeletedResource{
+		Client:      c.Build().RESTClient(),
+		Resource:    "functions",
+		ObjType:     &buildv1alpha1.Function{},
+		PodLabelKey: buildv1alpha1.FunctionLabelKey,
+	}
 
 	if opts.All {
-		if err := client.DeleteCollection(nil, metav1.ListOptions{}); err != nil {
+		names, err := opts.DeletedNames(ctx, deleted, opts.K8sListOptions())
+		if err != nil {
+			return err
+		}
+		if err := client.DeleteCollection(nil, opts.K8sListOptions()); err != nil {
 			return err
 		}
 		c.Successf("Deleted functions in namespace %q\n", opts.Namespace)
-		return nil
+		return opts.WaitForDeletion(ctx, c, deleted, names)
 	}
 
 	for _, name := range opts.Names {
@@ -62,7 +73,7 @@ func (opts *FunctionDeleteOptions) Exec(ctx context.Context, c *cli.Config) erro
 		c.Successf("Deleted function %q\n", name)
 	}
 
-	return nil
+	return opts.WaitForDeletion(ctx, c, deleted, opts.Names)
 }
 
 func NewFunctionDeleteCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -79,7 +90,9 @@ registry.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s function delete my-function", c.Name),
+			fmt.Sprintf("%s function delete my-function %s", c.Name, cli.WaitFlagName),
 			fmt.Sprintf("%s function delete %s ", c.Name, cli.AllFlagName),
+			fmt.Sprintf("%s function delete %s %s team=payments", c.Name, cli.AllFlagName, cli.SelectorFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -91,6 +104,9 @@ registry.
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all functions within the namespace")
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
+	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(cli.WaitFlagName), false, "wait for the functions and their pods to be removed")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), time.Minute, "`duration` to wait for the functions to be removed")
 
 	return cmd
 }
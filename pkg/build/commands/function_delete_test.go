@@ -77,6 +77,28 @@ func TestFunctionDeleteCommand(t *testing.T) {
 			}},
 			ExpectOutput: `
 Deleted functions in namespace "default"
+`,
+		},
+		{
+			Name: "delete all functions with selectors",
+			Args: []string{cli.AllFlagName, cli.SelectorFlagName, "team=payments", cli.FieldSelectorFlagName, "metadata.name!=my-function"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      functionName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectDeleteCollections: []rifftesting.DeleteCollectionRef{{
+				Group:         "build.projectriff.io",
+				Resource:      "functions",
+				Namespace:     defaultNamespace,
+				LabelSelector: "team=payments",
+				FieldSelector: "metadata.name!=my-function",
+			}},
+			ExpectOutput: `
+Deleted functions in namespace "default"
 `,
 		},
 		{
@@ -27,7 +27,6 @@ import (
 	"github.com/projectriff/cli/pkg/cli/printers"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -50,18 +49,15 @@ func (opts *FunctionListOptions) Validate(ctx context.Context) cli.FieldErrors {
 }
 
 func (opts *FunctionListOptions) Exec(ctx context.Context, c *cli.Config) error {
-	functions, err := c.Build().Functions(opts.Namespace).List(metav1.ListOptions{})
+	listOptions := opts.K8sListOptions()
+	functions, err := c.Build().Functions(opts.Namespace).List(listOptions)
 	if err != nil {
 		return err
 	}
 
-	if len(functions.Items) == 0 {
-		c.Infof("No functions found.\n")
-		return nil
-	}
-
 	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{
 		WithNamespace: opts.AllNamespaces,
+		Wide:          opts.Output == printers.OutputFormatWide,
 	}).With(func(h printers.PrintHandler) {
 		columns := opts.printColumns()
 		h.TableHandler(columns, opts.printList)
@@ -71,7 +67,25 @@ func (opts *FunctionListOptions) Exec(ctx context.Context, c *cli.Config) error
 	functions = functions.DeepCopy()
 	cli.SortByNamespaceAndName(functions.Items)
 
-	return tablePrinter.PrintObj(functions, c.Stdout)
+	if len(functions.Items) == 0 && printers.IsHumanReadable(opts.Output) {
+		c.Infof("No functions found.\n")
+	} else if err := printers.PrintList(opts.Output, tablePrinter, functions, buildv1alpha1.SchemeGroupVersion.WithKind("Function"), c.Stdout); err != nil {
+		return err
+	}
+
+	if opts.Watch {
+		return cli.WatchList(ctx, c, functions, cli.WatchListOptions{
+			Client:       c.Build().RESTClient(),
+			Resource:     "functions",
+			Namespace:    opts.Namespace,
+			ListOptions:  listOptions,
+			Output:       opts.Output,
+			TablePrinter: tablePrinter,
+			ItemKind:     buildv1alpha1.SchemeGroupVersion.WithKind("Function"),
+		})
+	}
+
+	return nil
 }
 
 func NewFunctionListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -90,12 +104,17 @@ For detail regarding the status of a single function, run:
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s function list", c.Name),
 			fmt.Sprintf("%s function list %s", c.Name, cli.AllNamespacesFlagName),
+			fmt.Sprintf("%s function list %s json", c.Name, cli.OutputFlagName),
+			fmt.Sprintf("%s function list %s", c.Name, cli.WatchFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.AllNamespacesFlag(cmd, c, &opts.Namespace, &opts.AllNamespaces)
+	cli.OutputFlag(cmd, &opts.Output)
+	cli.WatchFlag(cmd, &opts.Watch)
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
 
 	return cmd
 }
@@ -112,8 +131,13 @@ func (opts *FunctionListOptions) printList(functions *buildv1alpha1.FunctionList
 	return rows, nil
 }
 
-func (opts *FunctionListOptions) print(function *buildv1alpha1.Function, _ printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+func (opts *FunctionListOptions) print(function *buildv1alpha1.Function, printOpts printers.PrintOptions) ([]metav1beta1.TableRow, error) {
 	now := time.Now()
+	var gitRepo, gitRevision string
+	if function.Spec.Source != nil && function.Spec.Source.Git != nil {
+		gitRepo = function.Spec.Source.Git.URL
+		gitRevision = function.Spec.Source.Git.Revision
+	}
 	row := metav1beta1.TableRow{
 		Object: runtime.RawExtension{Object: function},
 	}
@@ -126,6 +150,12 @@ func (opts *FunctionListOptions) print(function *buildv1alpha1.Function, _ print
 		cli.FormatConditionStatus(function.Status.GetCondition(buildv1alpha1.FunctionConditionReady)),
 		cli.FormatTimestampSince(function.CreationTimestamp, now),
 	)
+	if printOpts.Wide {
+		row.Cells = append(row.Cells,
+			cli.FormatEmptyString(gitRepo),
+			cli.FormatEmptyString(gitRevision),
+		)
+	}
 	return []metav1beta1.TableRow{row}, nil
 }
 
@@ -138,5 +168,7 @@ func (opts *FunctionListOptions) printColumns() []metav1beta1.TableColumnDefinit
 		{Name: "Invoker", Type: "string"},
 		{Name: "Status", Type: "string"},
 		{Name: "Age", Type: "string"},
+		{Name: "Git Repo", Type: "string", Priority: 1},
+		{Name: "Git Revision", Type: "string", Priority: 1},
 	}
 }
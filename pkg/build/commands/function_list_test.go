@@ -18,15 +18,20 @@ package commands_test
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/projectriff/cli/pkg/build/commands"
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	"github.com/projectriff/system/pkg/apis"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	cachetesting "k8s.io/client-go/tools/cache/testing"
 )
 
 func TestFunctionListOptions(t *testing.T) {
@@ -126,6 +131,30 @@ No functions found.
 NAMESPACE         NAME                  LATEST IMAGE   ARTIFACT   HANDLER   INVOKER   STATUS      AGE
 default           test-function         <empty>        <empty>    <empty>   <empty>   <unknown>   <unknown>
 other-namespace   test-other-function   <empty>        <empty>    <empty>   <empty>   <unknown>   <unknown>
+`,
+		},
+		{
+			Name: "filters by selector",
+			Args: []string{cli.SelectorFlagName, "team=payments"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      functionName,
+						Namespace: defaultNamespace,
+						Labels:    map[string]string{"team": "payments"},
+					},
+				},
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      functionOtherName,
+						Namespace: defaultNamespace,
+						Labels:    map[string]string{"team": "billing"},
+					},
+				},
+			},
+			ExpectOutput: `
+NAME            LATEST IMAGE   ARTIFACT   HANDLER   INVOKER   STATUS      AGE
+test-function   <empty>        <empty>    <empty>   <empty>   <unknown>   <unknown>
 `,
 		},
 		{
@@ -157,6 +186,296 @@ other-namespace   test-other-function   <empty>        <empty>    <empty>   <emp
 			ExpectOutput: `
 NAME    LATEST IMAGE                          ARTIFACT       HANDLER               INVOKER   STATUS   AGE
 upper   projectriff/upper@sah256:abcdef1234   uppercase.js   functions.Uppercase   <empty>   Ready    <unknown>
+`,
+		},
+		{
+			Name: "wide output",
+			Args: []string{cli.OutputFlagName, "wide"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      functionName,
+						Namespace: defaultNamespace,
+					},
+					Spec: buildv1alpha1.FunctionSpec{
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      "https://example.com/repo.git",
+								Revision: "master",
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+NAME            LATEST IMAGE   ARTIFACT   HANDLER   INVOKER   STATUS      AGE         GIT REPO                       GIT REVISION
+test-function   <empty>        <empty>    <empty>   <empty>   <unknown>   <unknown>   https://example.com/repo.git   master
+`,
+		},
+		{
+			Name: "json output",
+			Args: []string{cli.OutputFlagName, "json"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      functionName,
+						Namespace: defaultNamespace,
+					},
+					Spec: buildv1alpha1.FunctionSpec{
+						Image: "example.com/repo",
+					},
+				},
+			},
+			ExpectOutput: `
+{
+    "kind": "FunctionList",
+    "apiVersion": "build.projectriff.io/v1alpha1",
+    "metadata": {},
+    "items": [
+        {
+            "kind": "Function",
+            "apiVersion": "build.projectriff.io/v1alpha1",
+            "metadata": {
+                "name": "test-function",
+                "namespace": "default",
+                "creationTimestamp": null
+            },
+            "spec": {
+                "image": "example.com/repo",
+                "build": {
+                    "resources": {}
+                }
+            },
+            "status": {}
+        }
+    ]
+}
+`,
+		},
+		{
+			Name: "yaml output",
+			Args: []string{cli.OutputFlagName, "yaml"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      functionName,
+						Namespace: defaultNamespace,
+					},
+					Spec: buildv1alpha1.FunctionSpec{
+						Image: "example.com/repo",
+					},
+				},
+			},
+			ExpectOutput: `
+apiVersion: build.projectriff.io/v1alpha1
+items:
+- apiVersion: build.projectriff.io/v1alpha1
+  kind: Function
+  metadata:
+    creationTimestamp: null
+    name: test-function
+    namespace: default
+  spec:
+    build:
+      resources: {}
+    image: example.com/repo
+  status: {}
+kind: FunctionList
+metadata: {}
+`,
+		},
+		{
+			Name: "empty json output",
+			Args: []string{cli.OutputFlagName, "json"},
+			ExpectOutput: `
+{
+    "kind": "FunctionList",
+    "apiVersion": "build.projectriff.io/v1alpha1",
+    "metadata": {},
+    "items": []
+}
+`,
+		},
+		{
+			Name: "jsonpath output",
+			Args: []string{cli.OutputFlagName, "jsonpath={.items[*].status.latestImage}"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      functionName,
+						Namespace: defaultNamespace,
+					},
+					Status: buildv1alpha1.FunctionStatus{
+						BuildStatus: buildv1alpha1.BuildStatus{
+							LatestImage: "projectriff/upper@sah256:abcdef1234",
+						},
+					},
+				},
+			},
+			ExpectOutput: "projectriff/upper@sah256:abcdef1234",
+		},
+		{
+			Name: "go-template output",
+			Args: []string{cli.OutputFlagName, `go-template={{range .items}}{{.metadata.name}}{{"\n"}}{{end}}`},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      functionName,
+						Namespace: defaultNamespace,
+					},
+				},
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      functionOtherName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+test-function
+test-other-function
+`,
+		},
+		{
+			Name: "name output",
+			Args: []string{cli.OutputFlagName, "name"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      functionName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+function/test-function
+`,
+		},
+		{
+			Name: "watch",
+			Args: []string{cli.WatchFlagName},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      functionName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				lw := cachetesting.NewFakeControllerSource()
+				ctx = k8s.WithListerWatcher(ctx, lw)
+				ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+				go func() {
+					defer cancel()
+					lw.Add(&buildv1alpha1.Function{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      functionName,
+							Namespace: defaultNamespace,
+						},
+						Status: buildv1alpha1.FunctionStatus{
+							Status: apis.Status{
+								Conditions: apis.Conditions{
+									{Type: buildv1alpha1.FunctionConditionReady, Status: "True"},
+								},
+							},
+							BuildStatus: buildv1alpha1.BuildStatus{
+								LatestImage: "projectriff/upper@sah256:abcdef1234",
+							},
+						},
+					})
+					<-ctx.Done()
+				}()
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*cachetesting.FakeControllerSource); ok {
+					lw.Shutdown()
+				}
+				return nil
+			},
+			ExpectOutput: `
+NAME            LATEST IMAGE   ARTIFACT   HANDLER   INVOKER   STATUS      AGE
+test-function   <empty>        <empty>    <empty>   <empty>   <unknown>   <unknown>
+test-function   projectriff/upper@sah256:abcdef1234   <empty>   <empty>   <empty>   Ready   <unknown>
+`,
+		},
+		{
+			Name: "watch with selector",
+			Args: []string{cli.WatchFlagName, cli.SelectorFlagName, "app=my-app"},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      functionName,
+						Namespace: defaultNamespace,
+						Labels:    map[string]string{"app": "my-app"},
+					},
+				},
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "other-function",
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				lw := &recordingListerWatcher{FakeControllerSource: cachetesting.NewFakeControllerSource()}
+				ctx = k8s.WithListerWatcher(ctx, lw)
+				ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+				go func() {
+					defer cancel()
+					<-ctx.Done()
+				}()
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*recordingListerWatcher); ok {
+					lw.Shutdown()
+					selectors := lw.LabelSelectors()
+					if len(selectors) == 0 {
+						t.Errorf("expected the watch to list functions")
+					}
+					for _, selector := range selectors {
+						if expected, actual := "app=my-app", selector; expected != actual {
+							t.Errorf("expected label selector %q, found %q", expected, actual)
+						}
+					}
+				}
+				return nil
+			},
+			ExpectOutput: `
+NAME            LATEST IMAGE   ARTIFACT   HANDLER   INVOKER   STATUS      AGE
+test-function   <empty>        <empty>    <empty>   <empty>   <unknown>   <unknown>
+`,
+		},
+		{
+			Name: "watch empty",
+			Args: []string{cli.WatchFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				lw := cachetesting.NewFakeControllerSource()
+				ctx = k8s.WithListerWatcher(ctx, lw)
+				ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+				go func() {
+					defer cancel()
+					lw.Add(&buildv1alpha1.Function{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      functionName,
+							Namespace: defaultNamespace,
+						},
+					})
+					<-ctx.Done()
+				}()
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*cachetesting.FakeControllerSource); ok {
+					lw.Shutdown()
+				}
+				return nil
+			},
+			ExpectOutput: `
+No functions found.
+test-function   <empty>   <empty>   <empty>   <empty>   <unknown>   <unknown>
 `,
 		},
 		{
@@ -171,3 +490,33 @@ upper   projectriff/upper@sah256:abcdef1234   uppercase.js   functions.Uppercase
 
 	table.Run(t, commands.NewFunctionListCommand)
 }
+
+// recordingListerWatcher records the label selector of each list and watch
+// request.
+type recordingListerWatcher struct {
+	*cachetesting.FakeControllerSource
+	m              sync.Mutex
+	labelSelectors []string
+}
+
+func (lw *recordingListerWatcher) List(options metav1.ListOptions) (runtime.Object, error) {
+	lw.record(options)
+	return lw.FakeControllerSource.List(options)
+}
+
+func (lw *recordingListerWatcher) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	lw.record(options)
+	return lw.FakeControllerSource.Watch(options)
+}
+
+func (lw *recordingListerWatcher) record(options metav1.ListOptions) {
+	lw.m.Lock()
+	defer lw.m.Unlock()
+	lw.labelSelectors = append(lw.labelSelectors, options.LabelSelector)
+}
+
+func (lw *recordingListerWatcher) LabelSelectors() []string {
+	lw.m.Lock()
+	defer lw.m.Unlock()
+	return append([]string{}, lw.labelSelectors...)
+}
@@ -23,6 +23,8 @@ import (
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/projectriff/cli/pkg/validation"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -31,6 +33,8 @@ import (
 
 type FunctionStatusOptions struct {
 	options.ResourceOptions
+
+	Output string
 }
 
 var (
@@ -43,6 +47,8 @@ func (opts *FunctionStatusOptions) Validate(ctx context.Context) cli.FieldErrors
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
 
+	errs = errs.Also(validation.StructuredOutputFormat(opts.Output, cli.OutputFlagName))
+
 	return errs
 }
 
@@ -56,10 +62,25 @@ func (opts *FunctionStatusOptions) Exec(ctx context.Context, c *cli.Config) erro
 		return cli.SilenceError(err)
 	}
 
+	if opts.Output != "" {
+		return printers.PrintObject(opts.Output, function, buildv1alpha1.SchemeGroupVersion.WithKind("Function"), c.Stdout)
+	}
+
 	ready := function.Status.GetCondition(buildv1alpha1.FunctionConditionReady)
 	cli.PrintResourceStatus(c, function.Name, ready)
 
-	return nil
+	return cli.PrintResourceStatusDetails(c, cli.ResourceStatusDetails{
+		GroupVersionKind: function.GetGroupVersionKind(),
+		Namespace:        function.Namespace,
+		Name:             function.Name,
+		Conditions:       function.Status.Conditions,
+		Details: []cli.StatusDetail{
+			{Name: "Latest Image", Value: function.Status.LatestImage},
+			{Name: "Target Image", Value: function.Status.TargetImage},
+			{Name: "Kpack Image", Value: cli.StatusRefName(function.Status.KpackImageRef)},
+		},
+		PodSelector: fmt.Sprintf("%s=%s", buildv1alpha1.FunctionLabelKey, function.Name),
+	})
 }
 
 func NewFunctionStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -75,9 +96,13 @@ The Ready condition is shown which should include a reason code and a
 descriptive message when the status is not "True". The status for the condition
 may be: "True", "False" or "Unknown". An "Unknown" status is common while the
 function roll out is processed or a build is in progress.
+
+Key fields from the status, every condition and the most recent events for
+the function are shown after the Ready condition.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s function status my-function", c.Name),
+			fmt.Sprintf("%s function status my-function %s jsonpath={.status.latestImage}", c.Name, cli.OutputFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -88,6 +113,7 @@ function roll out is processed or a build is in progress.
 	)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cli.StructuredOutputFlag(cmd, &opts.Output)
 
 	return cmd
 }
@@ -24,6 +24,8 @@ import (
 
 	"github.com/projectriff/cli/pkg/build/commands"
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
+	riffkail "github.com/projectriff/cli/pkg/kail"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	kailtesting "github.com/projectriff/cli/pkg/testing/kail"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
@@ -64,6 +66,63 @@ func TestFunctionTailOptions(t *testing.T) {
 			},
 			ExpectFieldErrors: cli.ErrInvalidValue("1", cli.SinceFlagName),
 		},
+		{
+			Name: "limit",
+			Options: &commands.FunctionTailOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Limit:           100,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid limit",
+			Options: &commands.FunctionTailOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Limit:           -1,
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue(int64(-1), cli.LimitFlagName),
+		},
+		{
+			Name: "limit while following",
+			Options: &commands.FunctionTailOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Follow:          true,
+				Limit:           100,
+			},
+			ExpectFieldErrors: cli.ErrMultipleOneOf(cli.FollowFlagName, cli.LimitFlagName),
+		},
+		{
+			Name: "json output",
+			Options: &commands.FunctionTailOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				TailOptions: options.TailOptions{
+					Output:     riffkail.LogOutputJSON,
+					Grep:       "ERROR",
+					Timestamps: true,
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid output",
+			Options: &commands.FunctionTailOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				TailOptions: options.TailOptions{
+					Output: "yaml",
+				},
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("yaml", cli.OutputFlagName),
+		},
+		{
+			Name: "invalid grep",
+			Options: &commands.FunctionTailOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				TailOptions: options.TailOptions{
+					Grep: "(",
+				},
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("(", cli.GrepFlagName),
+		},
 	}
 
 	table.Run(t)
@@ -91,7 +150,7 @@ func TestFunctionTailCommand(t *testing.T) {
 			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
 				kail := &kailtesting.Logger{}
 				c.Kail = kail
-				kail.On("FunctionLogs", mock.Anything, function, cli.TailSinceDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				kail.On("FunctionLogs", mock.Anything, function, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -114,7 +173,7 @@ func TestFunctionTailCommand(t *testing.T) {
 			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
 				kail := &kailtesting.Logger{}
 				c.Kail = kail
-				kail.On("FunctionLogs", mock.Anything, function, time.Hour, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				kail.On("FunctionLogs", mock.Anything, function, riffkail.LogOptions{Since: time.Hour, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -131,6 +190,86 @@ func TestFunctionTailCommand(t *testing.T) {
 ...log output...
 `,
 		},
+		{
+			Name: "show logs as json",
+			Args: []string{functionName, cli.OutputFlagName, riffkail.LogOutputJSON, cli.GrepFlagName, "ERROR", cli.TimestampsFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("FunctionLogs", mock.Anything, function, riffkail.LogOptions{
+					Since:      cli.TailSinceDefault,
+					Follow:     true,
+					Output:     riffkail.LogOutputJSON,
+					Grep:       "ERROR",
+					Timestamps: true,
+				}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fmt.Fprintf(c.Stdout, "...log output...\n")
+				})
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				function,
+			},
+			ExpectOutput: `
+...log output...
+`,
+		},
+		{
+			Name: "show existing logs",
+			Args: []string{functionName, cli.FollowFlagName + "=false"},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("FunctionLogs", mock.Anything, function, riffkail.LogOptions{}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fmt.Fprintf(c.Stdout, "...log output...\n")
+				})
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				function,
+			},
+			ExpectOutput: `
+...log output...
+`,
+		},
+		{
+			Name: "show most recent logs",
+			Args: []string{functionName, cli.FollowFlagName + "=false", cli.LimitFlagName, "100", cli.SinceFlagName, "1h"},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("FunctionLogs", mock.Anything, function, riffkail.LogOptions{Since: time.Hour, Limit: 100}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fmt.Fprintf(c.Stdout, "...log output...\n")
+				})
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				function,
+			},
+			ExpectOutput: `
+...log output...
+`,
+		},
+		{
+			Name:        "limit while following",
+			Args:        []string{functionName, cli.LimitFlagName, "100"},
+			ShouldError: true,
+		},
 		{
 			Name:        "unknown function",
 			Args:        []string{functionName},
@@ -142,7 +281,7 @@ func TestFunctionTailCommand(t *testing.T) {
 			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
 				kail := &kailtesting.Logger{}
 				c.Kail = kail
-				kail.On("FunctionLogs", mock.Anything, function, cli.TailSinceDefault, mock.Anything).Return(fmt.Errorf("kail error"))
+				kail.On("FunctionLogs", mock.Anything, function, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
 				return ctx, nil
 			},
 			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
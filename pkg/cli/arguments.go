@@ -24,8 +24,9 @@ import (
 )
 
 const (
-	NameArgumentName  = "name"
-	NamesArgumentName = "name(s)"
+	NameArgumentName      = "name"
+	NamesArgumentName     = "name(s)"
+	ResourcesArgumentName = "resource(s)"
 )
 
 var ErrIgnoreArg = fmt.Errorf("ignore argument")
@@ -125,6 +126,17 @@ func NamesArg(names *[]string) Arg {
 	}
 }
 
+func ResourcesArg(resources *[]string) Arg {
+	return Arg{
+		Name:  ResourcesArgumentName,
+		Arity: -1,
+		Set: func(cmd *cobra.Command, args []string, offset int) error {
+			*resources = args[offset:]
+			return nil
+		},
+	}
+}
+
 func BareDoubleDashArgs(values *[]string) Arg {
 	return Arg{
 		Arity: -1,
@@ -327,6 +327,54 @@ func TestNamesArg(t *testing.T) {
 	}
 }
 
+func TestResourcesArg(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		actual   []string
+		expected []string
+		err      error
+	}{{
+		name:     "no resource",
+		args:     []string{},
+		expected: []string{},
+	}, {
+		name:     "single resource",
+		args:     []string{"function/my-name"},
+		expected: []string{"function/my-name"},
+	}, {
+		name:     "multiple resources",
+		args:     []string{"function/my-name", "core-deployer/my-other-name"},
+		expected: []string{"function/my-name", "core-deployer/my-other-name"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := &cobra.Command{
+				Use: "args-test",
+				RunE: func(cmd *cobra.Command, args []string) error {
+					return nil
+				},
+			}
+			cli.Args(cmd,
+				cli.ResourcesArg(&test.actual),
+			)
+			cmd.SetArgs(test.args)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			err := cmd.Execute()
+
+			if expected, actual := fmt.Sprintf("%s", test.err), fmt.Sprintf("%s", err); expected != actual {
+				t.Errorf("Expected error %q, actually %q", expected, actual)
+			}
+			if diff := cmp.Diff(test.expected, test.actual); diff != "" {
+				t.Errorf("Unexpected arg binding (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
 func TestBareDoubleDashArgs(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -32,12 +32,17 @@ import (
 	"github.com/projectriff/cli/pkg/pack"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 type Config struct {
 	CompiledEnv
 	ViperConfigFile string
 	KubeConfigFile  string
+	// KubeConfigOverrides select the context, cluster, user, impersonated
+	// user and request timeout used instead of the current context from the
+	// kube config
+	KubeConfigOverrides clientcmd.ConfigOverrides
 	k8s.Client
 	Exec   func(ctx context.Context, command string, args ...string) *exec.Cmd
 	Pack   pack.Client
@@ -133,8 +138,28 @@ func (c *Config) initViperConfig() {
 	}
 }
 
-// initKubeConfig defines the default location for the kubectl config file
+// initKubeConfig defines the default location for the kubectl config file and
+// the kube config overrides not set by a flag
 func (c *Config) initKubeConfig() {
+	overrides := []struct {
+		flag  string
+		value *string
+	}{
+		{flag: ContextFlagName, value: &c.KubeConfigOverrides.CurrentContext},
+		{flag: ClusterFlagName, value: &c.KubeConfigOverrides.Context.Cluster},
+		{flag: UserFlagName, value: &c.KubeConfigOverrides.Context.AuthInfo},
+		{flag: RequestTimeoutFlagName, value: &c.KubeConfigOverrides.Timeout},
+		{flag: AsFlagName, value: &c.KubeConfigOverrides.AuthInfo.Impersonate},
+	}
+	for _, override := range overrides {
+		if *override.value == "" {
+			*override.value = viper.GetString(StripDash(override.flag))
+		}
+	}
+	if len(c.KubeConfigOverrides.AuthInfo.ImpersonateGroups) == 0 {
+		c.KubeConfigOverrides.AuthInfo.ImpersonateGroups = viper.GetStringSlice(StripDash(AsGroupFlagName))
+	}
+
 	if c.KubeConfigFile != "" {
 		return
 	}
@@ -152,7 +177,7 @@ func (c *Config) initKubeConfig() {
 
 func (c *Config) init() {
 	if c.Client == nil {
-		c.Client = k8s.NewClient(c.KubeConfigFile)
+		c.Client = k8s.NewClient(c.KubeConfigFile, c.KubeConfigOverrides)
 	}
 	if c.Pack == nil {
 		packClient, err := pack.NewClient(c.Stdout)
@@ -27,6 +27,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/viper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 func TestInitViperConfig(t *testing.T) {
@@ -170,6 +172,42 @@ func TestInitKubeConfig_HomeDir(t *testing.T) {
 	}
 }
 
+func TestInitKubeConfig_Overrides(t *testing.T) {
+	defer viper.Reset()
+
+	c := NewDefaultConfig()
+	output := &bytes.Buffer{}
+	c.Stdout = output
+	c.Stderr = output
+
+	viper.Set("context", "config-context")
+	viper.Set("cluster", "config-cluster")
+	viper.Set("request-timeout", "30s")
+	viper.Set("as", "team-user")
+	viper.Set("as-group", []string{"team-group"})
+	c.KubeConfigFile = "testdata/.kube/config"
+	c.KubeConfigOverrides.Context.Cluster = "flag-cluster"
+	c.initKubeConfig()
+
+	expectedOverrides := clientcmd.ConfigOverrides{
+		CurrentContext: "config-context",
+		Context: clientcmdapi.Context{
+			Cluster: "flag-cluster",
+		},
+		AuthInfo: clientcmdapi.AuthInfo{
+			Impersonate:       "team-user",
+			ImpersonateGroups: []string{"team-group"},
+		},
+		Timeout: "30s",
+	}
+	if diff := cmp.Diff(expectedOverrides, c.KubeConfigOverrides); diff != "" {
+		t.Errorf("Unexpected overrides (-expected, +actual): %s", diff)
+	}
+	if diff := cmp.Diff("", strings.TrimSpace(output.String())); diff != "" {
+		t.Errorf("Unexpected output (-expected, +actual): %s", diff)
+	}
+}
+
 func TestInit(t *testing.T) {
 	noColor := color.NoColor
 	color.NoColor = false
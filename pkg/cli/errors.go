@@ -16,6 +16,15 @@
 
 package cli
 
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/projectriff/cli/pkg/k8s"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
 var SilentError = &silentError{}
 
 type silentError struct {
@@ -38,3 +47,24 @@ func (e *silentError) Is(err error) bool {
 func SilenceError(err error) error {
 	return &silentError{err: err}
 }
+
+// KubeConfigHint replaces an error loading the kube config with a message
+// suggesting how to resolve the error. Other errors are returned as is.
+func KubeConfigHint(err error) error {
+	var configErr *k8s.ConfigError
+	if !errors.As(err, &configErr) {
+		return err
+	}
+	switch {
+	case errors.Is(configErr.Err, os.ErrNotExist):
+		return fmt.Errorf("kube config %q not found; set %s or the KUBECONFIG environment variable", configErr.File, KubeConfigFlagName)
+	case clientcmd.IsEmptyConfig(configErr.Err):
+		return fmt.Errorf("no current context in kube config %q; set %s or %s", configErr.File, KubeConfigFlagName, ContextFlagName)
+	case clientcmd.IsContextNotFound(configErr.Err):
+		return fmt.Errorf("%s; set %s to a context from kube config %q", configErr.Err, ContextFlagName, configErr.File)
+	case errors.Is(configErr.Err, k8s.ErrImpersonateGroupsWithoutUser):
+		return fmt.Errorf("%s; set %s with %s", configErr.Err, AsFlagName, AsGroupFlagName)
+	default:
+		return fmt.Errorf("%s; fix the kube config or set %s", configErr, KubeConfigFlagName)
+	}
+}
@@ -19,9 +19,12 @@ package cli_test
 import (
 	"errors"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 func TestSilenceError(t *testing.T) {
@@ -41,3 +44,52 @@ func TestSilenceError(t *testing.T) {
 		t.Errorf("errors expected to match, expected %q, actually %q", expected, actual)
 	}
 }
+
+func TestKubeConfigHint(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{{
+		name: "nil",
+	}, {
+		name:     "other error",
+		err:      fmt.Errorf("test error"),
+		expected: "test error",
+	}, {
+		name:     "missing file",
+		err:      &k8s.ConfigError{File: "/tmp/kube", Err: &os.PathError{Op: "open", Path: "/tmp/kube", Err: os.ErrNotExist}},
+		expected: `kube config "/tmp/kube" not found; set --kubeconfig or the KUBECONFIG environment variable`,
+	}, {
+		name:     "no current context",
+		err:      &k8s.ConfigError{File: "/tmp/kube", Err: clientcmd.ErrEmptyConfig},
+		expected: `no current context in kube config "/tmp/kube"; set --kubeconfig or --context`,
+	}, {
+		name:     "context not found",
+		err:      &k8s.ConfigError{File: "/tmp/kube", Err: fmt.Errorf("context was not found for specified context: my-context")},
+		expected: `context was not found for specified context: my-context; set --context to a context from kube config "/tmp/kube"`,
+	}, {
+		name:     "impersonate groups without user",
+		err:      &k8s.ConfigError{File: "/tmp/kube", Err: k8s.ErrImpersonateGroupsWithoutUser},
+		expected: `impersonating groups requires impersonating a user; set --as with --as-group`,
+	}, {
+		name:     "invalid config",
+		err:      fmt.Errorf("wrapped: %w", &k8s.ConfigError{File: "/tmp/kube", Err: fmt.Errorf("bad yaml")}),
+		expected: `unable to load kube config "/tmp/kube": bad yaml; fix the kube config or set --kubeconfig`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := cli.KubeConfigHint(test.err)
+			if test.expected == "" {
+				if err != nil {
+					t.Errorf("Expected no error, actually %v", err)
+				}
+				return
+			}
+			if expected, actual := test.expected, fmt.Sprintf("%s", err); expected != actual {
+				t.Errorf("Expected error %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
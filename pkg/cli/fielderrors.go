@@ -17,7 +17,11 @@
 package cli
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/projectriff/system/pkg/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 const CurrentField = validation.CurrentField
@@ -34,3 +38,11 @@ var (
 	ErrMissingOneOf      = validation.ErrMissingOneOf
 	ErrMultipleOneOf     = validation.ErrMultipleOneOf
 )
+
+// ErrNothingToUpdate reports that none of the fields that change a resource
+// were set.
+func ErrNothingToUpdate(names ...string) FieldErrors {
+	return FieldErrors{
+		field.Required(field.NewPath(fmt.Sprintf("[%s]", strings.Join(names, ", "))), "nothing to update, expected at least one"),
+	}
+}
@@ -218,3 +218,19 @@ func TestErrMultipleOneOf(t *testing.T) {
 		t.Errorf("(-expected, +actual): %s", diff)
 	}
 }
+
+func TestErrNothingToUpdate(t *testing.T) {
+	expected := cli.FieldErrors{
+		&field.Error{
+			Type:     field.ErrorTypeRequired,
+			Field:    "[field1, field2, field3]",
+			BadValue: "",
+			Detail:   "nothing to update, expected at least one",
+		},
+	}
+	actual := cli.ErrNothingToUpdate("field1", "field2", "field3")
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("(-expected, +actual): %s", diff)
+	}
+}
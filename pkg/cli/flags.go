@@ -17,40 +17,56 @@
 package cli
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/projectriff/cli/pkg/cli/printers"
 	"github.com/spf13/cobra"
 )
 
 const (
 	AllFlagName                   = "--all"
 	AllNamespacesFlagName         = "--all-namespaces"
+	AnnotationFlagName            = "--annotation"
 	ApplicationRefFlagName        = "--application-ref"
 	ArtifactFlagName              = "--artifact"
+	AsFlagName                    = "--as"
+	AsGroupFlagName               = "--as-group"
 	BootstrapServersFlagName      = "--bootstrap-servers"
 	CacheSizeFlagName             = "--cache-size"
+	ClusterFlagName               = "--cluster"
 	ConfigFlagName                = "--config"
 	ConfigurationRefFlagName      = "--configuration-ref"
+	ContainerFlagName             = "--container"
 	ContainerRefFlagName          = "--container-ref"
 	ContentTypeFlagName           = "--content-type"
+	ContextFlagName               = "--context"
 	DefaultImagePrefixFlagName    = "--default-image-prefix"
 	DirectoryFlagName             = "--directory"
 	DockerHubFlagName             = "--docker-hub"
 	DryRunFlagName                = "--dry-run"
 	EnvFlagName                   = "--env"
 	EnvFromFlagName               = "--env-from"
+	FieldSelectorFlagName         = "--field-selector"
+	FilenameFlagName              = "--filename"
+	FollowFlagName                = "--follow"
+	ForFlagName                   = "--for"
 	FunctionRefFlagName           = "--function-ref"
 	GatewayFlagName               = "--gateway"
 	GcrFlagName                   = "--gcr"
 	GitRepoFlagName               = "--git-repo"
 	GitRevisionFlagName           = "--git-revision"
+	GrepFlagName                  = "--grep"
 	HandlerFlagName               = "--handler"
 	ImageFlagName                 = "--image"
 	IngressPolicyFlagName         = "--ingress-policy"
 	InputFlagName                 = "--input"
+	InstallFlagName               = "--install"
 	InvokerFlagName               = "--invoker"
 	KubeConfigFlagName            = "--kubeconfig"
 	KubeConfigFlagNameDeprecated  = "--kube-config"
+	LabelFlagName                 = "--label"
+	LimitFlagName                 = "--limit"
 	LimitCPUFlagName              = "--limit-cpu"
 	LimitMemoryFlagName           = "--limit-memory"
 	LocalPathFlagName             = "--local-path"
@@ -59,17 +75,28 @@ const (
 	NamespaceFlagName             = "--namespace"
 	NoColorFlagName               = "--no-color"
 	OutputFlagName                = "--output"
+	QuietFlagName                 = "--quiet"
 	RegistryFlagName              = "--registry"
 	RegistryUserFlagName          = "--registry-user"
+	RequestTimeoutFlagName        = "--request-timeout"
+	SelectorFlagName              = "--selector"
 	ServiceRefFlagName            = "--service-ref"
 	ServiceURLFlagName            = "--service-url"
 	SetDefaultImagePrefixFlagName = "--set-default-image-prefix"
 	ShellFlagName                 = "--shell"
 	SinceFlagName                 = "--since"
 	SubPathFlagName               = "--sub-path"
+	SuggestRBACFlagName           = "--suggest-rbac"
 	TailFlagName                  = "--tail"
+	TailOutputFlagName            = "--tail-output"
 	TargetPortFlagName            = "--target-port"
+	TimeoutFlagName               = "--timeout"
+	TimestampsFlagName            = "--timestamps"
+	UserFlagName                  = "--user"
+	WaitFlagName                  = "--wait"
 	WaitTimeoutFlagName           = "--wait-timeout"
+	WatchFlagName                 = "--watch"
+	WithDependenciesFlagName      = "--with-dependencies"
 )
 
 func AllNamespacesFlag(cmd *cobra.Command, c *Config, namespace *string, allNamespaces *bool) {
@@ -99,6 +126,9 @@ func NamespaceFlag(cmd *cobra.Command, c *Config, namespace *string) {
 	prior := cmd.PreRunE
 	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
 		if *namespace == "" {
+			if err := c.Check(); err != nil {
+				return KubeConfigHint(err)
+			}
 			*namespace = c.DefaultNamespace()
 		}
 		if prior != nil {
@@ -113,6 +143,29 @@ func NamespaceFlag(cmd *cobra.Command, c *Config, namespace *string) {
 	_ = cmd.MarkFlagCustom(StripDash(NamespaceFlagName), "__"+c.Name+"_list_namespaces")
 }
 
+func OutputFlag(cmd *cobra.Command, output *string) {
+	cmd.Flags().StringVarP(output, StripDash(OutputFlagName), "o", "", fmt.Sprintf("output `format`, one of: %s (defaults to a table)", strings.Join(printers.OutputFormats, ", ")))
+}
+
+func SelectorFlags(cmd *cobra.Command, selector, fieldSelector *string) {
+	cmd.Flags().StringVarP(selector, StripDash(SelectorFlagName), "l", "", "label `selector` to filter resources, supports '=', '==', '!=', 'in' and 'notin' (e.g. team=payments)")
+	cmd.Flags().StringVar(fieldSelector, StripDash(FieldSelectorFlagName), "", "field `selector` to filter resources, supports '=', '==' and '!=' (e.g. metadata.name=my-name)")
+}
+
+func StructuredOutputFlag(cmd *cobra.Command, output *string) {
+	cmd.Flags().StringVarP(output, StripDash(OutputFlagName), "o", "", fmt.Sprintf("output `format`, one of: %s (defaults to a human readable summary)", strings.Join(printers.StructuredOutputFormats, ", ")))
+}
+
+func TailFlags(cmd *cobra.Command, outputFlagName string, output, grep *string, timestamps *bool) {
+	cmd.Flags().StringVar(output, StripDash(outputFlagName), "", "log output `format`, one of: json (defaults to plain text)")
+	cmd.Flags().StringVar(grep, StripDash(GrepFlagName), "", "only show log lines matching the regular `expression`")
+	cmd.Flags().BoolVar(timestamps, StripDash(TimestampsFlagName), false, "prefix each log line with the time it was logged")
+}
+
+func WatchFlag(cmd *cobra.Command, watch *bool) {
+	cmd.Flags().BoolVarP(watch, StripDash(WatchFlagName), "w", false, "watch for changes to the listed resources after listing them")
+}
+
 func StripDash(flagName string) string {
 	return strings.Replace(flagName, "--", "", 1)
 }
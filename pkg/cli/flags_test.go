@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	"github.com/spf13/cobra"
 )
@@ -35,6 +36,7 @@ func TestAllNamespacesFlag(t *testing.T) {
 		actualNamespace     string
 		allNamespaces       bool
 		actualAllNamespaces bool
+		checkErr            error
 		err                 error
 	}{{
 		name:      "default",
@@ -66,12 +68,24 @@ func TestAllNamespacesFlag(t *testing.T) {
 			return fmt.Errorf("prior PreRunE error")
 		},
 		err: fmt.Errorf("prior PreRunE error"),
+	}, {
+		name:     "kube config error",
+		args:     []string{},
+		checkErr: &k8s.ConfigError{File: "/tmp/kube", Err: fmt.Errorf("bad yaml")},
+		err:      fmt.Errorf(`unable to load kube config "/tmp/kube": bad yaml; fix the kube config or set --kubeconfig`),
+	}, {
+		name:      "kube config error, explicit namespace",
+		args:      []string{cli.NamespaceFlagName, "my-namespace"},
+		checkErr:  &k8s.ConfigError{File: "/tmp/kube", Err: fmt.Errorf("bad yaml")},
+		namespace: "my-namespace",
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			c := cli.NewDefaultConfig()
-			c.Client = rifftesting.NewClient()
+			client := rifftesting.NewClient()
+			client.CheckError = test.checkErr
+			c.Client = client
 			cmd := &cobra.Command{
 				PreRunE: test.prior,
 				RunE: func(cmd *cobra.Command, args []string) error {
@@ -114,6 +128,7 @@ func TestNamespaceFlag(t *testing.T) {
 		prior           func(cmd *cobra.Command, args []string) error
 		namespace       string
 		actualNamespace string
+		checkErr        error
 		err             error
 	}{{
 		name:      "default",
@@ -137,12 +152,24 @@ func TestNamespaceFlag(t *testing.T) {
 			return fmt.Errorf("prior PreRunE error")
 		},
 		err: fmt.Errorf("prior PreRunE error"),
+	}, {
+		name:     "kube config error",
+		args:     []string{},
+		checkErr: &k8s.ConfigError{File: "/tmp/kube", Err: fmt.Errorf("bad yaml")},
+		err:      fmt.Errorf(`unable to load kube config "/tmp/kube": bad yaml; fix the kube config or set --kubeconfig`),
+	}, {
+		name:      "kube config error, explicit namespace",
+		args:      []string{cli.NamespaceFlagName, "my-namespace"},
+		checkErr:  &k8s.ConfigError{File: "/tmp/kube", Err: fmt.Errorf("bad yaml")},
+		namespace: "my-namespace",
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			c := cli.NewDefaultConfig()
-			c.Client = rifftesting.NewClient()
+			client := rifftesting.NewClient()
+			client.CheckError = test.checkErr
+			c.Client = client
 			cmd := &cobra.Command{
 				PreRunE: test.prior,
 				RunE: func(cmd *cobra.Command, args []string) error {
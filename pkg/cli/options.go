@@ -65,6 +65,6 @@ func ExecOptions(ctx context.Context, c *Config, opts Executable) func(cmd *cobr
 			ctx = withStdout(ctx, c.Stdout)
 			c.Stdout = c.Stderr
 		}
-		return opts.Exec(ctx, c)
+		return KubeConfigHint(opts.Exec(ctx, c))
 	}
 }
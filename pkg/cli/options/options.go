@@ -18,14 +18,29 @@ package options
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/kail"
+	"github.com/projectriff/cli/pkg/race"
 	"github.com/projectriff/cli/pkg/validation"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
 )
 
 type ListOptions struct {
 	Namespace     string
 	AllNamespaces bool
+	Output        string
+	Watch         bool
+	Selector      string
+	FieldSelector string
 }
 
 func (opts *ListOptions) Validate(ctx context.Context) cli.FieldErrors {
@@ -38,9 +53,21 @@ func (opts *ListOptions) Validate(ctx context.Context) cli.FieldErrors {
 		errs = errs.Also(cli.ErrMultipleOneOf(cli.NamespaceFlagName, cli.AllNamespacesFlagName))
 	}
 
+	errs = errs.Also(validation.OutputFormat(opts.Output, cli.OutputFlagName))
+	errs = errs.Also(validation.LabelSelector(opts.Selector, cli.SelectorFlagName))
+	errs = errs.Also(validation.FieldSelector(opts.FieldSelector, cli.FieldSelectorFlagName))
+
 	return errs
 }
 
+// K8sListOptions filter the listed resources by the label and field selectors.
+func (opts *ListOptions) K8sListOptions() metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: opts.Selector,
+		FieldSelector: opts.FieldSelector,
+	}
+}
+
 type ResourceOptions struct {
 	Namespace string
 	Name      string
@@ -63,9 +90,13 @@ func (opts *ResourceOptions) Validate(ctx context.Context) cli.FieldErrors {
 }
 
 type DeleteOptions struct {
-	Namespace string
-	Names     []string
-	All       bool
+	Namespace     string
+	Names         []string
+	All           bool
+	Selector      string
+	FieldSelector string
+	Wait          bool
+	WaitTimeout   time.Duration
 }
 
 func (opts *DeleteOptions) Validate(ctx context.Context) cli.FieldErrors {
@@ -81,8 +112,132 @@ func (opts *DeleteOptions) Validate(ctx context.Context) cli.FieldErrors {
 	if !opts.All && len(opts.Names) == 0 {
 		errs = errs.Also(cli.ErrMissingOneOf(cli.AllFlagName, cli.NamesArgumentName))
 	}
+	// selectors filter the resources deleted with --all
+	if opts.Selector != "" && len(opts.Names) != 0 {
+		errs = errs.Also(cli.ErrMultipleOneOf(cli.SelectorFlagName, cli.NamesArgumentName))
+	}
+	if opts.FieldSelector != "" && len(opts.Names) != 0 {
+		errs = errs.Also(cli.ErrMultipleOneOf(cli.FieldSelectorFlagName, cli.NamesArgumentName))
+	}
 
 	errs = errs.Also(validation.K8sNames(opts.Names, cli.NamesArgumentName))
+	errs = errs.Also(validation.LabelSelector(opts.Selector, cli.SelectorFlagName))
+	errs = errs.Also(validation.FieldSelector(opts.FieldSelector, cli.FieldSelectorFlagName))
+
+	if opts.WaitTimeout < 0 {
+		errs = errs.Also(cli.ErrInvalidValue(opts.WaitTimeout, cli.WaitTimeoutFlagName))
+	}
+
+	return errs
+}
+
+// K8sListOptions filter the resources deleted with --all by the label and
+// field selectors.
+func (opts *DeleteOptions) K8sListOptions() metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: opts.Selector,
+		FieldSelector: opts.FieldSelector,
+	}
+}
+
+// DeletedResource describes a kind of resource, and the pods it owns, to wait
+// on while the resource is removed.
+type DeletedResource struct {
+	Client   rest.Interface
+	Resource string
+	// ObjType is an empty instance of the resource
+	ObjType runtime.Object
+	// PodLabelKey labels pods with the name of the resource that owns them,
+	// empty for resources without pods
+	PodLabelKey string
+}
+
+// DeletedNames are the names of the resources matching the list options that
+// are deleted with --all. The resources are only listed when waiting.
+func (opts *DeleteOptions) DeletedNames(ctx context.Context, deleted DeletedResource, listOptions metav1.ListOptions) ([]string, error) {
+	if !opts.Wait {
+		return nil, nil
+	}
+	return k8s.ListNames(ctx, deleted.Client, deleted.Resource, opts.Namespace, listOptions)
+}
+
+// WaitForDeletion blocks until the named resources and their pods are removed,
+// when waiting.
+func (opts *DeleteOptions) WaitForDeletion(ctx context.Context, c *cli.Config, deleted DeletedResource, names []string) error {
+	if !opts.Wait || len(names) == 0 {
+		return nil
+	}
+
+	tasks := []race.Task{}
+	for _, name := range names {
+		name := name
+		tasks = append(tasks, func(ctx context.Context) error {
+			listOptions := metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String()}
+			return k8s.WaitUntilDeleted(ctx, deleted.Client, deleted.Resource, opts.Namespace, listOptions, deleted.ObjType)
+		})
+		if deleted.PodLabelKey != "" {
+			tasks = append(tasks, func(ctx context.Context) error {
+				listOptions := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", deleted.PodLabelKey, name)}
+				return k8s.WaitUntilDeleted(ctx, c.Core().RESTClient(), "pods", opts.Namespace, listOptions, &corev1.Pod{})
+			})
+		}
+	}
+
+	err := race.Run(ctx, opts.WaitTimeout, func(ctx context.Context) error {
+		// every task must complete, unlike a race
+		errs := make(chan error, len(tasks))
+		for _, task := range tasks {
+			go func(task race.Task) {
+				errs <- task(ctx)
+			}(task)
+		}
+		for range tasks {
+			if err := <-errs; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == context.DeadlineExceeded {
+		c.Errorf("Timeout after %q waiting for %s to be deleted\n", opts.WaitTimeout, strings.Join(names, ", "))
+		return cli.SilenceError(err)
+	}
+	return err
+}
+
+// TailOptions control the format of streamed logs. The output format is
+// registered under the outputFlagName, commands that create resources use
+// cli.TailOutputFlagName as the output flag may already be taken.
+type TailOptions struct {
+	Containers []string
+	Output     string
+	Grep       string
+	Timestamps bool
+}
+
+func (opts *TailOptions) Validate(ctx context.Context, outputFlagName string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if opts.Output != "" && opts.Output != kail.LogOutputJSON {
+		errs = errs.Also(cli.ErrInvalidValue(opts.Output, outputFlagName))
+	}
+	errs = errs.Also(validation.Regexp(opts.Grep, cli.GrepFlagName))
+	errs = errs.Also(validation.K8sNames(opts.Containers, cli.ContainerFlagName))
 
 	return errs
 }
+
+// LogOptions follow logs starting from since in the requested format.
+func (opts *TailOptions) LogOptions(since time.Duration) kail.LogOptions {
+	logOptions := kail.LogOptions{
+		Since:      since,
+		Follow:     true,
+		Output:     opts.Output,
+		Grep:       opts.Grep,
+		Timestamps: opts.Timestamps,
+	}
+	if len(opts.Containers) != 0 {
+		logOptions.Containers = opts.Containers
+	}
+	return logOptions
+}
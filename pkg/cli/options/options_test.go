@@ -17,10 +17,14 @@
 package options_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/kail"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 )
 
@@ -53,6 +57,47 @@ func TestListOptions(t *testing.T) {
 			},
 			ExpectFieldErrors: cli.ErrMultipleOneOf(cli.NamespaceFlagName, cli.AllNamespacesFlagName),
 		},
+		{
+			Name: "structured output",
+			Options: &options.ListOptions{
+				Namespace: "default",
+				Output:    "json",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid output",
+			Options: &options.ListOptions{
+				Namespace: "default",
+				Output:    "xml",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("xml", cli.OutputFlagName),
+		},
+		{
+			Name: "selectors",
+			Options: &options.ListOptions{
+				Namespace:     "default",
+				Selector:      "team=payments",
+				FieldSelector: "metadata.name=my-function",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid selector",
+			Options: &options.ListOptions{
+				Namespace: "default",
+				Selector:  "team in payments",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("team in payments", cli.SelectorFlagName),
+		},
+		{
+			Name: "invalid field selector",
+			Options: &options.ListOptions{
+				Namespace:     "default",
+				FieldSelector: "metadata.name",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("metadata.name", cli.FieldSelectorFlagName),
+		},
 	}
 
 	table.Run(t)
@@ -136,6 +181,26 @@ func TestDeleteOptions(t *testing.T) {
 			},
 			ShouldValidate: true,
 		},
+		{
+			Name: "wait",
+			Options: &options.DeleteOptions{
+				Namespace:   "default",
+				Names:       []string{"my-function"},
+				Wait:        true,
+				WaitTimeout: time.Minute,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid wait timeout",
+			Options: &options.DeleteOptions{
+				Namespace:   "default",
+				Names:       []string{"my-function"},
+				Wait:        true,
+				WaitTimeout: -time.Minute,
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue(-time.Minute, cli.WaitTimeoutFlagName),
+		},
 		{
 			Name: "all with name",
 			Options: &options.DeleteOptions{
@@ -152,7 +217,119 @@ func TestDeleteOptions(t *testing.T) {
 			},
 			ExpectFieldErrors: cli.ErrMissingField(cli.NamespaceFlagName),
 		},
+		{
+			Name: "all with selectors",
+			Options: &options.DeleteOptions{
+				Namespace:     "default",
+				All:           true,
+				Selector:      "team=payments",
+				FieldSelector: "metadata.name!=my-function",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "selectors with name",
+			Options: &options.DeleteOptions{
+				Namespace:     "default",
+				Names:         []string{"my-function"},
+				Selector:      "team=payments",
+				FieldSelector: "metadata.name!=my-function",
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrMultipleOneOf(cli.SelectorFlagName, cli.NamesArgumentName),
+				cli.ErrMultipleOneOf(cli.FieldSelectorFlagName, cli.NamesArgumentName),
+			),
+		},
+		{
+			Name: "selector without all",
+			Options: &options.DeleteOptions{
+				Namespace: "default",
+				Selector:  "team=payments",
+			},
+			ExpectFieldErrors: cli.ErrMissingOneOf(cli.AllFlagName, cli.NamesArgumentName),
+		},
+		{
+			Name: "invalid selectors",
+			Options: &options.DeleteOptions{
+				Namespace:     "default",
+				All:           true,
+				Selector:      "team in payments",
+				FieldSelector: "metadata.name",
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidValue("team in payments", cli.SelectorFlagName),
+				cli.ErrInvalidValue("metadata.name", cli.FieldSelectorFlagName),
+			),
+		},
 	}
 
 	table.Run(t)
 }
+
+func TestTailOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  *options.TailOptions
+		expected cli.FieldErrors
+	}{{
+		name:     "default",
+		options:  &options.TailOptions{},
+		expected: cli.FieldErrors{},
+	}, {
+		name: "json output",
+		options: &options.TailOptions{
+			Output:     kail.LogOutputJSON,
+			Grep:       "^ERROR",
+			Timestamps: true,
+		},
+		expected: cli.FieldErrors{},
+	}, {
+		name: "invalid output",
+		options: &options.TailOptions{
+			Output: "yaml",
+		},
+		expected: cli.ErrInvalidValue("yaml", cli.TailOutputFlagName),
+	}, {
+		name: "invalid grep",
+		options: &options.TailOptions{
+			Grep: "(",
+		},
+		expected: cli.ErrInvalidValue("(", cli.GrepFlagName),
+	}, {
+		name: "invalid container",
+		options: &options.TailOptions{
+			Containers: []string{""},
+		},
+		expected: cli.ErrInvalidArrayValue("", cli.ContainerFlagName, 0),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.options.Validate(context.TODO(), cli.TailOutputFlagName)
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("Validate() (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
+func TestTailOptions_LogOptions(t *testing.T) {
+	opts := &options.TailOptions{
+		Containers: []string{"processor"},
+		Output:     kail.LogOutputJSON,
+		Grep:       "^ERROR",
+		Timestamps: true,
+	}
+	expected := kail.LogOptions{
+		Since:      time.Minute,
+		Follow:     true,
+		Containers: []string{"processor"},
+		Output:     kail.LogOutputJSON,
+		Grep:       "^ERROR",
+		Timestamps: true,
+	}
+	actual := opts.LogOptions(time.Minute)
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("LogOptions() (-expected, +actual): %s", diff)
+	}
+}
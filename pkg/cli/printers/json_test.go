@@ -0,0 +1,78 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package printers_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli/printers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJSONPrinter(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-config"},
+		Data:       map[string]string{"key": "value"},
+	}
+	output := &bytes.Buffer{}
+	if err := (&printers.JSONPrinter{}).PrintObj(configMap, output); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{
+    "kind": "ConfigMap",
+    "apiVersion": "v1",
+    "metadata": {
+        "name": "my-config",
+        "namespace": "default",
+        "creationTimestamp": null
+    },
+    "data": {
+        "key": "value"
+    }
+}
+`
+	if actual := output.String(); expected != actual {
+		t.Errorf("expected output %q, actually %q", expected, actual)
+	}
+}
+
+func TestYAMLPrinter(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-config"},
+		Data:       map[string]string{"key": "value"},
+	}
+	output := &bytes.Buffer{}
+	if err := (&printers.YAMLPrinter{}).PrintObj(configMap, output); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `apiVersion: v1
+data:
+  key: value
+kind: ConfigMap
+metadata:
+  creationTimestamp: null
+  name: my-config
+  namespace: default
+`
+	if actual := output.String(); expected != actual {
+		t.Errorf("expected output %q, actually %q", expected, actual)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package printers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NamePrinter is an implementation of ResourcePrinter which outputs "kind/name" for each object.
+// The kind must be populated on the object.
+type NamePrinter struct{}
+
+// PrintObj is an implementation of ResourcePrinter.PrintObj which decodes the object and prints
+// "kind/name" pairs, one per line.
+func (p *NamePrinter) PrintObj(obj runtime.Object, w io.Writer) error {
+	if meta.IsListType(obj) {
+		return meta.EachListItem(obj, func(item runtime.Object) error {
+			return p.PrintObj(item, w)
+		})
+	}
+
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		return fmt.Errorf("missing kind for %T", obj)
+	}
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s/%s\n", strings.ToLower(kind), m.GetName())
+	return err
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package printers_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli/printers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNamePrinter(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  runtime.Object
+		output string
+		err    error
+	}{{
+		name: "object",
+		input: &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: "my-config"},
+		},
+		output: "configmap/my-config\n",
+	}, {
+		name: "list",
+		input: &corev1.ConfigMapList{
+			Items: []corev1.ConfigMap{
+				{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+					ObjectMeta: metav1.ObjectMeta{Name: "my-config"},
+				},
+				{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+					ObjectMeta: metav1.ObjectMeta{Name: "my-other-config"},
+				},
+			},
+		},
+		output: "configmap/my-config\nconfigmap/my-other-config\n",
+	}, {
+		name: "missing kind",
+		input: &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-config"},
+		},
+		err: fmt.Errorf("missing kind for *v1.ConfigMap"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			err := (&printers.NamePrinter{}).PrintObj(test.input, output)
+			if expected, actual := fmt.Sprintf("%v", test.err), fmt.Sprintf("%v", err); expected != actual {
+				t.Errorf("expected error %q, actually %q", expected, actual)
+			}
+			if expected, actual := test.output, output.String(); expected != actual {
+				t.Errorf("expected output %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package printers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	OutputFormatWide       = "wide"
+	OutputFormatJSON       = "json"
+	OutputFormatYAML       = "yaml"
+	OutputFormatName       = "name"
+	OutputFormatJSONPath   = "jsonpath"
+	OutputFormatGoTemplate = "go-template"
+)
+
+// StructuredOutputFormats are the values accepted for an output format that is rendered from the
+// resource itself rather than a table. Templated formats take the template after an equals sign.
+var StructuredOutputFormats = []string{
+	OutputFormatJSON,
+	OutputFormatYAML,
+	OutputFormatName,
+	OutputFormatJSONPath + "=<template>",
+	OutputFormatGoTemplate + "=<template>",
+}
+
+// OutputFormats are the values accepted for an output format. The empty string selects the
+// default human readable table.
+var OutputFormats = append([]string{OutputFormatWide}, StructuredOutputFormats...)
+
+// IsHumanReadable returns true for output formats that are rendered as a table.
+func IsHumanReadable(format string) bool {
+	return format == "" || format == OutputFormatWide
+}
+
+// NewPrinterForFormat creates a printer for structured output formats. Human readable formats
+// are not supported, use a HumanReadablePrinter instead.
+func NewPrinterForFormat(format string) (ResourcePrinter, error) {
+	switch {
+	case format == OutputFormatJSON:
+		return &JSONPrinter{}, nil
+	case format == OutputFormatYAML:
+		return &YAMLPrinter{}, nil
+	case format == OutputFormatName:
+		return &NamePrinter{}, nil
+	case strings.HasPrefix(format, OutputFormatJSONPath+"="):
+		return NewJSONPathPrinter(strings.TrimPrefix(format, OutputFormatJSONPath+"="))
+	case strings.HasPrefix(format, OutputFormatGoTemplate+"="):
+		return NewGoTemplatePrinter(strings.TrimPrefix(format, OutputFormatGoTemplate+"="))
+	}
+	return nil, fmt.Errorf("unknown output format %q", format)
+}
+
+// PrintList writes the list to the output in the requested format. Human readable formats are
+// printed by the table printer. Structured formats need the list and each item to declare their
+// kind, which typed clients do not populate, so the TypeMeta is defaulted from the item kind.
+func PrintList(format string, tablePrinter ResourcePrinter, list runtime.Object, itemKind schema.GroupVersionKind, output io.Writer) error {
+	if IsHumanReadable(format) {
+		return tablePrinter.PrintObj(list, output)
+	}
+	printer, err := NewPrinterForFormat(format)
+	if err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		item.GetObjectKind().SetGroupVersionKind(itemKind)
+	}
+	// always set the items, an empty list is rendered as an empty array rather than null
+	if err := meta.SetList(list, items); err != nil {
+		return err
+	}
+	list.GetObjectKind().SetGroupVersionKind(itemKind.GroupVersion().WithKind(itemKind.Kind + "List"))
+	return printer.PrintObj(list, output)
+}
+
+// PrintObject writes a single resource to the output in a structured format. Typed clients do
+// not populate the TypeMeta for the resource, so it is defaulted from the kind.
+func PrintObject(format string, obj runtime.Object, kind schema.GroupVersionKind, output io.Writer) error {
+	printer, err := NewPrinterForFormat(format)
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(kind)
+	return printer.PrintObj(obj, output)
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package printers_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli/printers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+)
+
+func TestIsHumanReadable(t *testing.T) {
+	tests := []struct {
+		format string
+		output bool
+	}{
+		{format: "", output: true},
+		{format: printers.OutputFormatWide, output: true},
+		{format: printers.OutputFormatJSON, output: false},
+		{format: printers.OutputFormatYAML, output: false},
+		{format: printers.OutputFormatName, output: false},
+		{format: "jsonpath={.metadata.name}", output: false},
+		{format: "go-template={{.metadata.name}}", output: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			if expected, actual := test.output, printers.IsHumanReadable(test.format); expected != actual {
+				t.Errorf("expected %v, actually %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewPrinterForFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		printer printers.ResourcePrinter
+		err     error
+	}{{
+		name:    "json",
+		format:  "json",
+		printer: &printers.JSONPrinter{},
+	}, {
+		name:    "yaml",
+		format:  "yaml",
+		printer: &printers.YAMLPrinter{},
+	}, {
+		name:    "name",
+		format:  "name",
+		printer: &printers.NamePrinter{},
+	}, {
+		name:    "jsonpath",
+		format:  "jsonpath={.metadata.name}",
+		printer: &printers.JSONPathPrinter{},
+	}, {
+		name:    "go-template",
+		format:  "go-template={{.metadata.name}}",
+		printer: &printers.GoTemplatePrinter{},
+	}, {
+		name:   "jsonpath without template",
+		format: "jsonpath",
+		err:    fmt.Errorf(`unknown output format "jsonpath"`),
+	}, {
+		name:   "bad jsonpath",
+		format: "jsonpath={.metadata.name",
+		err:    fmt.Errorf("unclosed action"),
+	}, {
+		name:   "bad go-template",
+		format: "go-template={{.metadata.name",
+		err:    fmt.Errorf("template: output:1: unclosed action"),
+	}, {
+		name:   "human readable",
+		format: "wide",
+		err:    fmt.Errorf(`unknown output format "wide"`),
+	}, {
+		name:   "unknown",
+		format: "xml",
+		err:    fmt.Errorf(`unknown output format "xml"`),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			printer, err := printers.NewPrinterForFormat(test.format)
+			if expected, actual := fmt.Sprintf("%v", test.err), fmt.Sprintf("%v", err); expected != actual {
+				t.Errorf("expected error %q, actually %q", expected, actual)
+			}
+			if expected, actual := fmt.Sprintf("%T", test.printer), fmt.Sprintf("%T", printer); test.err == nil && expected != actual {
+				t.Errorf("expected printer %s, actually %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestPrintList(t *testing.T) {
+	kind := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{}).With(func(h printers.PrintHandler) {
+		columns := []metav1beta1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+		}
+		h.TableHandler(columns, func(list *corev1.ConfigMapList, _ printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+			rows := []metav1beta1.TableRow{}
+			for _, item := range list.Items {
+				rows = append(rows, metav1beta1.TableRow{Cells: []interface{}{item.Name}})
+			}
+			return rows, nil
+		})
+	})
+
+	tests := []struct {
+		name   string
+		format string
+		list   *corev1.ConfigMapList
+		output string
+		err    error
+	}{{
+		name:   "table",
+		format: "",
+		list: &corev1.ConfigMapList{
+			Items: []corev1.ConfigMap{{ObjectMeta: metav1.ObjectMeta{Name: "my-config"}}},
+		},
+		output: "NAME\nmy-config\n",
+	}, {
+		name:   "name",
+		format: "name",
+		list: &corev1.ConfigMapList{
+			Items: []corev1.ConfigMap{{ObjectMeta: metav1.ObjectMeta{Name: "my-config"}}},
+		},
+		output: "configmap/my-config\n",
+	}, {
+		name:   "jsonpath",
+		format: "jsonpath={.kind}: {.items[*].kind}",
+		list: &corev1.ConfigMapList{
+			Items: []corev1.ConfigMap{{ObjectMeta: metav1.ObjectMeta{Name: "my-config"}}},
+		},
+		output: "ConfigMapList: ConfigMap",
+	}, {
+		name:   "empty json",
+		format: "json",
+		list:   &corev1.ConfigMapList{},
+		output: `{
+    "kind": "ConfigMapList",
+    "apiVersion": "v1",
+    "metadata": {},
+    "items": []
+}
+`,
+	}, {
+		name:   "unknown format",
+		format: "xml",
+		list:   &corev1.ConfigMapList{},
+		err:    fmt.Errorf(`unknown output format "xml"`),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			err := printers.PrintList(test.format, tablePrinter, test.list, kind, output)
+			if expected, actual := fmt.Sprintf("%v", test.err), fmt.Sprintf("%v", err); expected != actual {
+				t.Errorf("expected error %q, actually %q", expected, actual)
+			}
+			if expected, actual := test.output, output.String(); expected != actual {
+				t.Errorf("expected output %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
+
+func TestPrintObject(t *testing.T) {
+	kind := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+
+	tests := []struct {
+		name   string
+		format string
+		output string
+		err    error
+	}{{
+		name:   "yaml",
+		format: "yaml",
+		output: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  creationTimestamp: null
+  name: my-config
+`,
+	}, {
+		name:   "name",
+		format: "name",
+		output: "configmap/my-config\n",
+	}, {
+		name:   "go-template",
+		format: "go-template={{.kind}}/{{.metadata.name}}",
+		output: "ConfigMap/my-config",
+	}, {
+		name:   "unknown format",
+		format: "xml",
+		err:    fmt.Errorf(`unknown output format "xml"`),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "my-config"}}
+			output := &bytes.Buffer{}
+			err := printers.PrintObject(test.format, configMap, kind, output)
+			if expected, actual := fmt.Sprintf("%v", test.err), fmt.Sprintf("%v", err); expected != actual {
+				t.Errorf("expected error %q, actually %q", expected, actual)
+			}
+			if expected, actual := test.output, output.String(); expected != actual {
+				t.Errorf("expected output %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
@@ -62,6 +62,18 @@ func NewTablePrinter(options PrintOptions) *HumanReadablePrinter {
 	return printer
 }
 
+// WithoutHeaders creates a printer sharing the handlers of this printer that
+// only prints rows, for example to print items received from a watch after the
+// initial list.
+func (h *HumanReadablePrinter) WithoutHeaders() *HumanReadablePrinter {
+	options := h.options
+	options.NoHeaders = true
+	return &HumanReadablePrinter{
+		handlerMap: h.handlerMap,
+		options:    options,
+	}
+}
+
 func printHeader(columnNames []string, w io.Writer) error {
 	if _, err := fmt.Fprintf(w, "%s\n", strings.Join(columnNames, "\t")); err != nil {
 		return err
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package printers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// JSONPathPrinter is an implementation of ResourcePrinter which formats data with a jsonpath
+// expression.
+type JSONPathPrinter struct {
+	*jsonpath.JSONPath
+}
+
+// NewJSONPathPrinter parses the jsonpath expression. Expressions without braces are wrapped, so
+// ".status.url" is the same as "{.status.url}".
+func NewJSONPathPrinter(tmpl string) (*JSONPathPrinter, error) {
+	if tmpl == "" {
+		return nil, fmt.Errorf("missing jsonpath template")
+	}
+	if !strings.Contains(tmpl, "{") {
+		tmpl = fmt.Sprintf("{%s}", tmpl)
+	}
+	j := jsonpath.New("out").AllowMissingKeys(true)
+	if err := j.Parse(tmpl); err != nil {
+		return nil, err
+	}
+	return &JSONPathPrinter{JSONPath: j}, nil
+}
+
+// PrintObj formats the obj with the jsonpath expression. The expression is evaluated against
+// the JSON representation of the object, so field names match the resource manifest.
+func (p *JSONPathPrinter) PrintObj(obj runtime.Object, w io.Writer) error {
+	data, err := toJSONData(obj)
+	if err != nil {
+		return err
+	}
+	return p.JSONPath.Execute(w, data)
+}
+
+// GoTemplatePrinter is an implementation of ResourcePrinter which formats data with a Go
+// template.
+type GoTemplatePrinter struct {
+	template *template.Template
+}
+
+// NewGoTemplatePrinter parses the Go template.
+func NewGoTemplatePrinter(tmpl string) (*GoTemplatePrinter, error) {
+	if tmpl == "" {
+		return nil, fmt.Errorf("missing go-template template")
+	}
+	t, err := template.New("output").Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	return &GoTemplatePrinter{template: t}, nil
+}
+
+// PrintObj formats the obj with the Go template. The template is executed against the JSON
+// representation of the object, so field names match the resource manifest.
+func (p *GoTemplatePrinter) PrintObj(obj runtime.Object, w io.Writer) error {
+	data, err := toJSONData(obj)
+	if err != nil {
+		return err
+	}
+	return p.template.Execute(w, data)
+}
+
+func toJSONData(obj runtime.Object) (interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package printers_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli/printers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJSONPathPrinter(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-config"},
+		Data:       map[string]string{"key": "value"},
+	}
+
+	tests := []struct {
+		name        string
+		template    string
+		output      string
+		shouldError bool
+	}{{
+		name:     "template",
+		template: "{.metadata.name}={.data.key}",
+		output:   "my-config=value",
+	}, {
+		name:     "without braces",
+		template: ".metadata.name",
+		output:   "my-config",
+	}, {
+		name:     "missing key",
+		template: "{.data.missing}",
+		output:   "",
+	}, {
+		name:        "empty template",
+		template:    "",
+		shouldError: true,
+	}, {
+		name:        "invalid template",
+		template:    "{.metadata.name",
+		shouldError: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			printer, err := printers.NewJSONPathPrinter(test.template)
+			if (err != nil) != test.shouldError {
+				t.Fatalf("expected error %v, actually %v", test.shouldError, err)
+			}
+			if err != nil {
+				return
+			}
+			output := &bytes.Buffer{}
+			if err := printer.PrintObj(configMap, output); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if expected, actual := test.output, output.String(); expected != actual {
+				t.Errorf("expected output %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
+
+func TestGoTemplatePrinter(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-config"},
+		Data:       map[string]string{"key": "value"},
+	}
+
+	tests := []struct {
+		name        string
+		template    string
+		output      string
+		shouldError bool
+		execError   bool
+	}{{
+		name:     "template",
+		template: "{{.metadata.name}}={{.data.key}}",
+		output:   "my-config=value",
+	}, {
+		name:     "range",
+		template: "{{range $k, $v := .data}}{{$k}}:{{$v}}{{end}}",
+		output:   "key:value",
+	}, {
+		name:        "empty template",
+		template:    "",
+		shouldError: true,
+	}, {
+		name:        "invalid template",
+		template:    "{{.metadata.name",
+		shouldError: true,
+	}, {
+		name:      "execution error",
+		template:  "{{index .metadata 1}}",
+		execError: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			printer, err := printers.NewGoTemplatePrinter(test.template)
+			if (err != nil) != test.shouldError {
+				t.Fatalf("expected error %v, actually %v", test.shouldError, err)
+			}
+			if err != nil {
+				return
+			}
+			output := &bytes.Buffer{}
+			err = printer.PrintObj(configMap, output)
+			if (err != nil) != test.execError {
+				t.Fatalf("expected execution error %v, actually %v", test.execError, err)
+			}
+			if err != nil {
+				return
+			}
+			if expected, actual := test.output, output.String(); expected != actual {
+				t.Errorf("expected output %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
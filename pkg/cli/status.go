@@ -17,10 +17,26 @@
 package cli
 
 import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/ghodss/yaml"
+	"github.com/projectriff/cli/pkg/cli/printers"
 	"github.com/projectriff/system/pkg/apis"
+	"github.com/projectriff/system/pkg/refs"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// maxStatusEvents is the number of the most recent events displayed
+const maxStatusEvents = 10
+
 func PrintResourceStatus(c *Config, name string, condition *apis.Condition) {
 	c.Printf("# %s: %s\n", name, FormatConditionStatus(condition))
 	if condition != nil {
@@ -29,3 +45,249 @@ func PrintResourceStatus(c *Config, name string, condition *apis.Condition) {
 		c.Printf("%s", string(s))
 	}
 }
+
+// StatusDetail is a key field from the status of a resource. Details without a
+// value are not displayed.
+type StatusDetail struct {
+	Name  string
+	Value string
+}
+
+// ResourceStatusDetails describe the state of a resource beyond its Ready
+// condition.
+type ResourceStatusDetails struct {
+	// GroupVersionKind of the resource, used to find events about the
+	// resource
+	GroupVersionKind schema.GroupVersionKind
+	Namespace        string
+	Name             string
+	// Conditions for the resource
+	Conditions apis.Conditions
+	// Details are key fields from the status of the resource
+	Details []StatusDetail
+	// PodSelector is a label selector for the pods of the resource, events
+	// about the pods are displayed alongside events about the resource
+	PodSelector string
+	// Deployment is the name of the deployment backing the resource, the
+	// replica counts for the deployment are displayed
+	Deployment string
+}
+
+// PrintResourceStatusDetails displays the key status fields, all conditions and
+// the most recent events for a resource and its pods.
+func PrintResourceStatusDetails(c *Config, status ResourceStatusDetails) error {
+	details := status.Details
+	if status.Deployment != "" {
+		replicas, err := deploymentReplicas(c, status.Namespace, status.Deployment)
+		if err != nil {
+			return err
+		}
+		details = append(details, StatusDetail{Name: "Replicas", Value: replicas})
+	}
+	printStatusDetails(c.Stdout, details)
+
+	c.Printf("\nConditions:\n")
+	printStatusConditions(c.Stdout, status.Conditions)
+
+	events, err := resourceEvents(c, status)
+	if err != nil && !apierrs.IsForbidden(err) {
+		return err
+	}
+	c.Printf("\nEvents:\n")
+	if err != nil {
+		// events are not required to show the status, note they are hidden
+		c.Printf("  <forbidden>\n")
+	} else {
+		printStatusEvents(c.Stdout, events)
+	}
+
+	return nil
+}
+
+// PrintStatusDetails displays the key fields for a resource. Details without a
+// value are not displayed.
+func PrintStatusDetails(c *Config, details []StatusDetail) {
+	printStatusDetails(c.Stdout, details)
+}
+
+func printStatusDetails(out io.Writer, details []StatusDetail) {
+	w := printers.GetNewTabWriter(out)
+	printed := false
+	for _, detail := range details {
+		if detail.Value == "" {
+			continue
+		}
+		if !printed {
+			fmt.Fprintf(w, "\nDetails:\n")
+			printed = true
+		}
+		fmt.Fprintf(w, "  %s:\t%s\n", detail.Name, detail.Value)
+	}
+	w.Flush()
+}
+
+func printStatusConditions(out io.Writer, conditions apis.Conditions) {
+	if len(conditions) == 0 {
+		fmt.Fprintf(out, "  <none>\n")
+		return
+	}
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintf(w, "  TYPE\tSTATUS\tREASON\tLAST TRANSITION\tMESSAGE\n")
+	for _, condition := range conditions {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n",
+			condition.Type,
+			condition.Status,
+			formatStatusValue(condition.Reason),
+			formatStatusTime(condition.LastTransitionTime.Inner.Time),
+			formatStatusValue(condition.Message),
+		)
+	}
+	w.Flush()
+}
+
+func printStatusEvents(out io.Writer, events []corev1.Event) {
+	if len(events) == 0 {
+		fmt.Fprintf(out, "  <none>\n")
+		return
+	}
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintf(w, "  LAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE\n")
+	for _, event := range events {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n",
+			formatStatusTime(eventTime(event)),
+			event.Type,
+			event.Reason,
+			fmt.Sprintf("%s/%s", strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name),
+			strings.TrimSpace(event.Message),
+		)
+	}
+	w.Flush()
+}
+
+func formatStatusValue(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
+
+func formatStatusTime(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func deploymentReplicas(c *Config, namespace, name string) (string, error) {
+	deployment, err := c.Apps().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			return "", nil
+		}
+		if apierrs.IsForbidden(err) {
+			return "<forbidden>", nil
+		}
+		return "", err
+	}
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	return fmt.Sprintf("%d desired, %d updated, %d ready, %d available",
+		desired,
+		deployment.Status.UpdatedReplicas,
+		deployment.Status.ReadyReplicas,
+		deployment.Status.AvailableReplicas,
+	), nil
+}
+
+// resourceEvents are the most recent events about the resource and its pods,
+// oldest first. Events about the pods are skipped when listing pods is
+// forbidden.
+func resourceEvents(c *Config, status ResourceStatusDetails) ([]corev1.Event, error) {
+	pods := map[string]bool{}
+	if status.PodSelector != "" {
+		podList, err := c.Core().Pods(status.Namespace).List(metav1.ListOptions{LabelSelector: status.PodSelector})
+		if err != nil && !apierrs.IsForbidden(err) {
+			return nil, err
+		}
+		if err == nil {
+			for _, pod := range podList.Items {
+				pods[pod.Name] = true
+			}
+		}
+	}
+
+	events := []corev1.Event{}
+	eventList, err := c.Core().Events(status.Namespace).List(metav1.ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind": status.GroupVersionKind.Kind,
+			"involvedObject.name": status.Name,
+		}.AsSelector().String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range eventList.Items {
+		if isStatusResource(event.InvolvedObject, status) {
+			events = append(events, event)
+		}
+	}
+	if len(pods) != 0 {
+		// list events for all pods at once rather than a request per pod
+		eventList, err := c.Core().Events(status.Namespace).List(metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("involvedObject.kind", "Pod").String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range eventList.Items {
+			involved := event.InvolvedObject
+			if involved.Kind == "Pod" && pods[involved.Name] {
+				events = append(events, event)
+			}
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	if len(events) > maxStatusEvents {
+		events = events[len(events)-maxStatusEvents:]
+	}
+	return events, nil
+}
+
+func isStatusResource(involved corev1.ObjectReference, status ResourceStatusDetails) bool {
+	gvk := status.GroupVersionKind
+	if involved.Kind != gvk.Kind || involved.Name != status.Name {
+		return false
+	}
+	return involved.APIVersion == "" || involved.APIVersion == gvk.GroupVersion().String()
+}
+
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.FirstTimestamp.Time
+}
+
+// StatusRefName is the name of the referenced resource, or empty when there is
+// no reference
+func StatusRefName(ref *refs.TypedLocalObjectReference) string {
+	if ref == nil {
+		return ""
+	}
+	return ref.Name
+}
+
+// StatusAddress is the url of the address, or empty when there is no address
+func StatusAddress(address *apis.Addressable) string {
+	if address == nil {
+		return ""
+	}
+	return address.URL
+}
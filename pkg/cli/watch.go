@@ -0,0 +1,96 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/projectriff/cli/pkg/k8s"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+)
+
+// WatchListOptions describe the resources watched by a list command.
+type WatchListOptions struct {
+	Client    rest.Interface
+	Resource  string
+	Namespace string
+	// ListOptions with the selectors used for the initial list
+	ListOptions metav1.ListOptions
+	// Output format, the same as for the initial list
+	Output string
+	// TablePrinter used to print the initial list
+	TablePrinter *printers.HumanReadablePrinter
+	ItemKind     schema.GroupVersionKind
+	// Redact, when set, removes sensitive fields from a copy of each resource
+	// before it is printed
+	Redact func(obj runtime.Object)
+}
+
+// WatchList prints resources as they are added or modified after the initial
+// list was printed, until the context is done. Human readable formats print a
+// table row for each change, structured formats print the resource.
+func WatchList(ctx context.Context, c *Config, list runtime.Object, opts WatchListOptions) error {
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	// the watch starts by syncing resources that were already printed
+	printed := map[string]string{}
+	for _, item := range items {
+		resource := item.(metav1.Object)
+		printed[resource.GetNamespace()+"/"+resource.GetName()] = resource.GetResourceVersion()
+	}
+
+	itemType := reflect.ValueOf(list).Elem().FieldByName("Items").Type().Elem()
+	objType := reflect.New(itemType).Interface().(runtime.Object)
+	rowPrinter := opts.TablePrinter.WithoutHeaders()
+
+	return k8s.WatchList(ctx, opts.Client, opts.Resource, opts.Namespace, opts.ListOptions, objType, func(event watch.Event) error {
+		if event.Type != watch.Added && event.Type != watch.Modified {
+			return nil
+		}
+		resource, ok := event.Object.(metav1.Object)
+		if !ok {
+			return nil
+		}
+		key := resource.GetNamespace() + "/" + resource.GetName()
+		if resourceVersion, ok := printed[key]; ok && resourceVersion == resource.GetResourceVersion() {
+			return nil
+		}
+		printed[key] = resource.GetResourceVersion()
+
+		obj := event.Object.DeepCopyObject()
+		if opts.Redact != nil {
+			opts.Redact(obj)
+		}
+		if printers.IsHumanReadable(opts.Output) {
+			return rowPrinter.PrintObj(obj, c.Stdout)
+		}
+		if opts.Output == printers.OutputFormatYAML {
+			// separate each resource so the output is a stream of documents
+			c.Printf("---\n")
+		}
+		return printers.PrintObject(opts.Output, obj, opts.ItemKind, c.Stdout)
+	})
+}
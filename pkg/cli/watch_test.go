@@ -0,0 +1,212 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/projectriff/cli/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestWatchList(t *testing.T) {
+	secret := func(name, resourceVersion string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "default",
+				Name:            name,
+				ResourceVersion: resourceVersion,
+			},
+			Data: map[string][]byte{
+				"password": []byte("hunter2"),
+			},
+		}
+	}
+	// secret-a was printed by the initial list
+	list := &corev1.SecretList{
+		Items: []corev1.Secret{*secret("secret-a", "1")},
+	}
+	events := []watch.Event{
+		{Type: watch.Added, Object: secret("secret-a", "1")},
+		{Type: watch.Modified, Object: secret("secret-a", "2")},
+		{Type: watch.Added, Object: secret("secret-b", "3")},
+		{Type: watch.Modified, Object: secret("secret-b", "3")},
+		{Type: watch.Deleted, Object: secret("secret-b", "4")},
+	}
+	redact := func(obj runtime.Object) {
+		obj.(*corev1.Secret).Data = nil
+	}
+
+	tests := []struct {
+		name     string
+		output   string
+		redact   func(obj runtime.Object)
+		expected string
+	}{{
+		name: "table",
+		expected: `
+secret-a
+secret-b
+`,
+	}, {
+		name:   "name",
+		output: printers.OutputFormatName,
+		expected: `
+secret/secret-a
+secret/secret-b
+`,
+	}, {
+		name:   "json",
+		output: printers.OutputFormatJSON,
+		redact: redact,
+		expected: `
+{
+    "kind": "Secret",
+    "apiVersion": "v1",
+    "metadata": {
+        "name": "secret-a",
+        "namespace": "default",
+        "resourceVersion": "2",
+        "creationTimestamp": null
+    }
+}
+{
+    "kind": "Secret",
+    "apiVersion": "v1",
+    "metadata": {
+        "name": "secret-b",
+        "namespace": "default",
+        "resourceVersion": "3",
+        "creationTimestamp": null
+    }
+}
+`,
+	}, {
+		name:   "yaml",
+		output: printers.OutputFormatYAML,
+		expected: `
+---
+apiVersion: v1
+data:
+  password: aHVudGVyMg==
+kind: Secret
+metadata:
+  creationTimestamp: null
+  name: secret-a
+  namespace: default
+  resourceVersion: "2"
+---
+apiVersion: v1
+data:
+  password: aHVudGVyMg==
+kind: Secret
+metadata:
+  creationTimestamp: null
+  name: secret-b
+  namespace: default
+  resourceVersion: "3"
+`,
+	}, {
+		name:   "yaml redacted",
+		output: printers.OutputFormatYAML,
+		redact: redact,
+		expected: `
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  creationTimestamp: null
+  name: secret-a
+  namespace: default
+  resourceVersion: "2"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  creationTimestamp: null
+  name: secret-b
+  namespace: default
+  resourceVersion: "3"
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// events are sent in order from the watch rather than synced from
+			// the initial list
+			watcher := watch.NewFakeWithChanSize(len(events), false)
+			for _, event := range events {
+				watcher.Action(event.Type, event.Object.DeepCopyObject())
+			}
+			lw := &cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return &corev1.SecretList{}, nil
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return watcher, nil
+				},
+			}
+			ctx := k8s.WithListerWatcher(context.Background(), lw)
+			ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+
+			output := &bytes.Buffer{}
+			c := &cli.Config{Stdout: output}
+			tablePrinter := printers.NewTablePrinter(printers.PrintOptions{}).With(func(h printers.PrintHandler) {
+				columns := []metav1beta1.TableColumnDefinition{
+					{Name: "Name", Type: "string"},
+				}
+				h.TableHandler(columns, func(secret *corev1.Secret, printOpts printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+					return []metav1beta1.TableRow{{
+						Object: runtime.RawExtension{Object: secret},
+						Cells:  []interface{}{secret.Name},
+					}}, nil
+				})
+			})
+
+			err := cli.WatchList(ctx, c, list, cli.WatchListOptions{
+				Resource:     "secrets",
+				Namespace:    "default",
+				Output:       test.output,
+				TablePrinter: tablePrinter,
+				ItemKind:     corev1.SchemeGroupVersion.WithKind("Secret"),
+				Redact:       test.redact,
+			})
+			if err != nil {
+				t.Fatalf("WatchList() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.expected, "\n"), output.String()); diff != "" {
+				t.Errorf("WatchList() (-expected, +actual): %s", diff)
+			}
+			if data := events[2].Object.(*corev1.Secret).Data; data == nil {
+				t.Errorf("WatchList() redacted the watched resource rather than a copy")
+			}
+		})
+	}
+}
@@ -37,6 +37,10 @@ import (
 
 type DeployerCreateOptions struct {
 	options.ResourceOptions
+	options.TailOptions
+
+	Labels      []string
+	Annotations []string
 
 	Image          string
 	ApplicationRef string
@@ -68,6 +72,8 @@ func (opts *DeployerCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 	errs := cli.FieldErrors{}
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+	errs = errs.Also(validation.Labels(opts.Labels, cli.LabelFlagName))
+	errs = errs.Also(validation.Annotations(opts.Annotations, cli.AnnotationFlagName))
 
 	// application-ref, build-ref and image are mutually exclusive
 	used := []string{}
@@ -132,6 +138,7 @@ func (opts *DeployerCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 	if opts.DryRun && opts.Tail {
 		errs = errs.Also(cli.ErrMultipleOneOf(cli.DryRunFlagName, cli.TailFlagName))
 	}
+	errs = errs.Also(opts.TailOptions.Validate(ctx, cli.TailOutputFlagName))
 
 	return errs
 }
@@ -139,11 +146,17 @@ func (opts *DeployerCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 func (opts *DeployerCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
 	deployer := &corev1alpha1.Deployer{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: opts.Namespace,
-			Name:      opts.Name,
+			Namespace:   opts.Namespace,
+			Name:        opts.Name,
+			Labels:      parsers.KeyValues(opts.Labels),
+			Annotations: parsers.KeyValues(opts.Annotations),
 		},
 		Spec: corev1alpha1.DeployerSpec{
 			Template: &corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      parsers.KeyValues(opts.Labels),
+					Annotations: parsers.KeyValues(opts.Annotations),
+				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{{}},
 				},
@@ -219,7 +232,7 @@ func (opts *DeployerCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 				return k8s.WaitUntilReady(ctx, c.CoreRuntime().RESTClient(), "deployers", deployer)
 			},
 			func(ctx context.Context) error {
-				return c.Kail.CoreDeployerLogs(ctx, deployer, cli.TailSinceCreateDefault, c.Stdout)
+				return c.Kail.CoreDeployerLogs(ctx, deployer, opts.LogOptions(cli.TailSinceCreateDefault), c.Stdout)
 			},
 		)
 		if err == context.DeadlineExceeded {
@@ -287,8 +300,11 @@ and ` + cli.EnvFromFlagName + ` to map values from a ConfigMap or Secret.
 	cmd.Flags().StringVar(&opts.LimitCPU, cli.StripDash(cli.LimitCPUFlagName), "", "the maximum amount of cpu allowed, in CPU `cores` (500m = .5 cores)")
 	cmd.Flags().StringVar(&opts.LimitMemory, cli.StripDash(cli.LimitMemoryFlagName), "", "the maximum amount of memory allowed, in `bytes` (500Mi = 500MiB = 500 * 1024 * 1024)")
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch deployer logs")
+	cli.TailFlags(cmd, cli.TailOutputFlagName, &opts.Output, &opts.Grep, &opts.Timestamps)
 	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the deployer to become ready when watching logs")
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().StringArrayVar(&opts.Labels, cli.StripDash(cli.LabelFlagName), []string{}, fmt.Sprintf("`label` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s team=payments", cli.LabelFlagName)))
+	cmd.Flags().StringArrayVar(&opts.Annotations, cli.StripDash(cli.AnnotationFlagName), []string{}, fmt.Sprintf("`annotation` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s owner=jane@example.com", cli.AnnotationFlagName)))
 	cmd.Flags().Int32Var(&opts.TargetPort, cli.StripDash(cli.TargetPortFlagName), 0, "`port` that the workload listens on for traffic. The value is exposed to the workload as the PORT environment variable")
 
 	return cmd
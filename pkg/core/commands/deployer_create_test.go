@@ -25,6 +25,7 @@ import (
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/core/commands"
 	"github.com/projectriff/cli/pkg/k8s"
+	riffkail "github.com/projectriff/cli/pkg/kail"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	kailtesting "github.com/projectriff/cli/pkg/testing/kail"
 	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
@@ -134,6 +135,31 @@ func TestDeployerCreateOptions(t *testing.T) {
 			},
 			ExpectFieldErrors: cli.ErrInvalidArrayValue("=foo", cli.EnvFlagName, 0),
 		},
+		{
+			Name: "with labels and annotations",
+			Options: &commands.DeployerCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				IngressPolicy:   string(corev1alpha1.IngressPolicyClusterLocal),
+				Labels:          []string{"team=payments"},
+				Annotations:     []string{"owner=jane@example.com"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with invalid labels and annotations",
+			Options: &commands.DeployerCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				IngressPolicy:   string(corev1alpha1.IngressPolicyClusterLocal),
+				Labels:          []string{"team"},
+				Annotations:     []string{"=jane"},
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidArrayValue("team", cli.LabelFlagName, 0),
+				cli.ErrInvalidArrayValue("=jane", cli.AnnotationFlagName, 0),
+			),
+		},
 		{
 			Name: "with envfrom secret",
 			Options: &commands.DeployerCreateOptions{
@@ -316,6 +342,37 @@ func TestDeployerCreateCommand(t *testing.T) {
 			},
 			ExpectOutput: `
 Created deployer "my-deployer"
+`,
+		},
+		{
+			Name: "create with labels and annotations",
+			Args: []string{deployerName, cli.ImageFlagName, image, cli.LabelFlagName, "team=payments", cli.AnnotationFlagName, "owner=jane@example.com"},
+			ExpectCreates: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   defaultNamespace,
+						Name:        deployerName,
+						Labels:      map[string]string{"team": "payments"},
+						Annotations: map[string]string{"owner": "jane@example.com"},
+					},
+					Spec: corev1alpha1.DeployerSpec{
+						Template: &corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Labels:      map[string]string{"team": "payments"},
+								Annotations: map[string]string{"owner": "jane@example.com"},
+							},
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{Image: image},
+								},
+							},
+						},
+						IngressPolicy: corev1alpha1.IngressPolicyClusterLocal,
+					},
+				},
+			},
+			ExpectOutput: `
+Created deployer "my-deployer"
 `,
 		},
 		{
@@ -627,7 +684,7 @@ Created deployer "my-deployer"
 						},
 						IngressPolicy: corev1alpha1.IngressPolicyClusterLocal,
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -685,7 +742,7 @@ Deployer "my-deployer" is ready
 						},
 						IngressPolicy: corev1alpha1.IngressPolicyClusterLocal,
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(k8s.ErrWaitTimeout).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(k8s.ErrWaitTimeout).Run(func(args mock.Arguments) {
 					ctx := args[0].(context.Context)
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 					// wait for context to be cancelled
@@ -754,7 +811,7 @@ To continue watching logs run: riff core deployer tail my-deployer --namespace d
 						},
 						IngressPolicy: corev1alpha1.IngressPolicyClusterLocal,
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(fmt.Errorf("kail error"))
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
 				return ctx, nil
 			},
 			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
@@ -20,11 +20,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type DeployerDeleteOptions struct {
@@ -46,13 +47,23 @@ func (opts *DeployerDeleteOptions) Validate(ctx context.Context) cli.FieldErrors
 
 func (opts *DeployerDeleteOptions) Exec(ctx context.Context, c *cli.Config) error {
 	client := c.CoreRuntime().Deployers(opts.Namespace)
+	deleted := options.DeletedResource{
+		Client:      c.CoreRuntime().RESTClient(),
+		Resource:    "deployers",
+		ObjType:     &corev1alpha1.Deployer{},
+		PodLabelKey: corev1alpha1.DeployerLabelKey,
+	}
 
 	if opts.All {
-		if err := client.DeleteCollection(nil, metav1.ListOptions{}); err != nil {
+		names, err := opts.DeletedNames(ctx, deleted, opts.K8sListOptions())
+		if err != nil {
+			return err
+		}
+		if err := client.DeleteCollection(nil, opts.K8sListOptions()); err != nil {
 			return err
 		}
 		c.Successf("Deleted deployers in namespace %q\n", opts.Namespace)
-		return nil
+		return opts.WaitForDeletion(ctx, c, deleted, names)
 	}
 
 	for _, name := range opts.Names {
@@ -62,7 +73,7 @@ func (opts *DeployerDeleteOptions) Exec(ctx context.Context, c *cli.Config) erro
 		c.Successf("Deleted deployer %q\n", name)
 	}
 
-	return nil
+	return opts.WaitForDeletion(ctx, c, deleted, opts.Names)
 }
 
 func NewDeployerDeleteCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -76,7 +87,9 @@ Delete one or more deployers by name or all deployers within a namespace.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s core deployer delete my-deployer", c.Name),
+			fmt.Sprintf("%s core deployer delete my-deployer %s", c.Name, cli.WaitFlagName),
 			fmt.Sprintf("%s core deployer delete %s", c.Name, cli.AllFlagName),
+			fmt.Sprintf("%s core deployer delete %s %s team=payments", c.Name, cli.AllFlagName, cli.SelectorFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -88,6 +101,9 @@ Delete one or more deployers by name or all deployers within a namespace.
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all deployers within the namespace")
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
+	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(cli.WaitFlagName), false, "wait for the deployers and their pods to be removed")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), time.Minute, "`duration` to wait for the deployers to be removed")
 
 	return cmd
 }
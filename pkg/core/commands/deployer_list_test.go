@@ -229,6 +229,37 @@ app         application   petclinic           app.default.example.com
 container   container     busybox             container.default.svc.cluster.local   Ready    <unknown>
 func        function      square              func.default.example.com              Ready    <unknown>
 img         image         projectriff/upper   img.default.example.com               Ready    <unknown>
+`,
+		},
+		{
+			Name: "wide output",
+			Args: []string{cli.OutputFlagName, "wide"},
+			GivenObjects: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      deployerName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+NAME            TYPE        REF         URL       STATUS      AGE         INGRESS POLICY   LATEST IMAGE
+test-deployer   <unknown>   <unknown>   <empty>   <unknown>   <unknown>   <empty>          <empty>
+`,
+		},
+		{
+			Name: "name output",
+			Args: []string{cli.OutputFlagName, "name"},
+			GivenObjects: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      deployerName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+deployer/test-deployer
 `,
 		},
 		{
@@ -23,6 +23,8 @@ import (
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/projectriff/cli/pkg/validation"
 	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
 	"github.com/spf13/cobra"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -31,6 +33,8 @@ import (
 
 type DeployerStatusOptions struct {
 	options.ResourceOptions
+
+	Output string
 }
 
 var (
@@ -43,6 +47,8 @@ func (opts *DeployerStatusOptions) Validate(ctx context.Context) cli.FieldErrors
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
 
+	errs = errs.Also(validation.StructuredOutputFormat(opts.Output, cli.OutputFlagName))
+
 	return errs
 }
 
@@ -56,10 +62,28 @@ func (opts *DeployerStatusOptions) Exec(ctx context.Context, c *cli.Config) erro
 		return cli.SilenceError(err)
 	}
 
+	if opts.Output != "" {
+		return printers.PrintObject(opts.Output, deployer, corev1alpha1.SchemeGroupVersion.WithKind("Deployer"), c.Stdout)
+	}
+
 	ready := deployer.Status.GetCondition(corev1alpha1.DeployerConditionReady)
 	cli.PrintResourceStatus(c, deployer.Name, ready)
 
-	return nil
+	return cli.PrintResourceStatusDetails(c, cli.ResourceStatusDetails{
+		GroupVersionKind: deployer.GetGroupVersionKind(),
+		Namespace:        deployer.Namespace,
+		Name:             deployer.Name,
+		Conditions:       deployer.Status.Conditions,
+		Details: []cli.StatusDetail{
+			{Name: "Latest Image", Value: deployer.Status.LatestImage},
+			{Name: "URL", Value: deployer.Status.URL},
+			{Name: "Address", Value: cli.StatusAddress(deployer.Status.Address)},
+			{Name: "Service", Value: cli.StatusRefName(deployer.Status.ServiceRef)},
+			{Name: "Ingress", Value: cli.StatusRefName(deployer.Status.IngressRef)},
+		},
+		PodSelector: fmt.Sprintf("%s=%s", corev1alpha1.DeployerLabelKey, deployer.Name),
+		Deployment:  cli.StatusRefName(deployer.Status.DeploymentRef),
+	})
 }
 
 func NewDeployerStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -75,9 +99,13 @@ The Ready condition is shown which should include a reason code and a
 descriptive message when the status is not "True". The status for the condition
 may be: "True", "False" or "Unknown". An "Unknown" status is common while the
 deployer roll out is processed.
+
+Key fields from the status, every condition and the most recent events for
+the deployer are shown after the Ready condition.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s core deployer status my-deployer", c.Name),
+			fmt.Sprintf("%s core deployer status my-deployer %s jsonpath={.status.url}", c.Name, cli.OutputFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -88,6 +116,7 @@ deployer roll out is processed.
 	)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cli.StructuredOutputFlag(cmd, &opts.Output)
 
 	return cmd
 }
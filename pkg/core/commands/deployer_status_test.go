@@ -17,16 +17,21 @@
 package commands_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/core/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	"github.com/projectriff/system/pkg/apis"
 	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
+	"github.com/projectriff/system/pkg/refs"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
 )
 
 func TestDeployerStatusOptions(t *testing.T) {
@@ -45,6 +50,14 @@ func TestDeployerStatusOptions(t *testing.T) {
 			},
 			ShouldValidate: true,
 		},
+		{
+			Name: "invalid output",
+			Options: &commands.DeployerStatusOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Output:          "xml",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("xml", cli.OutputFlagName),
+		},
 	}
 
 	table.Run(t)
@@ -96,7 +109,374 @@ message: a hopefully informative message about what went wrong
 reason: OopsieDoodle
 status: "False"
 type: Ready
+
+Conditions:
+  TYPE    STATUS   REASON         LAST TRANSITION        MESSAGE
+  Ready   False    OopsieDoodle   2019-06-29T01:44:05Z   a hopefully informative message about what went wrong
+
+Events:
+  <none>
+`,
+		},
+		{
+			Name: "show status with details and events",
+			Args: []string{deployerName},
+			GivenObjects: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      deployerName,
+						Namespace: defaultNamespace,
+					},
+					Status: corev1alpha1.DeployerStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   corev1alpha1.DeployerConditionDeploymentReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+						LatestImage: "registry.example.com/repo@sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+						DeploymentRef: &refs.TypedLocalObjectReference{
+							Kind: "Deployment",
+							Name: "my-deployer-deployer",
+						},
+						ServiceRef: &refs.TypedLocalObjectReference{
+							Kind: "Service",
+							Name: "my-deployer-deployer",
+						},
+					},
+				},
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-deployer-deployer",
+						Namespace: defaultNamespace,
+					},
+					Status: appsv1.DeploymentStatus{
+						UpdatedReplicas: 1,
+					},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-deployer-deployer-abc123",
+						Namespace: defaultNamespace,
+						Labels: map[string]string{
+							corev1alpha1.DeployerLabelKey: deployerName,
+						},
+					},
+				},
+				&corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-deployer.1",
+						Namespace: defaultNamespace,
+					},
+					InvolvedObject: corev1.ObjectReference{
+						APIVersion: "core.projectriff.io/v1alpha1",
+						Kind:       "Deployer",
+						Name:       deployerName,
+					},
+					Type:          corev1.EventTypeNormal,
+					Reason:        "Created",
+					Message:       "Created Deployment \"my-deployer-deployer\"",
+					LastTimestamp: metav1.Time{Time: time.Date(2019, 6, 29, 01, 44, 01, 0, time.UTC)},
+				},
+				&corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-deployer-deployer-abc123.1",
+						Namespace: defaultNamespace,
+					},
+					InvolvedObject: corev1.ObjectReference{
+						Kind: "Pod",
+						Name: "my-deployer-deployer-abc123",
+					},
+					Type:          corev1.EventTypeWarning,
+					Reason:        "BackOff",
+					Message:       "Back-off restarting failed container",
+					LastTimestamp: metav1.Time{Time: time.Date(2019, 6, 29, 01, 44, 03, 0, time.UTC)},
+				},
+				&corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-deployer.2",
+						Namespace: defaultNamespace,
+					},
+					InvolvedObject: corev1.ObjectReference{
+						APIVersion: "knative.projectriff.io/v1alpha1",
+						Kind:       "Deployer",
+						Name:       deployerName,
+					},
+					Type:          corev1.EventTypeNormal,
+					Reason:        "Created",
+					Message:       "Created Configuration \"my-deployer-deployer\"",
+					LastTimestamp: metav1.Time{Time: time.Date(2019, 6, 29, 01, 44, 02, 0, time.UTC)},
+				},
+				&corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "other-pod.1",
+						Namespace: defaultNamespace,
+					},
+					InvolvedObject: corev1.ObjectReference{
+						Kind: "Pod",
+						Name: "other-pod",
+					},
+					Type:          corev1.EventTypeNormal,
+					Reason:        "Pulled",
+					Message:       "Container image pulled",
+					LastTimestamp: metav1.Time{Time: time.Date(2019, 6, 29, 01, 44, 04, 0, time.UTC)},
+				},
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				requireInvolvedObjectSelector,
+			},
+			ExpectOutput: `
+# my-deployer: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+
+Details:
+  Latest Image:   registry.example.com/repo@sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef
+  Service:        my-deployer-deployer
+  Replicas:       1 desired, 1 updated, 0 ready, 0 available
+
+Conditions:
+  TYPE              STATUS   REASON   LAST TRANSITION        MESSAGE
+  DeploymentReady   True     <none>   2019-06-29T01:44:05Z   <none>
+  Ready             True     <none>   2019-06-29T01:44:05Z   <none>
+
+Events:
+  LAST SEEN              TYPE      REASON    OBJECT                            MESSAGE
+  2019-06-29T01:44:01Z   Normal    Created   deployer/my-deployer              Created Deployment "my-deployer-deployer"
+  2019-06-29T01:44:03Z   Warning   BackOff   pod/my-deployer-deployer-abc123   Back-off restarting failed container
+`,
+		},
+		{
+			Name: "show status without access to deployments and events",
+			Args: []string{deployerName},
+			GivenObjects: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      deployerName,
+						Namespace: defaultNamespace,
+					},
+					Status: corev1alpha1.DeployerStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+						DeploymentRef: &refs.TypedLocalObjectReference{
+							Kind: "Deployment",
+							Name: "my-deployer-deployer",
+						},
+					},
+				},
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceForbidden("get", "deployments"),
+				rifftesting.InduceForbidden("list", "pods"),
+				rifftesting.InduceForbidden("list", "events"),
+			},
+			ExpectOutput: `
+# my-deployer: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+
+Details:
+  Replicas:   <forbidden>
+
+Conditions:
+  TYPE    STATUS   REASON   LAST TRANSITION        MESSAGE
+  Ready   True     <none>   2019-06-29T01:44:05Z   <none>
+
+Events:
+  <forbidden>
+`,
+		},
+		{
+			Name: "show status without access to pods",
+			Args: []string{deployerName},
+			GivenObjects: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      deployerName,
+						Namespace: defaultNamespace,
+					},
+					Status: corev1alpha1.DeployerStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+						DeploymentRef: &refs.TypedLocalObjectReference{
+							Kind: "Deployment",
+							Name: "my-deployer-deployer",
+						},
+					},
+				},
+				&corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-deployer.1",
+						Namespace: defaultNamespace,
+					},
+					InvolvedObject: corev1.ObjectReference{
+						APIVersion: "core.projectriff.io/v1alpha1",
+						Kind:       "Deployer",
+						Name:       deployerName,
+					},
+					Type:          corev1.EventTypeNormal,
+					Reason:        "Created",
+					Message:       "Created Deployment \"my-deployer-deployer\"",
+					LastTimestamp: metav1.Time{Time: time.Date(2019, 6, 29, 01, 44, 01, 0, time.UTC)},
+				},
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceForbidden("list", "pods"),
+			},
+			ExpectOutput: `
+# my-deployer: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+
+Conditions:
+  TYPE    STATUS   REASON   LAST TRANSITION        MESSAGE
+  Ready   True     <none>   2019-06-29T01:44:05Z   <none>
+
+Events:
+  LAST SEEN              TYPE     REASON    OBJECT                 MESSAGE
+  2019-06-29T01:44:01Z   Normal   Created   deployer/my-deployer   Created Deployment "my-deployer-deployer"
 `,
+		},
+		{
+			Name: "events error",
+			Args: []string{deployerName},
+			GivenObjects: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      deployerName,
+						Namespace: defaultNamespace,
+					},
+					Status: corev1alpha1.DeployerStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+						DeploymentRef: &refs.TypedLocalObjectReference{
+							Kind: "Deployment",
+							Name: "my-deployer-deployer",
+						},
+					},
+				},
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("list", "events"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "jsonpath output",
+			Args: []string{deployerName, cli.OutputFlagName, "jsonpath={.status.conditions[0].reason}"},
+			GivenObjects: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      deployerName,
+						Namespace: defaultNamespace,
+					},
+					Status: corev1alpha1.DeployerStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:    apis.ConditionReady,
+									Status:  corev1.ConditionFalse,
+									Reason:  "OopsieDoodle",
+									Message: "a hopefully informative message about what went wrong",
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+OopsieDoodle`,
+		},
+		{
+			Name: "go-template output",
+			Args: []string{deployerName, cli.OutputFlagName, `go-template={{.metadata.name}}: {{range .status.conditions}}{{.type}}={{.status}}{{end}}`},
+			GivenObjects: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      deployerName,
+						Namespace: defaultNamespace,
+					},
+					Status: corev1alpha1.DeployerStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:    apis.ConditionReady,
+									Status:  corev1.ConditionFalse,
+									Reason:  "OopsieDoodle",
+									Message: "a hopefully informative message about what went wrong",
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+my-deployer: Ready=False`,
 		},
 		{
 			Name: "not found",
@@ -143,3 +523,16 @@ Deployer "default/my-deployer" not found
 
 	table.Run(t, commands.NewDeployerStatusCommand)
 }
+
+// requireInvolvedObjectSelector fails lists of events that are not selected by
+// the kind of the involved object
+func requireInvolvedObjectSelector(action clientgotesting.Action) (bool, runtime.Object, error) {
+	if !action.Matches("list", "events") {
+		return false, nil, nil
+	}
+	selector := action.(clientgotesting.ListAction).GetListRestrictions().Fields
+	if _, ok := selector.RequiresExactMatch("involvedObject.kind"); !ok {
+		return true, nil, fmt.Errorf("expected events to be selected by involved object, found %q", selector)
+	}
+	return false, nil, nil
+}
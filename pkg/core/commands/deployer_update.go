@@ -0,0 +1,300 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/parsers"
+	"github.com/projectriff/cli/pkg/race"
+	"github.com/projectriff/cli/pkg/validation"
+	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type DeployerUpdateOptions struct {
+	options.ResourceOptions
+	options.TailOptions
+
+	Image          string
+	ApplicationRef string
+	ContainerRef   string
+	FunctionRef    string
+
+	IngressPolicy string
+	TargetPort    int32
+
+	Env     []string
+	EnvFrom []string
+
+	LimitCPU    string
+	LimitMemory string
+
+	Tail        bool
+	WaitTimeout string
+
+	DryRun bool
+}
+
+var (
+	_ cli.Validatable = (*DeployerUpdateOptions)(nil)
+	_ cli.Executable  = (*DeployerUpdateOptions)(nil)
+	_ cli.DryRunable  = (*DeployerUpdateOptions)(nil)
+)
+
+func (opts *DeployerUpdateOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+
+	if opts.Image == "" && opts.ApplicationRef == "" && opts.ContainerRef == "" && opts.FunctionRef == "" &&
+		opts.IngressPolicy == "" && opts.TargetPort == 0 &&
+		len(opts.Env) == 0 && len(opts.EnvFrom) == 0 && opts.LimitCPU == "" && opts.LimitMemory == "" {
+		errs = errs.Also(cli.ErrNothingToUpdate(
+			cli.ImageFlagName, cli.ApplicationRefFlagName, cli.ContainerRefFlagName, cli.FunctionRefFlagName,
+			cli.IngressPolicyFlagName, cli.TargetPortFlagName,
+			cli.EnvFlagName, cli.EnvFromFlagName, cli.LimitCPUFlagName, cli.LimitMemoryFlagName,
+		))
+	}
+
+	// application-ref, build-ref and image are mutually exclusive, all are optional
+	used := []string{}
+
+	if opts.ApplicationRef != "" {
+		used = append(used, cli.ApplicationRefFlagName)
+	}
+	if opts.ContainerRef != "" {
+		used = append(used, cli.ContainerRefFlagName)
+	}
+	if opts.FunctionRef != "" {
+		used = append(used, cli.FunctionRefFlagName)
+	}
+	if opts.Image != "" {
+		used = append(used, cli.ImageFlagName)
+	}
+
+	if len(used) > 1 {
+		errs = errs.Also(cli.ErrMultipleOneOf(used...))
+	}
+
+	if opts.IngressPolicy != "" && opts.IngressPolicy != string(corev1alpha1.IngressPolicyClusterLocal) && opts.IngressPolicy != string(corev1alpha1.IngressPolicyExternal) {
+		errs = errs.Also(cli.ErrInvalidValue(opts.IngressPolicy, cli.IngressPolicyFlagName))
+	}
+
+	errs = errs.Also(validation.EnvVars(opts.Env, cli.EnvFlagName))
+	errs = errs.Also(validation.EnvVarFroms(opts.EnvFrom, cli.EnvFromFlagName))
+
+	if opts.LimitCPU != "" {
+		errs = errs.Also(validation.Quantity(opts.LimitCPU, cli.LimitCPUFlagName))
+	}
+	if opts.LimitMemory != "" {
+		errs = errs.Also(validation.Quantity(opts.LimitMemory, cli.LimitMemoryFlagName))
+	}
+
+	if opts.TargetPort != 0 {
+		errs = errs.Also(validation.PortNumber(opts.TargetPort, cli.TargetPortFlagName))
+	}
+
+	if opts.Tail {
+		if opts.WaitTimeout == "" {
+			errs = errs.Also(cli.ErrMissingField(cli.WaitTimeoutFlagName))
+		} else if _, err := time.ParseDuration(opts.WaitTimeout); err != nil {
+			errs = errs.Also(cli.ErrInvalidValue(opts.WaitTimeout, cli.WaitTimeoutFlagName))
+		}
+	}
+
+	if opts.DryRun && opts.Tail {
+		errs = errs.Also(cli.ErrMultipleOneOf(cli.DryRunFlagName, cli.TailFlagName))
+	}
+	errs = errs.Also(opts.TailOptions.Validate(ctx, cli.TailOutputFlagName))
+
+	return errs
+}
+
+func (opts *DeployerUpdateOptions) Exec(ctx context.Context, c *cli.Config) error {
+	existing, err := c.CoreRuntime().Deployers(opts.Namespace).Get(opts.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		c.Errorf("Deployer %q not found\n", fmt.Sprintf("%s/%s", opts.Namespace, opts.Name))
+		return cli.SilenceError(err)
+	}
+
+	deployer := existing.DeepCopy()
+	if deployer.Spec.Template == nil {
+		deployer.Spec.Template = &corev1.PodTemplateSpec{}
+	}
+	if len(deployer.Spec.Template.Spec.Containers) == 0 {
+		deployer.Spec.Template.Spec.Containers = []corev1.Container{{}}
+	}
+	container := &deployer.Spec.Template.Spec.Containers[0]
+
+	if opts.ApplicationRef != "" {
+		deployer.Spec.Build = &corev1alpha1.Build{
+			ApplicationRef: opts.ApplicationRef,
+		}
+		container.Image = ""
+	}
+	if opts.ContainerRef != "" {
+		deployer.Spec.Build = &corev1alpha1.Build{
+			ContainerRef: opts.ContainerRef,
+		}
+		container.Image = ""
+	}
+	if opts.FunctionRef != "" {
+		deployer.Spec.Build = &corev1alpha1.Build{
+			FunctionRef: opts.FunctionRef,
+		}
+		container.Image = ""
+	}
+	if opts.Image != "" {
+		deployer.Spec.Build = nil
+		container.Image = opts.Image
+	}
+
+	if opts.IngressPolicy != "" {
+		deployer.Spec.IngressPolicy = corev1alpha1.IngressPolicy(opts.IngressPolicy)
+	}
+
+	if len(opts.Env) > 0 || len(opts.EnvFrom) > 0 {
+		env := []corev1.EnvVar{}
+		for _, envvar := range opts.Env {
+			env = append(env, parsers.EnvVar(envvar))
+		}
+		for _, envvar := range opts.EnvFrom {
+			env = append(env, parsers.EnvVarFrom(envvar))
+		}
+		container.Env = k8s.MergeEnvVars(container.Env, env...)
+	}
+
+	if (opts.LimitCPU != "" || opts.LimitMemory != "") && container.Resources.Limits == nil {
+		container.Resources.Limits = corev1.ResourceList{}
+	}
+	if opts.LimitCPU != "" {
+		// parse errors are handled by the opt validation
+		container.Resources.Limits[corev1.ResourceCPU] = resource.MustParse(opts.LimitCPU)
+	}
+	if opts.LimitMemory != "" {
+		// parse errors are handled by the opt validation
+		container.Resources.Limits[corev1.ResourceMemory] = resource.MustParse(opts.LimitMemory)
+	}
+	if opts.TargetPort > 0 {
+		container.Ports = []corev1.ContainerPort{
+			{Protocol: corev1.ProtocolTCP, ContainerPort: opts.TargetPort},
+		}
+	}
+
+	if opts.DryRun {
+		cli.DryRunResource(ctx, deployer, deployer.GetGroupVersionKind())
+	} else {
+		deployer, err = c.CoreRuntime().Deployers(opts.Namespace).Update(deployer)
+		if err != nil {
+			return err
+		}
+	}
+	c.Successf("Updated deployer %q\n", deployer.Name)
+	if opts.Tail {
+		// err guarded by Validate()
+		timeout, _ := time.ParseDuration(opts.WaitTimeout)
+		err := race.Run(ctx, timeout,
+			func(ctx context.Context) error {
+				return k8s.WaitUntilReady(ctx, c.CoreRuntime().RESTClient(), "deployers", deployer)
+			},
+			func(ctx context.Context) error {
+				return c.Kail.CoreDeployerLogs(ctx, deployer, opts.LogOptions(cli.TailSinceCreateDefault), c.Stdout)
+			},
+		)
+		if err == context.DeadlineExceeded {
+			c.Errorf("Timeout after %q waiting for %q to become ready\n", opts.WaitTimeout, opts.Name)
+			c.Infof("To view status run: %s core deployer list %s %s\n", c.Name, cli.NamespaceFlagName, opts.Namespace)
+			c.Infof("To continue watching logs run: %s core deployer tail %s %s %s\n", c.Name, opts.Name, cli.NamespaceFlagName, opts.Namespace)
+			err = cli.SilenceError(err)
+		}
+		if err != nil {
+			return err
+		}
+		c.Successf("Deployer %q is ready\n", deployer.Name)
+	}
+	return nil
+}
+
+func (opts *DeployerUpdateOptions) IsDryRun() bool {
+	return opts.DryRun
+}
+
+func NewDeployerUpdateCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &DeployerUpdateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "update a deployer in place",
+		Long: strings.TrimSpace(`
+Update a core deployer in place.
+
+Only the properties set by flags are changed, all other properties of the
+deployer are left as is. The deployment backing the deployer is rolled forward
+so the workload remains available while the new pods become ready.
+
+Setting a build reference replaces the existing build reference or image, and
+setting an image removes the existing build reference. Environment variables set
+by ` + cli.EnvFlagName + ` or ` + cli.EnvFromFlagName + ` replace existing variables of the same name.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s core deployer update my-image-deployer %s registry.example.com/my-image:v1.0.1", c.Name, cli.ImageFlagName),
+			fmt.Sprintf("%s core deployer update my-app-deployer %s MY_VAR=my-value %s", c.Name, cli.EnvFlagName, cli.TailFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+	)
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().StringVar(&opts.Image, cli.StripDash(cli.ImageFlagName), "", "container `image` to deploy")
+	cmd.Flags().StringVar(&opts.ApplicationRef, cli.StripDash(cli.ApplicationRefFlagName), "", "`name` of application to deploy")
+	_ = cmd.MarkFlagCustom(cli.StripDash(cli.ApplicationRefFlagName), "__"+c.Name+"_list_applications")
+	cmd.Flags().StringVar(&opts.ContainerRef, cli.StripDash(cli.ContainerRefFlagName), "", "`name` of container to deploy")
+	_ = cmd.MarkFlagCustom(cli.StripDash(cli.ContainerRefFlagName), "__"+c.Name+"_list_containers")
+	cmd.Flags().StringVar(&opts.FunctionRef, cli.StripDash(cli.FunctionRefFlagName), "", "`name` of function to deploy")
+	_ = cmd.MarkFlagCustom(cli.StripDash(cli.FunctionRefFlagName), "__"+c.Name+"_list_functions")
+	cmd.Flags().StringVar(&opts.IngressPolicy, cli.StripDash(cli.IngressPolicyFlagName), "", fmt.Sprintf("ingress `policy` for network access to the workload, one of %q or %q", corev1alpha1.IngressPolicyClusterLocal, corev1alpha1.IngressPolicyExternal))
+	_ = cmd.MarkFlagCustom(cli.StripDash(cli.IngressPolicyFlagName), "__"+c.Name+"_ingress_policy")
+	cmd.Flags().StringArrayVar(&opts.Env, cli.StripDash(cli.EnvFlagName), []string{}, fmt.Sprintf("environment `variable` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s MY_VAR=my-value", cli.EnvFlagName)))
+	cmd.Flags().StringArrayVar(&opts.EnvFrom, cli.StripDash(cli.EnvFromFlagName), []string{}, fmt.Sprintf("environment `variable` from a config map or secret, example %q, %q (may be set multiple times)", fmt.Sprintf("%s MY_SECRET_VALUE=secretKeyRef:my-secret-name:key-in-secret", cli.EnvFromFlagName), fmt.Sprintf("%s MY_CONFIG_MAP_VALUE=configMapKeyRef:my-config-map-name:key-in-config-map", cli.EnvFromFlagName)))
+	cmd.Flags().StringVar(&opts.LimitCPU, cli.StripDash(cli.LimitCPUFlagName), "", "the maximum amount of cpu allowed, in CPU `cores` (500m = .5 cores)")
+	cmd.Flags().StringVar(&opts.LimitMemory, cli.StripDash(cli.LimitMemoryFlagName), "", "the maximum amount of memory allowed, in `bytes` (500Mi = 500MiB = 500 * 1024 * 1024)")
+	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch deployer logs")
+	cli.TailFlags(cmd, cli.TailOutputFlagName, &opts.Output, &opts.Grep, &opts.Timestamps)
+	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the deployer to become ready when watching logs")
+	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().Int32Var(&opts.TargetPort, cli.StripDash(cli.TargetPortFlagName), 0, "`port` that the workload listens on for traffic. The value is exposed to the workload as the PORT environment variable")
+
+	return cmd
+}
@@ -17,6 +17,11 @@
 package k8s
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
 	projectriffclientset "github.com/projectriff/system/pkg/client/clientset/versioned"
 	buildv1alpha1 "github.com/projectriff/system/pkg/client/clientset/versioned/typed/build/v1alpha1"
 	corev1alpha1 "github.com/projectriff/system/pkg/client/clientset/versioned/typed/core/v1alpha1"
@@ -25,17 +30,22 @@ import (
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
 	"k8s.io/client-go/kubernetes"
+	appsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
 	authv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 type Client interface {
+	// Check loads the kube config and creates the clients, returning a
+	// ConfigError when the kube config is missing or invalid. Requests made
+	// with the clients of a client that fails to load return the same error.
+	Check() error
 	DefaultNamespace() string
 	KubeRestConfig() *rest.Config
 	Core() corev1.CoreV1Interface
+	Apps() appsv1.AppsV1Interface
 	Auth() authv1client.AuthorizationV1Interface
 	APIExtension() apiextensionsv1beta1.ApiextensionsV1beta1Interface
 	Build() buildv1alpha1.BuildV1alpha1Interface
@@ -44,111 +54,172 @@ type Client interface {
 	KnativeRuntime() knativev1alpha1.KnativeV1alpha1Interface
 }
 
+// ErrImpersonateGroupsWithoutUser is the cause of a ConfigError when the
+// overrides impersonate groups without impersonating a user.
+var ErrImpersonateGroupsWithoutUser = errors.New("impersonating groups requires impersonating a user")
+
+// ConfigError is returned when the kube config cannot be loaded.
+type ConfigError struct {
+	// File is the kube config file
+	File string
+	Err  error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("unable to load kube config %q: %s", e.File, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+func (c *client) Check() error {
+	return c.load()
+}
+
 func (c *client) DefaultNamespace() string {
-	return c.lazyLoadDefaultNamespaceOrDie()
+	c.load()
+	return c.defaultNamespace
 }
 
 func (c *client) KubeRestConfig() *rest.Config {
-	return c.lazyLoadRestConfigOrDie()
+	c.load()
+	return c.restConfig
 }
 
 func (c *client) Core() corev1.CoreV1Interface {
-	return c.lazyLoadKubernetesClientsetOrDie().CoreV1()
+	c.load()
+	return c.kubeClientset.CoreV1()
+}
+
+func (c *client) Apps() appsv1.AppsV1Interface {
+	c.load()
+	return c.kubeClientset.AppsV1()
 }
 
 func (c *client) Auth() authv1client.AuthorizationV1Interface {
-	return c.lazyLoadKubernetesClientsetOrDie().AuthorizationV1()
+	c.load()
+	return c.kubeClientset.AuthorizationV1()
 }
 
 func (c *client) APIExtension() apiextensionsv1beta1.ApiextensionsV1beta1Interface {
-	return c.lazyLoadAPIExtensionsClientsetOrDie().ApiextensionsV1beta1()
+	c.load()
+	return c.apiExtensionsClientset.ApiextensionsV1beta1()
 }
 
 func (c *client) Build() buildv1alpha1.BuildV1alpha1Interface {
-	return c.lazyLoadRiffClientsetOrDie().BuildV1alpha1()
+	c.load()
+	return c.riffClientset.BuildV1alpha1()
 }
 
 func (c *client) CoreRuntime() corev1alpha1.CoreV1alpha1Interface {
-	return c.lazyLoadRiffClientsetOrDie().CoreV1alpha1()
+	c.load()
+	return c.riffClientset.CoreV1alpha1()
 }
 
 func (c *client) StreamingRuntime() streamv1alpha1.StreamingV1alpha1Interface {
-	return c.lazyLoadRiffClientsetOrDie().StreamingV1alpha1()
+	c.load()
+	return c.riffClientset.StreamingV1alpha1()
 }
 
 func (c *client) KnativeRuntime() knativev1alpha1.KnativeV1alpha1Interface {
-	return c.lazyLoadRiffClientsetOrDie().KnativeV1alpha1()
+	c.load()
+	return c.riffClientset.KnativeV1alpha1()
 }
 
-func NewClient(kubeConfigFile string) Client {
-	return &client{kubeConfigFile: kubeConfigFile}
+// NewClient creates a client for the kube config file. The overrides select
+// the context, cluster, user or request timeout instead of the values from the
+// current context of the kube config.
+func NewClient(kubeConfigFile string, overrides clientcmd.ConfigOverrides) Client {
+	return &client{kubeConfigFile: kubeConfigFile, overrides: overrides}
 }
 
 type client struct {
+	loadOnce               sync.Once
+	loadErr                error
 	defaultNamespace       string
 	kubeConfigFile         string
-	kubeConfig             clientcmd.ClientConfig
+	overrides              clientcmd.ConfigOverrides
 	restConfig             *rest.Config
 	kubeClientset          *kubernetes.Clientset
 	apiExtensionsClientset *apiextensionsclientset.Clientset
 	riffClientset          *projectriffclientset.Clientset
 }
 
-func (c *client) lazyLoadKubeConfig() clientcmd.ClientConfig {
-	if c.kubeConfig == nil {
-		c.kubeConfig = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-			&clientcmd.ClientConfigLoadingRules{ExplicitPath: c.kubeConfigFile},
-			&clientcmd.ConfigOverrides{ClusterInfo: clientcmdapi.Cluster{Server: ""}},
-		)
-	}
-	return c.kubeConfig
+// load reads the kube config and creates each clientset once. When loading
+// fails, the clientsets are created for a config that fails every request with
+// the load error, so the accessors never return nil.
+func (c *client) load() error {
+	c.loadOnce.Do(func() {
+		if err := c.loadClients(); err != nil {
+			c.loadErr = &ConfigError{File: c.kubeConfigFile, Err: err}
+			c.defaultNamespace = ""
+			c.restConfig = failedRestConfig(c.loadErr)
+			// clientsets for the failed config never error
+			_ = c.loadClientsets()
+		}
+	})
+	return c.loadErr
 }
 
-func (c *client) lazyLoadRestConfigOrDie() *rest.Config {
-	if c.restConfig == nil {
-		kubeConfig := c.lazyLoadKubeConfig()
-		restConfig, err := kubeConfig.ClientConfig()
+func (c *client) loadClients() error {
+	if len(c.overrides.AuthInfo.ImpersonateGroups) != 0 && c.overrides.AuthInfo.Impersonate == "" {
+		return ErrImpersonateGroupsWithoutUser
+	}
+	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: c.kubeConfigFile},
+		&c.overrides,
+	)
+	if contextName := c.overrides.CurrentContext; contextName != "" {
+		// report a missing context the same way clientcmd reports a missing
+		// current context, so clientcmd.IsContextNotFound matches both
+		rawConfig, err := kubeConfig.RawConfig()
 		if err != nil {
-			panic(err)
+			return err
+		}
+		if _, ok := rawConfig.Contexts[contextName]; !ok {
+			return fmt.Errorf("context was not found for specified context: %s", contextName)
 		}
-		c.restConfig = restConfig
 	}
-	return c.restConfig
+	restConfig, err := kubeConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+	namespace, _, err := kubeConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	c.restConfig = restConfig
+	c.defaultNamespace = namespace
+	return c.loadClientsets()
 }
 
-func (c *client) lazyLoadKubernetesClientsetOrDie() *kubernetes.Clientset {
-	if c.kubeClientset == nil {
-		restConfig := c.lazyLoadRestConfigOrDie()
-		c.kubeClientset = kubernetes.NewForConfigOrDie(restConfig)
+func (c *client) loadClientsets() error {
+	var err error
+	if c.kubeClientset, err = kubernetes.NewForConfig(c.restConfig); err != nil {
+		return err
+	}
+	if c.apiExtensionsClientset, err = apiextensionsclientset.NewForConfig(c.restConfig); err != nil {
+		return err
 	}
-	return c.kubeClientset
+	if c.riffClientset, err = projectriffclientset.NewForConfig(c.restConfig); err != nil {
+		return err
+	}
+	return nil
 }
 
-func (c *client) lazyLoadAPIExtensionsClientsetOrDie() *apiextensionsclientset.Clientset {
-	if c.apiExtensionsClientset == nil {
-		restConfig := c.lazyLoadRestConfigOrDie()
-		c.apiExtensionsClientset = apiextensionsclientset.NewForConfigOrDie(restConfig)
+// failedRestConfig is a config for which every request fails with the error
+func failedRestConfig(err error) *rest.Config {
+	return &rest.Config{
+		Host:      "localhost",
+		Transport: failedTransport{err: err},
 	}
-	return c.apiExtensionsClientset
-
 }
 
-func (c *client) lazyLoadRiffClientsetOrDie() *projectriffclientset.Clientset {
-	if c.riffClientset == nil {
-		restConfig := c.lazyLoadRestConfigOrDie()
-		c.riffClientset = projectriffclientset.NewForConfigOrDie(restConfig)
-	}
-	return c.riffClientset
+type failedTransport struct {
+	err error
 }
 
-func (c *client) lazyLoadDefaultNamespaceOrDie() string {
-	if c.defaultNamespace == "" {
-		kubeConfig := c.lazyLoadKubeConfig()
-		namespace, _, err := kubeConfig.Namespace()
-		if err != nil {
-			panic(err)
-		}
-		c.defaultNamespace = namespace
-	}
-	return c.defaultNamespace
+func (t failedTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
 }
@@ -17,14 +17,27 @@
 package k8s_test
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/projectriff/cli/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 func TestNewClient(t *testing.T) {
-	client := k8s.NewClient("testdata/.kube/config")
+	client := k8s.NewClient("testdata/.kube/config", clientcmd.ConfigOverrides{})
 
+	if err := client.Check(); err != nil {
+		t.Errorf("Expected no error, actually %v", err)
+	}
 	if expected, actual := "my-namespace", client.DefaultNamespace(); expected != actual {
 		t.Errorf("Expected namespace to be %q, actually %q", expected, actual)
 	}
@@ -53,3 +66,156 @@ func TestNewClient(t *testing.T) {
 		t.Errorf("Expected KnativeRuntime client to not be nil")
 	}
 }
+
+func TestNewClient_Overrides(t *testing.T) {
+	tests := []struct {
+		name        string
+		overrides   clientcmd.ConfigOverrides
+		namespace   string
+		host        string
+		token       string
+		timeout     time.Duration
+		impersonate rest.ImpersonationConfig
+	}{{
+		name:      "context",
+		overrides: clientcmd.ConfigOverrides{CurrentContext: "other-context"},
+		namespace: "other-namespace",
+		host:      "https://192.168.1.2:8443",
+		token:     "other-token",
+	}, {
+		name:      "cluster",
+		overrides: clientcmd.ConfigOverrides{Context: clientcmdapi.Context{Cluster: "other-cluster"}},
+		namespace: "my-namespace",
+		host:      "https://192.168.1.2:8443",
+	}, {
+		name:      "user",
+		overrides: clientcmd.ConfigOverrides{Context: clientcmdapi.Context{AuthInfo: "other-user"}},
+		namespace: "my-namespace",
+		host:      "https://192.168.1.1:8443",
+		token:     "other-token",
+	}, {
+		name:      "request timeout",
+		overrides: clientcmd.ConfigOverrides{Timeout: "30s"},
+		namespace: "my-namespace",
+		host:      "https://192.168.1.1:8443",
+		timeout:   30 * time.Second,
+	}, {
+		name: "impersonate",
+		overrides: clientcmd.ConfigOverrides{AuthInfo: clientcmdapi.AuthInfo{
+			Impersonate:       "team-user",
+			ImpersonateGroups: []string{"team-group", "system:authenticated"},
+		}},
+		namespace: "my-namespace",
+		host:      "https://192.168.1.1:8443",
+		impersonate: rest.ImpersonationConfig{
+			UserName: "team-user",
+			Groups:   []string{"team-group", "system:authenticated"},
+			Extra:    map[string][]string{},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := k8s.NewClient("testdata/.kube/config", test.overrides)
+
+			if err := client.Check(); err != nil {
+				t.Fatalf("Expected no error, actually %v", err)
+			}
+			if expected, actual := test.namespace, client.DefaultNamespace(); expected != actual {
+				t.Errorf("Expected namespace to be %q, actually %q", expected, actual)
+			}
+			restConfig := client.KubeRestConfig()
+			if expected, actual := test.host, restConfig.Host; expected != actual {
+				t.Errorf("Expected host to be %q, actually %q", expected, actual)
+			}
+			if expected, actual := test.token, restConfig.BearerToken; expected != actual {
+				t.Errorf("Expected token to be %q, actually %q", expected, actual)
+			}
+			if expected, actual := test.timeout, restConfig.Timeout; expected != actual {
+				t.Errorf("Expected timeout to be %s, actually %s", expected, actual)
+			}
+			if diff := cmp.Diff(test.impersonate, restConfig.Impersonate); diff != "" {
+				t.Errorf("Unexpected impersonation (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewClient_ConfigError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kube")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	noContext := filepath.Join(dir, "no-context")
+	if err := ioutil.WriteFile(noContext, []byte("apiVersion: v1\nkind: Config\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		kubeConfig string
+		overrides  clientcmd.ConfigOverrides
+		check      func(t *testing.T, err error)
+	}{{
+		name:       "missing file",
+		kubeConfig: filepath.Join(dir, "missing"),
+	}, {
+		name:       "no current context",
+		kubeConfig: noContext,
+		check: func(t *testing.T, err error) {
+			if !clientcmd.IsEmptyConfig(err) {
+				t.Errorf("Expected empty config error, actually %v", err)
+			}
+		},
+	}, {
+		name:       "context not found",
+		kubeConfig: "testdata/.kube/config",
+		overrides:  clientcmd.ConfigOverrides{CurrentContext: "missing-context"},
+		check: func(t *testing.T, err error) {
+			if !clientcmd.IsContextNotFound(err) {
+				t.Errorf("Expected context not found error, actually %v", err)
+			}
+		},
+	}, {
+		name:       "impersonate groups without user",
+		kubeConfig: "testdata/.kube/config",
+		overrides:  clientcmd.ConfigOverrides{AuthInfo: clientcmdapi.AuthInfo{ImpersonateGroups: []string{"team-group"}}},
+		check: func(t *testing.T, err error) {
+			if !errors.Is(err, k8s.ErrImpersonateGroupsWithoutUser) {
+				t.Errorf("Expected impersonate groups without user error, actually %v", err)
+			}
+		},
+	}, {
+		name:       "invalid request timeout",
+		kubeConfig: "testdata/.kube/config",
+		overrides:  clientcmd.ConfigOverrides{Timeout: "soon"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := k8s.NewClient(test.kubeConfig, test.overrides)
+
+			var configErr *k8s.ConfigError
+			if err := client.Check(); !errors.As(err, &configErr) {
+				t.Fatalf("Expected ConfigError, actually %v", err)
+			}
+			if expected, actual := test.kubeConfig, configErr.File; expected != actual {
+				t.Errorf("Expected file to be %q, actually %q", expected, actual)
+			}
+			if test.check != nil {
+				test.check(t, configErr.Err)
+			}
+			if expected, actual := "", client.DefaultNamespace(); expected != actual {
+				t.Errorf("Expected namespace to be %q, actually %q", expected, actual)
+			}
+			if client.Core() == nil {
+				t.Fatalf("Expected Core client to not be nil")
+			}
+			_, err := client.Core().Namespaces().List(metav1.ListOptions{})
+			if !errors.As(err, &configErr) {
+				t.Errorf("Expected request to fail with ConfigError, actually %v", err)
+			}
+		})
+	}
+}
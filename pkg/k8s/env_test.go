@@ -0,0 +1,114 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMergeEnvVars(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      []corev1.EnvVar
+		overrides []corev1.EnvVar
+		expected  []corev1.EnvVar
+	}{{
+		name:     "empty",
+		expected: []corev1.EnvVar{},
+	}, {
+		name: "no overrides",
+		base: []corev1.EnvVar{
+			{Name: "MY_VAR", Value: "my-value"},
+		},
+		expected: []corev1.EnvVar{
+			{Name: "MY_VAR", Value: "my-value"},
+		},
+	}, {
+		name: "append",
+		base: []corev1.EnvVar{
+			{Name: "MY_VAR", Value: "my-value"},
+		},
+		overrides: []corev1.EnvVar{
+			{Name: "MY_OTHER_VAR", Value: "my-other-value"},
+		},
+		expected: []corev1.EnvVar{
+			{Name: "MY_VAR", Value: "my-value"},
+			{Name: "MY_OTHER_VAR", Value: "my-other-value"},
+		},
+	}, {
+		name: "replace",
+		base: []corev1.EnvVar{
+			{Name: "MY_VAR", Value: "my-value"},
+			{Name: "MY_OTHER_VAR", Value: "my-other-value"},
+		},
+		overrides: []corev1.EnvVar{
+			{Name: "MY_VAR", Value: "my-new-value"},
+		},
+		expected: []corev1.EnvVar{
+			{Name: "MY_VAR", Value: "my-new-value"},
+			{Name: "MY_OTHER_VAR", Value: "my-other-value"},
+		},
+	}, {
+		name: "replace value with value from",
+		base: []corev1.EnvVar{
+			{Name: "MY_VAR", Value: "my-value"},
+		},
+		overrides: []corev1.EnvVar{
+			{
+				Name: "MY_VAR",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+						Key:                  "my-key",
+					},
+				},
+			},
+		},
+		expected: []corev1.EnvVar{
+			{
+				Name: "MY_VAR",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+						Key:                  "my-key",
+					},
+				},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var base []corev1.EnvVar
+			if test.base != nil {
+				base = append(base, test.base...)
+			}
+			expected := test.expected
+			actual := k8s.MergeEnvVars(test.base, test.overrides...)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("MergeEnvVars() = (-expected, +actual): %s", diff)
+			}
+			if diff := cmp.Diff(base, test.base); diff != "" {
+				t.Errorf("MergeEnvVars() mutated base (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
@@ -19,9 +19,11 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/projectriff/system/pkg/apis"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -60,6 +62,11 @@ func readyCondition(target object) watchclient.ConditionFunc {
 		switch event.Type {
 		case watch.Added, watch.Modified:
 			status := obj.GetStatus()
+			if status.GetObservedGeneration() < obj.GetGeneration() {
+				// the status is stale until the controller observes the
+				// latest spec
+				return false, nil
+			}
 			if status.IsReady() {
 				return true, nil
 			}
@@ -75,15 +82,121 @@ func readyCondition(target object) watchclient.ConditionFunc {
 	}
 }
 
+// WaitUntilDeleted watches the resources in the namespace that match the list
+// options until none remain. The objType is an empty instance of the resource.
+func WaitUntilDeleted(ctx context.Context, client rest.Interface, resource, namespace string, listOptions metav1.ListOptions, objType runtime.Object) error {
+	lw := getListerWatcher(ctx, client, resource, namespace, listOptions)
+	var store cache.Store
+	_, err := watchclient.UntilWithSync(ctx, lw, objType, func(s cache.Store) (bool, error) {
+		store = s
+		return len(store.ListKeys()) == 0, nil
+	}, func(event watch.Event) (bool, error) {
+		if event.Type == watch.Error {
+			return false, fmt.Errorf("error waiting for %s to be deleted", resource)
+		}
+		// the store is updated before the event is delivered
+		return len(store.ListKeys()) == 0, nil
+	})
+	return err
+}
+
+// ListNames returns the sorted names of the resources in the namespace that
+// match the list options.
+func ListNames(ctx context.Context, client rest.Interface, resource, namespace string, listOptions metav1.ListOptions) ([]string, error) {
+	lw := getListerWatcher(ctx, client, resource, namespace, listOptions)
+	list, err := lw.List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, accessor.GetName())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// WatchList watches for mutations of resources in the namespace, or all
+// namespaces when empty, that match the selectors of the list options. The
+// handler is called for each event until the context is done or the handler
+// returns an error. The objType is an empty instance of the resource.
+func WatchList(ctx context.Context, client rest.Interface, resource, namespace string, listOptions metav1.ListOptions, objType runtime.Object, handler func(event watch.Event) error) error {
+	lw := getListerWatcher(ctx, client, resource, namespace, listOptions)
+	_, err := watchclient.UntilWithSync(ctx, lw, objType, nil, func(event watch.Event) (bool, error) {
+		if event.Type == watch.Error {
+			return false, fmt.Errorf("error watching %s", resource)
+		}
+		return false, handler(event)
+	})
+	if err == ErrWaitTimeout && ctx.Err() != nil {
+		// the watch ends when the context is done
+		return nil
+	}
+	return err
+}
+
 type lwKey struct{}
 
 func WithListerWatcher(ctx context.Context, lw cache.ListerWatcher) context.Context {
 	return context.WithValue(ctx, lwKey{}, lw)
 }
 
+type lwResourceKey struct {
+	resource string
+}
+
+// WithResourceListerWatcher overrides the lister watcher for a single resource,
+// taking precedence over WithListerWatcher. Watching several kinds of resource
+// requires a lister watcher for each kind.
+func WithResourceListerWatcher(ctx context.Context, resource string, lw cache.ListerWatcher) context.Context {
+	return context.WithValue(ctx, lwResourceKey{resource: resource}, lw)
+}
+
 func GetListerWatcher(ctx context.Context, client rest.Interface, resource string, target object) cache.ListerWatcher {
+	if lw, ok := ctx.Value(lwResourceKey{resource: resource}).(cache.ListerWatcher); ok {
+		return lw
+	}
 	if lw, ok := ctx.Value(lwKey{}).(cache.ListerWatcher); ok {
 		return lw
 	}
 	return cache.NewListWatchFromClient(client, resource, target.GetNamespace(), fields.Everything())
 }
+
+func getListerWatcher(ctx context.Context, client rest.Interface, resource, namespace string, listOptions metav1.ListOptions) cache.ListerWatcher {
+	optionsModifier := func(options *metav1.ListOptions) {
+		options.LabelSelector = listOptions.LabelSelector
+		options.FieldSelector = listOptions.FieldSelector
+	}
+	if lw, ok := ctx.Value(lwResourceKey{resource: resource}).(cache.ListerWatcher); ok {
+		return &filteredListerWatcher{ListerWatcher: lw, optionsModifier: optionsModifier}
+	}
+	if lw, ok := ctx.Value(lwKey{}).(cache.ListerWatcher); ok {
+		return &filteredListerWatcher{ListerWatcher: lw, optionsModifier: optionsModifier}
+	}
+	return cache.NewFilteredListWatchFromClient(client, resource, namespace, optionsModifier)
+}
+
+// filteredListerWatcher applies the selectors of a list to an overridden
+// lister watcher, matching the filtering of a lister watcher from a client.
+type filteredListerWatcher struct {
+	cache.ListerWatcher
+	optionsModifier func(options *metav1.ListOptions)
+}
+
+func (lw *filteredListerWatcher) List(options metav1.ListOptions) (runtime.Object, error) {
+	lw.optionsModifier(&options)
+	return lw.ListerWatcher.List(options)
+}
+
+func (lw *filteredListerWatcher) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	lw.optionsModifier(&options)
+	return lw.ListerWatcher.Watch(options)
+}
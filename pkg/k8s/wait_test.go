@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/projectriff/cli/pkg/k8s"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	"github.com/projectriff/system/pkg/apis"
@@ -81,6 +82,14 @@ func TestWaitUntilReady(t *testing.T) {
 			updateReadyOther(application, corev1.ConditionFalse, "not my app"),
 			updateReady(application, corev1.ConditionTrue, ""),
 		},
+	}, {
+		name:     "ignore stale status",
+		resource: application.DeepCopy(),
+		events: []watch.Event{
+			updateReadyGeneration(application, 2, 1, corev1.ConditionTrue, ""),
+			updateReadyGeneration(application, 2, 2, corev1.ConditionFalse, "test not ready"),
+		},
+		err: fmt.Errorf("failed to become ready: %s", "test not ready"),
 	}, {
 		name:     "bail on delete",
 		resource: application.DeepCopy(),
@@ -124,6 +133,14 @@ func updateReady(application *buildv1alpha1.Application, status corev1.Condition
 	return watch.Event{Type: watch.Modified, Object: application}
 }
 
+func updateReadyGeneration(application *buildv1alpha1.Application, generation, observedGeneration int64, status corev1.ConditionStatus, message string) watch.Event {
+	event := updateReady(application, status, message)
+	application = event.Object.(*buildv1alpha1.Application)
+	application.Generation = generation
+	application.Status.ObservedGeneration = observedGeneration
+	return event
+}
+
 func updateReadyOther(application *buildv1alpha1.Application, status corev1.ConditionStatus, message string) watch.Event {
 	application = application.DeepCopy()
 	application.UID = "not-a-uid"
@@ -131,3 +148,184 @@ func updateReadyOther(application *buildv1alpha1.Application, status corev1.Cond
 	application.Status.Conditions[0].Message = message
 	return watch.Event{Type: watch.Modified, Object: application}
 }
+
+func TestWatchList(t *testing.T) {
+	// using Application, but any type will work
+	application := &buildv1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-application",
+		},
+		Status: buildv1alpha1.ApplicationStatus{
+			Status: apis.Status{
+				Conditions: apis.Conditions{
+					{
+						Type:   apis.ConditionReady,
+						Status: corev1.ConditionUnknown,
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		events  []watch.Event
+		handled []watch.EventType
+		err     error
+	}{{
+		name: "handles events until done",
+		events: []watch.Event{
+			{Type: watch.Added, Object: application.DeepCopy()},
+			updateReady(application, corev1.ConditionTrue, ""),
+			{Type: watch.Deleted, Object: application.DeepCopy()},
+		},
+		handled: []watch.EventType{watch.Added, watch.Modified, watch.Deleted},
+	}, {
+		name: "handler error",
+		events: []watch.Event{
+			{Type: watch.Added, Object: application.DeepCopy()},
+		},
+		handled: []watch.EventType{watch.Added},
+		err:     fmt.Errorf("handler error"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lw := cachetesting.NewFakeControllerSource()
+			defer lw.Shutdown()
+			ctx := k8s.WithListerWatcher(context.Background(), lw)
+			ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+
+			client := rifftesting.NewClient()
+			handled := []watch.EventType{}
+			done := make(chan error, 1)
+			defer close(done)
+			go func() {
+				done <- k8s.WatchList(ctx, client.Build().RESTClient(), "applications", "default", metav1.ListOptions{}, &buildv1alpha1.Application{}, func(event watch.Event) error {
+					handled = append(handled, event.Type)
+					return test.err
+				})
+			}()
+
+			time.Sleep(5 * time.Millisecond)
+			for _, event := range test.events {
+				lw.Change(event, 1)
+			}
+
+			err := <-done
+			if expected, actual := fmt.Sprintf("%s", test.err), fmt.Sprintf("%s", err); expected != actual {
+				t.Errorf("expected error %v, actually %v", expected, actual)
+			}
+			if expected, actual := fmt.Sprintf("%v", test.handled), fmt.Sprintf("%v", handled); expected != actual {
+				t.Errorf("expected events %s, actually %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestWaitUntilDeleted(t *testing.T) {
+	// using Application, but any type will work
+	application := &buildv1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-application",
+		},
+	}
+	otherApplication := application.DeepCopy()
+	otherApplication.Name = "my-other-application"
+
+	tests := []struct {
+		name    string
+		given   []*buildv1alpha1.Application
+		events  []watch.Event
+		timeout bool
+	}{{
+		name: "already deleted",
+	}, {
+		name:  "deleted",
+		given: []*buildv1alpha1.Application{application},
+		events: []watch.Event{
+			{Type: watch.Deleted, Object: application.DeepCopy()},
+		},
+	}, {
+		name:  "all deleted",
+		given: []*buildv1alpha1.Application{application, otherApplication},
+		events: []watch.Event{
+			{Type: watch.Deleted, Object: application.DeepCopy()},
+			{Type: watch.Deleted, Object: otherApplication.DeepCopy()},
+		},
+	}, {
+		name:  "some remain",
+		given: []*buildv1alpha1.Application{application, otherApplication},
+		events: []watch.Event{
+			{Type: watch.Deleted, Object: application.DeepCopy()},
+		},
+		timeout: true,
+	}, {
+		name:  "modified",
+		given: []*buildv1alpha1.Application{application},
+		events: []watch.Event{
+			{Type: watch.Modified, Object: application.DeepCopy()},
+		},
+		timeout: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lw := cachetesting.NewFakeControllerSource()
+			defer lw.Shutdown()
+			for _, given := range test.given {
+				lw.Add(given.DeepCopy())
+			}
+			ctx := k8s.WithListerWatcher(context.Background(), lw)
+			// the cache is synced before the first check, which takes at least 100ms
+			ctx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+			defer cancel()
+
+			client := rifftesting.NewClient()
+			done := make(chan error, 1)
+			defer close(done)
+			go func() {
+				done <- k8s.WaitUntilDeleted(ctx, client.Build().RESTClient(), "applications", "default", metav1.ListOptions{}, &buildv1alpha1.Application{})
+			}()
+
+			time.Sleep(5 * time.Millisecond)
+			for _, event := range test.events {
+				lw.Change(event, 1)
+			}
+
+			err := <-done
+			if expected, actual := test.timeout, err == k8s.ErrWaitTimeout; expected != actual {
+				t.Errorf("expected timeout %v, actually %v", expected, err)
+			}
+			if !test.timeout && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestListNames(t *testing.T) {
+	lw := cachetesting.NewFakeControllerSource()
+	defer lw.Shutdown()
+	for _, name := range []string{"my-application", "my-other-application"} {
+		lw.Add(&buildv1alpha1.Application{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+			},
+		})
+	}
+	ctx := k8s.WithListerWatcher(context.Background(), lw)
+
+	client := rifftesting.NewClient()
+	names, err := k8s.ListNames(ctx, client.Build().RESTClient(), "applications", "default", metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if diff := cmp.Diff([]string{"my-application", "my-other-application"}, names); diff != "" {
+		t.Errorf("Unexpected names (-expected, +actual): %s", diff)
+	}
+}
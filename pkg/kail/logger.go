@@ -22,6 +22,8 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"net/http"
+	"strings"
 	"time"
 
 	logutil "github.com/boz/go-logutil"
@@ -33,17 +35,67 @@ import (
 	streamingv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
 )
 
+const (
+	// LogOutputJSON formats each log line as a JSON object
+	LogOutputJSON = "json"
+	// AllContainers streams logs from every container in the pod, including
+	// sidecars and init containers
+	AllContainers = "all"
+)
+
+// LogOptions control which log lines are streamed and how they are printed.
+type LogOptions struct {
+	// Since is how far back to start reading logs from
+	Since time.Duration
+	// Follow streams new log lines until canceled, otherwise the existing
+	// log lines are printed and the call returns
+	Follow bool
+	// Limit is the number of most recent log lines to print for each
+	// container when not following, zero prints every line
+	Limit int64
+	// Containers to stream logs from, defaults to the containers of interest
+	// for the resource. AllContainers selects every container.
+	Containers []string
+	// Prefix identifies the source of each log line when logs from several
+	// resources are merged
+	Prefix string
+	// Output is the format for each log line, either plain text when empty
+	// or LogOutputJSON
+	Output string
+	// Grep is a regular expression a log line must match to be printed
+	Grep string
+	// Timestamps prefixes each plain text log line with the time it was
+	// logged
+	Timestamps bool
+}
+
+// containers selects the containers to stream logs from, an empty list selects
+// every container in the pod.
+func (opts LogOptions) containers(defaults ...string) []string {
+	if len(opts.Containers) == 0 {
+		return defaults
+	}
+	for _, container := range opts.Containers {
+		if container == AllContainers {
+			return []string{}
+		}
+	}
+	return opts.Containers
+}
+
 type Logger interface {
-	ApplicationLogs(ctx context.Context, application *buildv1alpha1.Application, since time.Duration, out io.Writer) error
-	FunctionLogs(ctx context.Context, function *buildv1alpha1.Function, since time.Duration, out io.Writer) error
-	CoreDeployerLogs(ctx context.Context, deployer *corev1alpha1.Deployer, since time.Duration, out io.Writer) error
-	StreamingProcessorLogs(ctx context.Context, processor *streamingv1alpha1.Processor, since time.Duration, out io.Writer) error
-	KafkaGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.KafkaGateway, since time.Duration, out io.Writer) error
-	PulsarGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.PulsarGateway, since time.Duration, out io.Writer) error
-	InMemoryGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.InMemoryGateway, since time.Duration, out io.Writer) error
-	KnativeDeployerLogs(ctx context.Context, deployer *knativev1alpha1.Deployer, since time.Duration, out io.Writer) error
+	ApplicationLogs(ctx context.Context, application *buildv1alpha1.Application, opts LogOptions, out io.Writer) error
+	FunctionLogs(ctx context.Context, function *buildv1alpha1.Function, opts LogOptions, out io.Writer) error
+	CoreDeployerLogs(ctx context.Context, deployer *corev1alpha1.Deployer, opts LogOptions, out io.Writer) error
+	StreamingProcessorLogs(ctx context.Context, processor *streamingv1alpha1.Processor, opts LogOptions, out io.Writer) error
+	KafkaGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.KafkaGateway, opts LogOptions, out io.Writer) error
+	PulsarGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.PulsarGateway, opts LogOptions, out io.Writer) error
+	InMemoryGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.InMemoryGateway, opts LogOptions, out io.Writer) error
+	KnativeDeployerLogs(ctx context.Context, deployer *knativev1alpha1.Deployer, opts LogOptions, out io.Writer) error
 }
 
 func NewDefault(k8s k8s.Client) Logger {
@@ -56,84 +108,97 @@ type logger struct {
 	k8s k8s.Client
 }
 
-func (c *logger) ApplicationLogs(ctx context.Context, application *buildv1alpha1.Application, since time.Duration, out io.Writer) error {
+func (c *logger) ApplicationLogs(ctx context.Context, application *buildv1alpha1.Application, opts LogOptions, out io.Writer) error {
 	selector, err := labels.Parse(fmt.Sprintf("%s=%s", buildv1alpha1.ApplicationLabelKey, application.Name))
 	if err != nil {
 		panic(err)
 	}
-	containers := []string{}
-	return c.stream(ctx, application.Namespace, selector, containers, since, out)
+	containers := opts.containers()
+	return c.stream(ctx, application.Namespace, selector, containers, opts, out)
 }
 
-func (c *logger) FunctionLogs(ctx context.Context, function *buildv1alpha1.Function, since time.Duration, out io.Writer) error {
+func (c *logger) FunctionLogs(ctx context.Context, function *buildv1alpha1.Function, opts LogOptions, out io.Writer) error {
 	selector, err := labels.Parse(fmt.Sprintf("%s=%s", buildv1alpha1.FunctionLabelKey, function.Name))
 	if err != nil {
 		panic(err)
 	}
-	containers := []string{}
-	return c.stream(ctx, function.Namespace, selector, containers, since, out)
+	containers := opts.containers()
+	return c.stream(ctx, function.Namespace, selector, containers, opts, out)
 }
 
-func (c *logger) CoreDeployerLogs(ctx context.Context, deployer *corev1alpha1.Deployer, since time.Duration, out io.Writer) error {
+func (c *logger) CoreDeployerLogs(ctx context.Context, deployer *corev1alpha1.Deployer, opts LogOptions, out io.Writer) error {
 	selector, err := labels.Parse(fmt.Sprintf("%s=%s", corev1alpha1.DeployerLabelKey, deployer.Name))
 	if err != nil {
 		panic(err)
 	}
-	containers := []string{}
-	return c.stream(ctx, deployer.Namespace, selector, containers, since, out)
+	containers := opts.containers()
+	return c.stream(ctx, deployer.Namespace, selector, containers, opts, out)
 }
 
-func (c *logger) StreamingProcessorLogs(ctx context.Context, processor *streamingv1alpha1.Processor, since time.Duration, out io.Writer) error {
+func (c *logger) StreamingProcessorLogs(ctx context.Context, processor *streamingv1alpha1.Processor, opts LogOptions, out io.Writer) error {
 	selector, err := labels.Parse(fmt.Sprintf("%s=%s", streamingv1alpha1.ProcessorLabelKey, processor.Name))
 	if err != nil {
 		panic(err)
 	}
-	containers := []string{"function", "processor"}
-	return c.stream(ctx, processor.Namespace, selector, containers, since, out)
+	containers := opts.containers("function", "processor")
+	return c.stream(ctx, processor.Namespace, selector, containers, opts, out)
 }
 
-func (c *logger) KafkaGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.KafkaGateway, since time.Duration, out io.Writer) error {
+func (c *logger) KafkaGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.KafkaGateway, opts LogOptions, out io.Writer) error {
 	selector, err := labels.Parse(fmt.Sprintf("%s=%s", streamingv1alpha1.KafkaGatewayLabelKey, gateway.Name))
 	if err != nil {
 		panic(err)
 	}
-	containers := []string{}
-	return c.stream(ctx, gateway.Namespace, selector, containers, since, out)
+	containers := opts.containers()
+	return c.stream(ctx, gateway.Namespace, selector, containers, opts, out)
 }
 
-func (c *logger) PulsarGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.PulsarGateway, since time.Duration, out io.Writer) error {
+func (c *logger) PulsarGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.PulsarGateway, opts LogOptions, out io.Writer) error {
 	selector, err := labels.Parse(fmt.Sprintf("%s=%s", streamingv1alpha1.PulsarGatewayLabelKey, gateway.Name))
 	if err != nil {
 		panic(err)
 	}
-	containers := []string{}
-	return c.stream(ctx, gateway.Namespace, selector, containers, since, out)
+	containers := opts.containers()
+	return c.stream(ctx, gateway.Namespace, selector, containers, opts, out)
 }
 
-func (c *logger) InMemoryGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.InMemoryGateway, since time.Duration, out io.Writer) error {
+func (c *logger) InMemoryGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.InMemoryGateway, opts LogOptions, out io.Writer) error {
 	selector, err := labels.Parse(fmt.Sprintf("%s=%s", streamingv1alpha1.InMemoryGatewayLabelKey, gateway.Name))
 	if err != nil {
 		panic(err)
 	}
-	containers := []string{}
-	return c.stream(ctx, gateway.Namespace, selector, containers, since, out)
+	containers := opts.containers()
+	return c.stream(ctx, gateway.Namespace, selector, containers, opts, out)
 }
 
-func (c *logger) KnativeDeployerLogs(ctx context.Context, deployer *knativev1alpha1.Deployer, since time.Duration, out io.Writer) error {
+func (c *logger) KnativeDeployerLogs(ctx context.Context, deployer *knativev1alpha1.Deployer, opts LogOptions, out io.Writer) error {
 	selector, err := labels.Parse(fmt.Sprintf("%s=%s", knativev1alpha1.DeployerLabelKey, deployer.Name))
 	if err != nil {
 		panic(err)
 	}
-	containers := []string{"user-container"}
-	return c.stream(ctx, deployer.Namespace, selector, containers, since, out)
+	containers := opts.containers("user-container")
+	return c.stream(ctx, deployer.Namespace, selector, containers, opts, out)
 }
 
-func (c *logger) stream(ctx context.Context, namespace string, selector labels.Selector, containers []string, since time.Duration, out io.Writer) error {
+func (c *logger) stream(ctx context.Context, namespace string, selector labels.Selector, containers []string, opts LogOptions, out io.Writer) error {
+	writer, err := newWriter(out, opts)
+	if err != nil {
+		return err
+	}
+	if !opts.Follow {
+		return c.print(ctx, namespace, selector, containers, opts, writer)
+	}
+
 	// avoid kail logs appearing
 	l := logutil.New(log.New(ioutil.Discard, "", log.LstdFlags), ioutil.Discard)
 	ctx = logutil.NewContext(ctx, l)
 
-	rc := c.k8s.KubeRestConfig()
+	// kail does not request timestamps from the api server, add them to each
+	// request for logs
+	rc := rest.CopyConfig(c.k8s.KubeRestConfig())
+	rc.WrapTransport = transport.Wrappers(rc.WrapTransport, func(rt http.RoundTripper) http.RoundTripper {
+		return &timestampsRoundTripper{rt: rt}
+	})
 	cs, err := kubernetes.NewForConfig(rc)
 	if err != nil {
 		return err
@@ -143,17 +208,37 @@ func (c *logger) stream(ctx context.Context, namespace string, selector labels.S
 		return err
 	}
 	filter := kail.NewContainerFilter(containers)
-	controller, err := kail.NewController(ctx, cs, rc, ds.Pods(), filter, since)
+	controller, err := kail.NewController(ctx, cs, rc, ds.Pods(), filter, opts.Since)
 	if err != nil {
 		return err
 	}
-	writer := kail.NewWriter(out)
 	for {
 		select {
 		case ev := <-controller.Events():
-			writer.Print(ev)
+			if err := writer.Print(ev); err != nil {
+				return err
+			}
 		case <-controller.Done():
 			return nil
 		}
 	}
 }
+
+// timestampsRoundTripper requests timestamps for each line of a pod's logs
+type timestampsRoundTripper struct {
+	rt http.RoundTripper
+}
+
+func (t *timestampsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.HasSuffix(req.URL.Path, "/log") {
+		return t.rt.RoundTrip(req)
+	}
+	// the request must not be modified, send a copy with the timestamps param
+	req = req.WithContext(req.Context())
+	u := *req.URL
+	query := u.Query()
+	query.Set("timestamps", "true")
+	u.RawQuery = query.Encode()
+	req.URL = &u
+	return t.rt.RoundTrip(req)
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kail
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLogOptionsContainers(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     LogOptions
+		defaults []string
+		expected []string
+	}{{
+		name:     "defaults",
+		defaults: []string{"function", "processor"},
+		expected: []string{"function", "processor"},
+	}, {
+		name:     "no defaults",
+		expected: nil,
+	}, {
+		name:     "selected",
+		opts:     LogOptions{Containers: []string{"processor"}},
+		defaults: []string{"function", "processor"},
+		expected: []string{"processor"},
+	}, {
+		name:     "all",
+		opts:     LogOptions{Containers: []string{AllContainers}},
+		defaults: []string{"function", "processor"},
+		expected: []string{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.opts.containers(test.defaults...)
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("containers() (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
+func TestTimestampsRoundTripper(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{{
+		name:     "pod logs",
+		url:      "https://example.com/api/v1/namespaces/default/pods/my-pod/log?container=function&follow=true",
+		expected: "https://example.com/api/v1/namespaces/default/pods/my-pod/log?container=function&follow=true&timestamps=true",
+	}, {
+		name:     "other requests",
+		url:      "https://example.com/api/v1/namespaces/default/pods?watch=true",
+		expected: "https://example.com/api/v1/namespaces/default/pods?watch=true",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, test.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var actual string
+			rt := &timestampsRoundTripper{rt: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				actual = req.URL.String()
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			})}
+			if _, err := rt.RoundTrip(req); err != nil {
+				t.Fatalf("RoundTrip() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("RoundTrip() (-expected, +actual): %s", diff)
+			}
+			if diff := cmp.Diff(test.url, req.URL.String()); diff != "" {
+				t.Errorf("RoundTrip() modified the request (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
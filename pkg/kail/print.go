@@ -0,0 +1,133 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kail
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/boz/kail"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// print writes the existing logs for the selected containers of each pod
+// and returns rather than following new log lines
+func (c *logger) print(ctx context.Context, namespace string, selector labels.Selector, containers []string, opts LogOptions, writer *writer) error {
+	pods, err := c.k8s.Core().Pods(namespace).List(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+	for _, pod := range pods.Items {
+		for _, container := range startedContainers(&pod, containers) {
+			stream, err := c.k8s.Core().Pods(namespace).GetLogs(pod.Name, podLogOptions(container, opts)).Context(ctx).Stream()
+			if err != nil {
+				return err
+			}
+			source := &eventSource{namespace: pod.Namespace, name: pod.Name, container: container, node: pod.Spec.NodeName}
+			err = printLines(stream, source, writer)
+			stream.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// startedContainers are the names of the selected containers in the pod that
+// have logs to read, init containers are listed first
+func startedContainers(pod *corev1.Pod, containers []string) []string {
+	selected := func(name string) bool {
+		if len(containers) == 0 {
+			return true
+		}
+		for _, container := range containers {
+			if container == name {
+				return true
+			}
+		}
+		return false
+	}
+	started := func(status corev1.ContainerStatus) bool {
+		return status.State.Running != nil || status.State.Terminated != nil || status.LastTerminationState.Terminated != nil
+	}
+
+	names := []string{}
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if selected(status.Name) && started(status) {
+				names = append(names, status.Name)
+			}
+		}
+	}
+	return names
+}
+
+func podLogOptions(container string, opts LogOptions) *corev1.PodLogOptions {
+	podLogOptions := &corev1.PodLogOptions{
+		Container:  container,
+		Timestamps: true,
+	}
+	if opts.Since > 0 {
+		// round up to the nearest second, the api does not accept partial seconds
+		sinceSeconds := int64(math.Ceil(opts.Since.Seconds()))
+		podLogOptions.SinceSeconds = &sinceSeconds
+	}
+	if opts.Limit > 0 {
+		tailLines := opts.Limit
+		podLogOptions.TailLines = &tailLines
+	}
+	return podLogOptions
+}
+
+func printLines(r io.Reader, source kail.EventSource, writer *writer) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if err := writer.Print(&event{source: source, log: scanner.Bytes()}); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+type eventSource struct {
+	namespace string
+	name      string
+	container string
+	node      string
+}
+
+func (s *eventSource) Namespace() string { return s.namespace }
+func (s *eventSource) Name() string      { return s.name }
+func (s *eventSource) Container() string { return s.container }
+func (s *eventSource) Node() string      { return s.node }
+
+type event struct {
+	source kail.EventSource
+	log    []byte
+}
+
+func (e *event) Source() kail.EventSource { return e.source }
+func (e *event) Log() []byte              { return e.log }
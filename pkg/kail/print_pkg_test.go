@@ -0,0 +1,135 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kail
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestStartedContainers(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	waiting := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}}
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{Name: "init", State: terminated},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "function", State: running},
+				{Name: "processor", State: waiting, LastTerminationState: terminated},
+				{Name: "sidecar", State: waiting},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		containers []string
+		expected   []string
+	}{{
+		name:     "all",
+		expected: []string{"init", "function", "processor"},
+	}, {
+		name:       "selected",
+		containers: []string{"processor"},
+		expected:   []string{"processor"},
+	}, {
+		name:       "not started",
+		containers: []string{"sidecar"},
+		expected:   []string{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := startedContainers(pod, test.containers)
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("startedContainers() (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
+func TestPodLogOptions(t *testing.T) {
+	sinceSeconds := int64(2)
+	tailLines := int64(10)
+
+	tests := []struct {
+		name     string
+		opts     LogOptions
+		expected *corev1.PodLogOptions
+	}{{
+		name: "all lines",
+		expected: &corev1.PodLogOptions{
+			Container:  "function",
+			Timestamps: true,
+		},
+	}, {
+		name: "since",
+		opts: LogOptions{Since: 1500 * time.Millisecond},
+		expected: &corev1.PodLogOptions{
+			Container:    "function",
+			SinceSeconds: &sinceSeconds,
+			Timestamps:   true,
+		},
+	}, {
+		name: "limit",
+		opts: LogOptions{Limit: 10},
+		expected: &corev1.PodLogOptions{
+			Container:  "function",
+			TailLines:  &tailLines,
+			Timestamps: true,
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := podLogOptions("function", test.opts)
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("podLogOptions() (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
+func TestPrintLines(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	source := &eventSource{namespace: "default", name: "my-pod", container: "function", node: "my-node"}
+	out := &bytes.Buffer{}
+	w, err := newWriter(out, LogOptions{Grep: "ERROR"})
+	if err != nil {
+		t.Fatalf("newWriter() unexpected error: %v", err)
+	}
+
+	if err := printLines(strings.NewReader("starting\nERROR something went wrong\ndone\n"), source, w); err != nil {
+		t.Fatalf("printLines() unexpected error: %v", err)
+	}
+	expected := "default/my-pod[function]: ERROR something went wrong\n"
+	if diff := cmp.Diff(expected, out.String()); diff != "" {
+		t.Errorf("printLines() (-expected, +actual): %s", diff)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kail
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/boz/kail"
+	"github.com/fatih/color"
+)
+
+// sourceColors distinguish the prefixes of logs merged from several sources
+var sourceColors = []*color.Color{
+	color.New(color.FgCyan),
+	color.New(color.FgMagenta),
+	color.New(color.FgYellow),
+	color.New(color.FgGreen),
+	color.New(color.FgBlue),
+}
+
+// logLine is the JSON representation of a log line
+type logLine struct {
+	Source    string `json:"source,omitempty"`
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Timestamp string `json:"timestamp"`
+	Message   string `json:"message"`
+}
+
+type writer struct {
+	out        io.Writer
+	text       kail.Writer
+	prefix     string
+	output     string
+	grep       *regexp.Regexp
+	timestamps bool
+	now        func() time.Time
+}
+
+func newWriter(out io.Writer, opts LogOptions) (*writer, error) {
+	if opts.Output != "" && opts.Output != LogOutputJSON {
+		return nil, fmt.Errorf("unknown log output format %q", opts.Output)
+	}
+	var grep *regexp.Regexp
+	if opts.Grep != "" {
+		var err error
+		grep, err = regexp.Compile(opts.Grep)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &writer{
+		out:        out,
+		text:       kail.NewWriter(out),
+		prefix:     opts.Prefix,
+		output:     opts.Output,
+		grep:       grep,
+		timestamps: opts.Timestamps,
+		now:        time.Now,
+	}, nil
+}
+
+func (w *writer) Print(ev kail.Event) error {
+	timestamp, message := w.timestamp(bytes.TrimRight(ev.Log(), "\n"))
+	if w.grep != nil && !w.grep.Match(message) {
+		return nil
+	}
+	ev = &event{source: ev.Source(), log: message}
+
+	// write each line at once so lines from concurrent sources do not mix
+	line := &bytes.Buffer{}
+	if w.output == LogOutputJSON {
+		data, err := json.Marshal(logLine{
+			Source:    w.prefix,
+			Namespace: ev.Source().Namespace(),
+			Pod:       ev.Source().Name(),
+			Container: ev.Source().Container(),
+			Timestamp: timestamp,
+			Message:   string(message),
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(line, "%s\n", data)
+	} else {
+		if w.prefix != "" {
+			sourceColor(w.prefix).Fprintf(line, "[%s] ", w.prefix)
+		}
+		if w.timestamps {
+			fmt.Fprintf(line, "%s ", timestamp)
+		}
+		if err := w.text.Fprint(line, ev); err != nil {
+			return err
+		}
+	}
+	_, err := w.out.Write(line.Bytes())
+	return err
+}
+
+// timestamp splits the timestamp added by the api server from the log line.
+// The time the line was received is used for lines without a timestamp.
+func (w *writer) timestamp(line []byte) (string, []byte) {
+	if i := bytes.IndexByte(line, ' '); i > 0 {
+		if t, err := time.Parse(time.RFC3339Nano, string(line[:i])); err == nil {
+			return t.UTC().Format(time.RFC3339Nano), line[i+1:]
+		}
+	}
+	return w.now().UTC().Format(time.RFC3339Nano), line
+}
+
+// sourceColor picks a stable color for the source prefix
+func sourceColor(prefix string) *color.Color {
+	h := fnv.New32a()
+	h.Write([]byte(prefix))
+	return sourceColors[h.Sum32()%uint32(len(sourceColors))]
+}
+
+// NewSyncWriter guards the writer so it can be shared by logs streamed
+// concurrently from several sources.
+func NewSyncWriter(out io.Writer) io.Writer {
+	return &syncWriter{out: out}
+}
+
+type syncWriter struct {
+	m   sync.Mutex
+	out io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.out.Write(p)
+}
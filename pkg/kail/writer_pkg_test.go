@@ -0,0 +1,157 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kail
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/boz/kail"
+	"github.com/fatih/color"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriter(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	source := &eventSource{namespace: "default", name: "my-pod", container: "function", node: "my-node"}
+	events := []kail.Event{
+		&event{source: source, log: []byte("starting\n")},
+		&event{source: source, log: []byte("ERROR something went wrong\n")},
+		&event{source: source, log: []byte("done")},
+	}
+	serverEvents := []kail.Event{
+		&event{source: source, log: []byte("2019-12-01T12:29:58.123456789Z starting\n")},
+		&event{source: source, log: []byte("2019-12-01T13:29:59+01:00 done")},
+	}
+	now := time.Date(2019, time.December, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		opts        LogOptions
+		events      []kail.Event
+		expected    string
+		expectedErr bool
+	}{{
+		name: "text",
+		expected: `default/my-pod[function]: starting
+default/my-pod[function]: ERROR something went wrong
+default/my-pod[function]: done
+`,
+	}, {
+		name: "timestamps",
+		opts: LogOptions{Timestamps: true},
+		expected: `2019-12-01T12:30:00Z default/my-pod[function]: starting
+2019-12-01T12:30:00Z default/my-pod[function]: ERROR something went wrong
+2019-12-01T12:30:00Z default/my-pod[function]: done
+`,
+	}, {
+		name:   "server timestamps",
+		opts:   LogOptions{Timestamps: true},
+		events: serverEvents,
+		expected: `2019-12-01T12:29:58.123456789Z default/my-pod[function]: starting
+2019-12-01T12:29:59Z default/my-pod[function]: done
+`,
+	}, {
+		name:   "server timestamps omitted",
+		events: serverEvents,
+		expected: `default/my-pod[function]: starting
+default/my-pod[function]: done
+`,
+	}, {
+		name:   "json server timestamps",
+		opts:   LogOptions{Output: LogOutputJSON},
+		events: serverEvents,
+		expected: `{"namespace":"default","pod":"my-pod","container":"function","timestamp":"2019-12-01T12:29:58.123456789Z","message":"starting"}
+{"namespace":"default","pod":"my-pod","container":"function","timestamp":"2019-12-01T12:29:59Z","message":"done"}
+`,
+	}, {
+		name: "grep",
+		opts: LogOptions{Grep: "^ERROR"},
+		expected: `default/my-pod[function]: ERROR something went wrong
+`,
+	}, {
+		name:   "grep server timestamps",
+		opts:   LogOptions{Timestamps: true, Grep: "^done"},
+		events: serverEvents,
+		expected: `2019-12-01T12:29:59Z default/my-pod[function]: done
+`,
+	}, {
+		name:   "grep ignores server timestamps",
+		opts:   LogOptions{Grep: "2019"},
+		events: serverEvents,
+	}, {
+		name: "json",
+		opts: LogOptions{Output: LogOutputJSON},
+		expected: `{"namespace":"default","pod":"my-pod","container":"function","timestamp":"2019-12-01T12:30:00Z","message":"starting"}
+{"namespace":"default","pod":"my-pod","container":"function","timestamp":"2019-12-01T12:30:00Z","message":"ERROR something went wrong"}
+{"namespace":"default","pod":"my-pod","container":"function","timestamp":"2019-12-01T12:30:00Z","message":"done"}
+`,
+	}, {
+		name: "json grep",
+		opts: LogOptions{Output: LogOutputJSON, Grep: "done"},
+		expected: `{"namespace":"default","pod":"my-pod","container":"function","timestamp":"2019-12-01T12:30:00Z","message":"done"}
+`,
+	}, {
+		name: "prefix",
+		opts: LogOptions{Prefix: "processor/my-processor", Grep: "done"},
+		expected: `[processor/my-processor] default/my-pod[function]: done
+`,
+	}, {
+		name: "json prefix",
+		opts: LogOptions{Prefix: "processor/my-processor", Output: LogOutputJSON, Grep: "done"},
+		expected: `{"source":"processor/my-processor","namespace":"default","pod":"my-pod","container":"function","timestamp":"2019-12-01T12:30:00Z","message":"done"}
+`,
+	}, {
+		name:        "invalid grep",
+		opts:        LogOptions{Grep: "("},
+		expectedErr: true,
+	}, {
+		name:        "unknown output",
+		opts:        LogOptions{Output: "yaml"},
+		expectedErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			w, err := newWriter(out, test.opts)
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("newWriter() expected error %v, actually %v", test.expectedErr, err)
+			}
+			if test.expectedErr {
+				return
+			}
+			w.now = func() time.Time { return now }
+			testEvents := events
+			if test.events != nil {
+				testEvents = test.events
+			}
+			for _, ev := range testEvents {
+				if err := w.Print(ev); err != nil {
+					t.Fatalf("Print() unexpected error: %v", err)
+				}
+			}
+			if diff := cmp.Diff(test.expected, out.String()); diff != "" {
+				t.Errorf("(-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
@@ -25,7 +25,9 @@ import (
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
 	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/parsers"
 	"github.com/projectriff/cli/pkg/race"
+	"github.com/projectriff/cli/pkg/validation"
 	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +36,9 @@ import (
 type AdapterCreateOptions struct {
 	options.ResourceOptions
 
+	Labels      []string
+	Annotations []string
+
 	ApplicationRef string
 	ContainerRef   string
 	FunctionRef    string
@@ -57,6 +62,8 @@ func (opts *AdapterCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 	errs := cli.FieldErrors{}
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+	errs = errs.Also(validation.Labels(opts.Labels, cli.LabelFlagName))
+	errs = errs.Also(validation.Annotations(opts.Annotations, cli.AnnotationFlagName))
 
 	// application-ref, build-ref and container-ref are mutually exclusive
 	used := []string{}
@@ -126,8 +133,10 @@ func (opts *AdapterCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 func (opts *AdapterCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
 	adapter := &knativev1alpha1.Adapter{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: opts.Namespace,
-			Name:      opts.Name,
+			Namespace:   opts.Namespace,
+			Name:        opts.Name,
+			Labels:      parsers.KeyValues(opts.Labels),
+			Annotations: parsers.KeyValues(opts.Annotations),
 		},
 		Spec: knativev1alpha1.AdapterSpec{},
 	}
@@ -232,6 +241,8 @@ the image for an existing resource.
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch adapter logs")
 	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the adapter to become ready when watching logs")
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().StringArrayVar(&opts.Labels, cli.StripDash(cli.LabelFlagName), []string{}, fmt.Sprintf("`label` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s team=payments", cli.LabelFlagName)))
+	cmd.Flags().StringArrayVar(&opts.Annotations, cli.StripDash(cli.AnnotationFlagName), []string{}, fmt.Sprintf("`annotation` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s owner=jane@example.com", cli.AnnotationFlagName)))
 
 	return cmd
 }
@@ -204,6 +204,31 @@ func TestAdapterCreateCommand(t *testing.T) {
 			},
 			ExpectOutput: `
 Created adapter "my-adapter"
+`,
+		},
+		{
+			Name: "create with labels and annotations",
+			Args: []string{adapterName, cli.ApplicationRefFlagName, applicationRef, cli.ServiceRefFlagName, serviceRef, cli.LabelFlagName, "team=payments", cli.AnnotationFlagName, "owner=jane@example.com"},
+			ExpectCreates: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   defaultNamespace,
+						Name:        adapterName,
+						Labels:      map[string]string{"team": "payments"},
+						Annotations: map[string]string{"owner": "jane@example.com"},
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Build: knativev1alpha1.Build{
+							ApplicationRef: applicationRef,
+						},
+						Target: knativev1alpha1.AdapterTarget{
+							ServiceRef: serviceRef,
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created adapter "my-adapter"
 `,
 		},
 		{
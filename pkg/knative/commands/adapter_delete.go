@@ -20,11 +20,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type AdapterDeleteOptions struct {
@@ -46,13 +47,22 @@ func (opts *AdapterDeleteOptions) Validate(ctx context.Context) cli.FieldErrors
 
 func (opts *AdapterDeleteOptions) Exec(ctx context.Context, c *cli.Config) error {
 	client := c.KnativeRuntime().Adapters(opts.Namespace)
+	deleted := options.DeletedResource{
+		Client:   c.KnativeRuntime().RESTClient(),
+		Resource: "adapters",
+		ObjType:  &knativev1alpha1.Adapter{},
+	}
 
 	if opts.All {
-		if err := client.DeleteCollection(nil, metav1.ListOptions{}); err != nil {
+		names, err := opts.DeletedNames(ctx, deleted, opts.K8sListOptions())
+		if err != nil {
+			return err
+		}
+		if err := client.DeleteCollection(nil, opts.K8sListOptions()); err != nil {
 			return err
 		}
 		c.Successf("Deleted adapters in namespace %q\n", opts.Namespace)
-		return nil
+		return opts.WaitForDeletion(ctx, c, deleted, names)
 	}
 
 	for _, name := range opts.Names {
@@ -62,7 +72,7 @@ func (opts *AdapterDeleteOptions) Exec(ctx context.Context, c *cli.Config) error
 		c.Successf("Deleted adapter %q\n", name)
 	}
 
-	return nil
+	return opts.WaitForDeletion(ctx, c, deleted, opts.Names)
 }
 
 func NewAdapterDeleteCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -76,7 +86,9 @@ Delete one or more adapters by name or all adapters within a namespace.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s knative adapter delete my-adapter", c.Name),
+			fmt.Sprintf("%s knative adapter delete my-adapter %s", c.Name, cli.WaitFlagName),
 			fmt.Sprintf("%s knative adapter delete %s", c.Name, cli.AllFlagName),
+			fmt.Sprintf("%s knative adapter delete %s %s team=payments", c.Name, cli.AllFlagName, cli.SelectorFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -88,6 +100,9 @@ Delete one or more adapters by name or all adapters within a namespace.
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all adapters within the namespace")
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
+	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(cli.WaitFlagName), false, "wait for the adapters to be removed")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), time.Minute, "`duration` to wait for the adapters to be removed")
 
 	return cmd
 }
@@ -27,7 +27,6 @@ import (
 	"github.com/projectriff/cli/pkg/cli/printers"
 	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -50,18 +49,15 @@ func (opts *AdapterListOptions) Validate(ctx context.Context) cli.FieldErrors {
 }
 
 func (opts *AdapterListOptions) Exec(ctx context.Context, c *cli.Config) error {
-	adapters, err := c.KnativeRuntime().Adapters(opts.Namespace).List(metav1.ListOptions{})
+	listOptions := opts.K8sListOptions()
+	adapters, err := c.KnativeRuntime().Adapters(opts.Namespace).List(listOptions)
 	if err != nil {
 		return err
 	}
 
-	if len(adapters.Items) == 0 {
-		c.Infof("No adapters found.\n")
-		return nil
-	}
-
 	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{
 		WithNamespace: opts.AllNamespaces,
+		Wide:          opts.Output == printers.OutputFormatWide,
 	}).With(func(h printers.PrintHandler) {
 		columns := opts.printColumns()
 		h.TableHandler(columns, opts.printList)
@@ -71,7 +67,25 @@ func (opts *AdapterListOptions) Exec(ctx context.Context, c *cli.Config) error {
 	adapters = adapters.DeepCopy()
 	cli.SortByNamespaceAndName(adapters.Items)
 
-	return tablePrinter.PrintObj(adapters, c.Stdout)
+	if len(adapters.Items) == 0 && printers.IsHumanReadable(opts.Output) {
+		c.Infof("No adapters found.\n")
+	} else if err := printers.PrintList(opts.Output, tablePrinter, adapters, knativev1alpha1.SchemeGroupVersion.WithKind("Adapter"), c.Stdout); err != nil {
+		return err
+	}
+
+	if opts.Watch {
+		return cli.WatchList(ctx, c, adapters, cli.WatchListOptions{
+			Client:       c.KnativeRuntime().RESTClient(),
+			Resource:     "adapters",
+			Namespace:    opts.Namespace,
+			ListOptions:  listOptions,
+			Output:       opts.Output,
+			TablePrinter: tablePrinter,
+			ItemKind:     knativev1alpha1.SchemeGroupVersion.WithKind("Adapter"),
+		})
+	}
+
+	return nil
 }
 
 func NewAdapterListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -90,12 +104,17 @@ For detail regarding the status of a single adapter, run:
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s knative adapter list", c.Name),
 			fmt.Sprintf("%s knative adapter list %s", c.Name, cli.AllNamespacesFlagName),
+			fmt.Sprintf("%s knative adapter list %s json", c.Name, cli.OutputFlagName),
+			fmt.Sprintf("%s knative adapter list %s", c.Name, cli.WatchFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.AllNamespacesFlag(cmd, c, &opts.Namespace, &opts.AllNamespaces)
+	cli.OutputFlag(cmd, &opts.Output)
+	cli.WatchFlag(cmd, &opts.Watch)
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
 
 	return cmd
 }
@@ -112,7 +131,7 @@ func (opts *AdapterListOptions) printList(adapters *knativev1alpha1.AdapterList,
 	return rows, nil
 }
 
-func (opts *AdapterListOptions) print(adapter *knativev1alpha1.Adapter, _ printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+func (opts *AdapterListOptions) print(adapter *knativev1alpha1.Adapter, printOpts printers.PrintOptions) ([]metav1beta1.TableRow, error) {
 	now := time.Now()
 	row := metav1beta1.TableRow{
 		Object: runtime.RawExtension{Object: adapter},
@@ -128,6 +147,11 @@ func (opts *AdapterListOptions) print(adapter *knativev1alpha1.Adapter, _ printe
 		cli.FormatConditionStatus(adapter.Status.GetCondition(knativev1alpha1.AdapterConditionReady)),
 		cli.FormatTimestampSince(adapter.CreationTimestamp, now),
 	)
+	if printOpts.Wide {
+		row.Cells = append(row.Cells,
+			cli.FormatEmptyString(adapter.Status.LatestImage),
+		)
+	}
 	return []metav1beta1.TableRow{row}, nil
 }
 
@@ -140,6 +164,7 @@ func (opts *AdapterListOptions) printColumns() []metav1beta1.TableColumnDefiniti
 		{Name: "Target Ref", Type: "string"},
 		{Name: "Status", Type: "string"},
 		{Name: "Age", Type: "string"},
+		{Name: "Latest Image", Type: "string", Priority: 1},
 	}
 }
 
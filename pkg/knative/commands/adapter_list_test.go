@@ -194,6 +194,37 @@ NAME        BUILD TYPE    BUILD REF      TARGET TYPE     TARGET REF         STAT
 app         application   my-app         service         my-service         Ready    <unknown>
 container   container     my-container   configuration   my-configuration   Ready    <unknown>
 func        function      my-func        service         my-service         Ready    <unknown>
+`,
+		},
+		{
+			Name: "wide output",
+			Args: []string{cli.OutputFlagName, "wide"},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+NAME           BUILD TYPE   BUILD REF   TARGET TYPE   TARGET REF   STATUS      AGE         LATEST IMAGE
+test-adapter   <unknown>    <unknown>   <unknown>     <unknown>    <unknown>   <unknown>   <empty>
+`,
+		},
+		{
+			Name: "name output",
+			Args: []string{cli.OutputFlagName, "name"},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+adapter/test-adapter
 `,
 		},
 		{
@@ -23,6 +23,8 @@ import (
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/projectriff/cli/pkg/validation"
 	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
 	"github.com/spf13/cobra"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -31,6 +33,8 @@ import (
 
 type AdapterStatusOptions struct {
 	options.ResourceOptions
+
+	Output string
 }
 
 var (
@@ -43,6 +47,8 @@ func (opts *AdapterStatusOptions) Validate(ctx context.Context) cli.FieldErrors
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
 
+	errs = errs.Also(validation.StructuredOutputFormat(opts.Output, cli.OutputFlagName))
+
 	return errs
 }
 
@@ -56,10 +62,22 @@ func (opts *AdapterStatusOptions) Exec(ctx context.Context, c *cli.Config) error
 		return cli.SilenceError(err)
 	}
 
+	if opts.Output != "" {
+		return printers.PrintObject(opts.Output, adapter, knativev1alpha1.SchemeGroupVersion.WithKind("Adapter"), c.Stdout)
+	}
+
 	ready := adapter.Status.GetCondition(knativev1alpha1.AdapterConditionReady)
 	cli.PrintResourceStatus(c, adapter.Name, ready)
 
-	return nil
+	return cli.PrintResourceStatusDetails(c, cli.ResourceStatusDetails{
+		GroupVersionKind: adapter.GetGroupVersionKind(),
+		Namespace:        adapter.Namespace,
+		Name:             adapter.Name,
+		Conditions:       adapter.Status.Conditions,
+		Details: []cli.StatusDetail{
+			{Name: "Latest Image", Value: adapter.Status.LatestImage},
+		},
+	})
 }
 
 func NewAdapterStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -75,9 +93,13 @@ The Ready condition is shown which should include a reason code and a
 descriptive message when the status is not "True". The status for the condition
 may be: "True", "False" or "Unknown". An "Unknown" status is common while the
 adapter roll out is processed.
+
+Key fields from the status, every condition and the most recent events for
+the adapter are shown after the Ready condition.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s knative adapter status my-adapter", c.Name),
+			fmt.Sprintf("%s knative adapter status my-adapter %s jsonpath={.status.latestImage}", c.Name, cli.OutputFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -88,6 +110,7 @@ adapter roll out is processed.
 	)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cli.StructuredOutputFlag(cmd, &opts.Output)
 
 	return cmd
 }
@@ -20,6 +20,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/knative/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	"github.com/projectriff/system/pkg/apis"
@@ -45,6 +46,14 @@ func TestAdapterStatusOptions(t *testing.T) {
 			},
 			ShouldValidate: true,
 		},
+		{
+			Name: "invalid output",
+			Options: &commands.AdapterStatusOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Output:          "xml",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("xml", cli.OutputFlagName),
+		},
 	}
 
 	table.Run(t)
@@ -96,7 +105,76 @@ message: a hopefully informative message about what went wrong
 reason: OopsieDoodle
 status: "False"
 type: Ready
+
+Conditions:
+  TYPE    STATUS   REASON         LAST TRANSITION        MESSAGE
+  Ready   False    OopsieDoodle   2019-06-29T01:44:05Z   a hopefully informative message about what went wrong
+
+Events:
+  <none>
 `,
+		},
+		{
+			Name: "jsonpath output",
+			Args: []string{adapterName, cli.OutputFlagName, "jsonpath={.status.conditions[0].reason}"},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+					},
+					Status: knativev1alpha1.AdapterStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:    apis.ConditionReady,
+									Status:  corev1.ConditionFalse,
+									Reason:  "OopsieDoodle",
+									Message: "a hopefully informative message about what went wrong",
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+OopsieDoodle`,
+		},
+		{
+			Name: "go-template output",
+			Args: []string{adapterName, cli.OutputFlagName, `go-template={{.metadata.name}}: {{range .status.conditions}}{{.type}}={{.status}}{{end}}`},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+					},
+					Status: knativev1alpha1.AdapterStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:    apis.ConditionReady,
+									Status:  corev1.ConditionFalse,
+									Reason:  "OopsieDoodle",
+									Message: "a hopefully informative message about what went wrong",
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+my-adapter: Ready=False`,
 		},
 		{
 			Name: "not found",
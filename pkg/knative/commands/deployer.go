@@ -46,6 +46,7 @@ The hostname to access the deployer is available in the deployer listing.
 
 	cmd.AddCommand(NewDeployerListCommand(ctx, c))
 	cmd.AddCommand(NewDeployerCreateCommand(ctx, c))
+	cmd.AddCommand(NewDeployerUpdateCommand(ctx, c))
 	cmd.AddCommand(NewDeployerDeleteCommand(ctx, c))
 	cmd.AddCommand(NewDeployerStatusCommand(ctx, c))
 	cmd.AddCommand(NewDeployerTailCommand(ctx, c))
@@ -37,6 +37,10 @@ import (
 
 type DeployerCreateOptions struct {
 	options.ResourceOptions
+	options.TailOptions
+
+	Labels      []string
+	Annotations []string
 
 	Image          string
 	ApplicationRef string
@@ -71,6 +75,8 @@ func (opts *DeployerCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 	errs := cli.FieldErrors{}
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+	errs = errs.Also(validation.Labels(opts.Labels, cli.LabelFlagName))
+	errs = errs.Also(validation.Annotations(opts.Annotations, cli.AnnotationFlagName))
 
 	// application-ref, build-ref and image are mutually exclusive
 	used := []string{}
@@ -149,6 +155,7 @@ func (opts *DeployerCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 	if opts.DryRun && opts.Tail {
 		errs = errs.Also(cli.ErrMultipleOneOf(cli.DryRunFlagName, cli.TailFlagName))
 	}
+	errs = errs.Also(opts.TailOptions.Validate(ctx, cli.TailOutputFlagName))
 
 	return errs
 }
@@ -156,11 +163,17 @@ func (opts *DeployerCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 func (opts *DeployerCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
 	deployer := &knativev1alpha1.Deployer{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: opts.Namespace,
-			Name:      opts.Name,
+			Namespace:   opts.Namespace,
+			Name:        opts.Name,
+			Labels:      parsers.KeyValues(opts.Labels),
+			Annotations: parsers.KeyValues(opts.Annotations),
 		},
 		Spec: knativev1alpha1.DeployerSpec{
 			Template: &corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      parsers.KeyValues(opts.Labels),
+					Annotations: parsers.KeyValues(opts.Annotations),
+				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{{}},
 				},
@@ -244,7 +257,7 @@ func (opts *DeployerCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 				return k8s.WaitUntilReady(ctx, c.KnativeRuntime().RESTClient(), "deployers", deployer)
 			},
 			func(ctx context.Context) error {
-				return c.Kail.KnativeDeployerLogs(ctx, deployer, cli.TailSinceCreateDefault, c.Stdout)
+				return c.Kail.KnativeDeployerLogs(ctx, deployer, opts.LogOptions(cli.TailSinceCreateDefault), c.Stdout)
 			},
 		)
 		if err == context.DeadlineExceeded {
@@ -314,8 +327,11 @@ and ` + cli.EnvFromFlagName + ` to map values from a ConfigMap or Secret.
 	cmd.Flags().Int32Var(&opts.MaxScale, cli.StripDash(cli.MaxScaleFlagName), int32(0), "maximum `number` of replicas (default unbounded)")
 	cmd.Flags().Int32Var(&opts.MinScale, cli.StripDash(cli.MinScaleFlagName), int32(0), "minimum `number` of replicas (default 0)")
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch deployer logs")
+	cli.TailFlags(cmd, cli.TailOutputFlagName, &opts.Output, &opts.Grep, &opts.Timestamps)
 	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the deployer to become ready when watching logs")
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().StringArrayVar(&opts.Labels, cli.StripDash(cli.LabelFlagName), []string{}, fmt.Sprintf("`label` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s team=payments", cli.LabelFlagName)))
+	cmd.Flags().StringArrayVar(&opts.Annotations, cli.StripDash(cli.AnnotationFlagName), []string{}, fmt.Sprintf("`annotation` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s owner=jane@example.com", cli.AnnotationFlagName)))
 	cmd.Flags().Int32Var(&opts.TargetPort, cli.StripDash(cli.TargetPortFlagName), 0, "`port` that the workload listens on for traffic. The value is exposed to the workload as the PORT environment variable")
 
 	return cmd
@@ -24,6 +24,7 @@ import (
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/k8s"
+	riffkail "github.com/projectriff/cli/pkg/kail"
 	"github.com/projectriff/cli/pkg/knative/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	kailtesting "github.com/projectriff/cli/pkg/testing/kail"
@@ -369,6 +370,37 @@ func TestDeployerCreateCommand(t *testing.T) {
 			},
 			ExpectOutput: `
 Created deployer "my-deployer"
+`,
+		},
+		{
+			Name: "create with labels and annotations",
+			Args: []string{deployerName, cli.ImageFlagName, image, cli.LabelFlagName, "team=payments", cli.AnnotationFlagName, "owner=jane@example.com"},
+			ExpectCreates: []runtime.Object{
+				&knativev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   defaultNamespace,
+						Name:        deployerName,
+						Labels:      map[string]string{"team": "payments"},
+						Annotations: map[string]string{"owner": "jane@example.com"},
+					},
+					Spec: knativev1alpha1.DeployerSpec{
+						Template: &corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Labels:      map[string]string{"team": "payments"},
+								Annotations: map[string]string{"owner": "jane@example.com"},
+							},
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{Image: image},
+								},
+							},
+						},
+						IngressPolicy: knativev1alpha1.IngressPolicyClusterLocal,
+					},
+				},
+			},
+			ExpectOutput: `
+Created deployer "my-deployer"
 `,
 		},
 		{
@@ -770,7 +802,7 @@ Created deployer "my-deployer"
 						},
 						IngressPolicy: knativev1alpha1.IngressPolicyClusterLocal,
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -828,7 +860,7 @@ Deployer "my-deployer" is ready
 						},
 						IngressPolicy: knativev1alpha1.IngressPolicyClusterLocal,
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(k8s.ErrWaitTimeout).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(k8s.ErrWaitTimeout).Run(func(args mock.Arguments) {
 					ctx := args[0].(context.Context)
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 					// wait for context to be cancelled
@@ -897,7 +929,7 @@ To continue watching logs run: riff knative deployer tail my-deployer --namespac
 						},
 						IngressPolicy: knativev1alpha1.IngressPolicyClusterLocal,
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(fmt.Errorf("kail error"))
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
 				return ctx, nil
 			},
 			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
@@ -27,7 +27,6 @@ import (
 	"github.com/projectriff/cli/pkg/cli/printers"
 	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -50,18 +49,15 @@ func (opts *DeployerListOptions) Validate(ctx context.Context) cli.FieldErrors {
 }
 
 func (opts *DeployerListOptions) Exec(ctx context.Context, c *cli.Config) error {
-	deployers, err := c.KnativeRuntime().Deployers(opts.Namespace).List(metav1.ListOptions{})
+	listOptions := opts.K8sListOptions()
+	deployers, err := c.KnativeRuntime().Deployers(opts.Namespace).List(listOptions)
 	if err != nil {
 		return err
 	}
 
-	if len(deployers.Items) == 0 {
-		c.Infof("No deployers found.\n")
-		return nil
-	}
-
 	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{
 		WithNamespace: opts.AllNamespaces,
+		Wide:          opts.Output == printers.OutputFormatWide,
 	}).With(func(h printers.PrintHandler) {
 		columns := opts.printColumns()
 		h.TableHandler(columns, opts.printList)
@@ -71,7 +67,25 @@ func (opts *DeployerListOptions) Exec(ctx context.Context, c *cli.Config) error
 	deployers = deployers.DeepCopy()
 	cli.SortByNamespaceAndName(deployers.Items)
 
-	return tablePrinter.PrintObj(deployers, c.Stdout)
+	if len(deployers.Items) == 0 && printers.IsHumanReadable(opts.Output) {
+		c.Infof("No deployers found.\n")
+	} else if err := printers.PrintList(opts.Output, tablePrinter, deployers, knativev1alpha1.SchemeGroupVersion.WithKind("Deployer"), c.Stdout); err != nil {
+		return err
+	}
+
+	if opts.Watch {
+		return cli.WatchList(ctx, c, deployers, cli.WatchListOptions{
+			Client:       c.KnativeRuntime().RESTClient(),
+			Resource:     "deployers",
+			Namespace:    opts.Namespace,
+			ListOptions:  listOptions,
+			Output:       opts.Output,
+			TablePrinter: tablePrinter,
+			ItemKind:     knativev1alpha1.SchemeGroupVersion.WithKind("Deployer"),
+		})
+	}
+
+	return nil
 }
 
 func NewDeployerListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -90,12 +104,17 @@ For detail regarding the status of a single deployer, run:
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s knative deployer list", c.Name),
 			fmt.Sprintf("%s knative deployer list %s", c.Name, cli.AllNamespacesFlagName),
+			fmt.Sprintf("%s knative deployer list %s json", c.Name, cli.OutputFlagName),
+			fmt.Sprintf("%s knative deployer list %s", c.Name, cli.WatchFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.AllNamespacesFlag(cmd, c, &opts.Namespace, &opts.AllNamespaces)
+	cli.OutputFlag(cmd, &opts.Output)
+	cli.WatchFlag(cmd, &opts.Watch)
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
 
 	return cmd
 }
@@ -112,7 +131,7 @@ func (opts *DeployerListOptions) printList(deployers *knativev1alpha1.DeployerLi
 	return rows, nil
 }
 
-func (opts *DeployerListOptions) print(deployer *knativev1alpha1.Deployer, _ printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+func (opts *DeployerListOptions) print(deployer *knativev1alpha1.Deployer, printOpts printers.PrintOptions) ([]metav1beta1.TableRow, error) {
 	now := time.Now()
 	row := metav1beta1.TableRow{
 		Object: runtime.RawExtension{Object: deployer},
@@ -137,6 +156,12 @@ func (opts *DeployerListOptions) print(deployer *knativev1alpha1.Deployer, _ pri
 		cli.FormatConditionStatus(deployer.Status.GetCondition(knativev1alpha1.DeployerConditionReady)),
 		cli.FormatTimestampSince(deployer.CreationTimestamp, now),
 	)
+	if printOpts.Wide {
+		row.Cells = append(row.Cells,
+			cli.FormatEmptyString(string(deployer.Spec.IngressPolicy)),
+			cli.FormatEmptyString(deployer.Status.LatestImage),
+		)
+	}
 	return []metav1beta1.TableRow{row}, nil
 }
 
@@ -148,6 +173,8 @@ func (opts *DeployerListOptions) printColumns() []metav1beta1.TableColumnDefinit
 		{Name: "URL", Type: "string"},
 		{Name: "Status", Type: "string"},
 		{Name: "Age", Type: "string"},
+		{Name: "Ingress Policy", Type: "string", Priority: 1},
+		{Name: "Latest Image", Type: "string", Priority: 1},
 	}
 }
 
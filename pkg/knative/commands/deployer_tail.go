@@ -24,14 +24,18 @@ import (
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/kail"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type DeployerTailOptions struct {
 	options.ResourceOptions
+	options.TailOptions
 
-	Since string
+	Since  string
+	Follow bool
+	Limit  int64
 }
 
 var (
@@ -43,12 +47,19 @@ func (opts *DeployerTailOptions) Validate(ctx context.Context) cli.FieldErrors {
 	errs := cli.FieldErrors{}
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+	errs = errs.Also(opts.TailOptions.Validate(ctx, cli.OutputFlagName))
 
 	if opts.Since != "" {
 		if _, err := time.ParseDuration(opts.Since); err != nil {
 			errs = errs.Also(cli.ErrInvalidValue(opts.Since, cli.SinceFlagName))
 		}
 	}
+	if opts.Limit < 0 {
+		errs = errs.Also(cli.ErrInvalidValue(opts.Limit, cli.LimitFlagName))
+	}
+	if opts.Limit != 0 && opts.Follow {
+		errs = errs.Also(cli.ErrMultipleOneOf(cli.FollowFlagName, cli.LimitFlagName))
+	}
 
 	return errs
 }
@@ -59,11 +70,18 @@ func (opts *DeployerTailOptions) Exec(ctx context.Context, c *cli.Config) error
 		return err
 	}
 	since := cli.TailSinceDefault
+	if !opts.Follow {
+		// print every existing log line unless bounded
+		since = 0
+	}
 	if opts.Since != "" {
 		// error is protected by Validate()
 		since, _ = time.ParseDuration(opts.Since)
 	}
-	return c.Kail.KnativeDeployerLogs(ctx, deployer, since, c.Stdout)
+	logOptions := opts.LogOptions(since)
+	logOptions.Follow = opts.Follow
+	logOptions.Limit = opts.Limit
+	return c.Kail.KnativeDeployerLogs(ctx, deployer, logOptions, c.Stdout)
 }
 
 func NewDeployerTailCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -78,10 +96,16 @@ shell or kill the process.
 
 As new deployer pods are started, the logs are displayed. To show historical logs
 use ` + cli.SinceFlagName + `.
+
+To print the existing logs and exit rather than waiting for new logs use
+` + cli.FollowFlagName + `=false.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s knative deployer tail my-deployer", c.Name),
 			fmt.Sprintf("%s knative deployer tail my-deployer %s 1h", c.Name, cli.SinceFlagName),
+			fmt.Sprintf("%s knative deployer tail my-deployer %s=false %s 100", c.Name, cli.FollowFlagName, cli.LimitFlagName),
+			fmt.Sprintf("%s knative deployer tail my-deployer %s json %s ERROR", c.Name, cli.OutputFlagName, cli.GrepFlagName),
+			fmt.Sprintf("%s knative deployer tail my-deployer %s %s", c.Name, cli.ContainerFlagName, kail.AllContainers),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -93,6 +117,10 @@ use ` + cli.SinceFlagName + `.
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().StringVar(&opts.Since, cli.StripDash(cli.SinceFlagName), "", "time `duration` to start reading logs from")
+	cmd.Flags().BoolVar(&opts.Follow, cli.StripDash(cli.FollowFlagName), true, "stream new log lines until canceled, when false the existing log lines are printed")
+	cmd.Flags().Int64Var(&opts.Limit, cli.StripDash(cli.LimitFlagName), 0, fmt.Sprintf("`number` of the most recent log lines to print for each container, requires %s=false", cli.FollowFlagName))
+	cmd.Flags().StringArrayVarP(&opts.Containers, cli.StripDash(cli.ContainerFlagName), "c", []string{}, fmt.Sprintf("`name` of the container to stream logs from, or %q for every container (may be set multiple times)", kail.AllContainers))
+	cli.TailFlags(cmd, cli.OutputFlagName, &opts.Output, &opts.Grep, &opts.Timestamps)
 
 	return cmd
 }
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
+	riffkail "github.com/projectriff/cli/pkg/kail"
 	"github.com/projectriff/cli/pkg/knative/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	kailtesting "github.com/projectriff/cli/pkg/testing/kail"
@@ -91,7 +92,7 @@ func TestDeployerTailCommand(t *testing.T) {
 			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
 				kail := &kailtesting.Logger{}
 				c.Kail = kail
-				kail.On("KnativeDeployerLogs", mock.Anything, deployer, cli.TailSinceDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				kail.On("KnativeDeployerLogs", mock.Anything, deployer, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -114,7 +115,7 @@ func TestDeployerTailCommand(t *testing.T) {
 			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
 				kail := &kailtesting.Logger{}
 				c.Kail = kail
-				kail.On("KnativeDeployerLogs", mock.Anything, deployer, time.Hour, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				kail.On("KnativeDeployerLogs", mock.Anything, deployer, riffkail.LogOptions{Since: time.Hour, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -142,7 +143,7 @@ func TestDeployerTailCommand(t *testing.T) {
 			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
 				kail := &kailtesting.Logger{}
 				c.Kail = kail
-				kail.On("KnativeDeployerLogs", mock.Anything, deployer, cli.TailSinceDefault, mock.Anything).Return(fmt.Errorf("kail error"))
+				kail.On("KnativeDeployerLogs", mock.Anything, deployer, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
 				return ctx, nil
 			},
 			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
@@ -0,0 +1,671 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
+	riffkail "github.com/projectriff/cli/pkg/kail"
+	"github.com/projectriff/cli/pkg/knative/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	kailtesting "github.com/projectriff/cli/pkg/testing/kail"
+	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	cachetesting "k8s.io/client-go/tools/cache/testing"
+)
+
+func TestDeployerUpdateOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "invalid resource",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.InvalidResourceOptions,
+				Image:           "example.com/repo:tag",
+			},
+			ExpectFieldErrors: rifftesting.InvalidResourceOptionsFieldError,
+		},
+		{
+			Name: "valid",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "nothing to update",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Tail:            true,
+				WaitTimeout:     "10m",
+			},
+			ExpectFieldErrors: cli.ErrNothingToUpdate(
+				cli.ImageFlagName, cli.ApplicationRefFlagName, cli.ContainerRefFlagName, cli.FunctionRefFlagName,
+				cli.IngressPolicyFlagName, cli.TargetPortFlagName,
+				cli.EnvFlagName, cli.EnvFromFlagName, cli.LimitCPUFlagName, cli.LimitMemoryFlagName,
+				cli.MinScaleFlagName, cli.MaxScaleFlagName,
+			),
+		},
+		{
+			Name: "from application",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				ApplicationRef:  "my-application",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "from image",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "from application, container, function and image",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				ApplicationRef:  "my-application",
+				ContainerRef:    "my-container",
+				FunctionRef:     "my-function",
+				Image:           "example.com/repo:tag",
+			},
+			ExpectFieldErrors: cli.ErrMultipleOneOf(cli.ApplicationRefFlagName, cli.ContainerRefFlagName, cli.FunctionRefFlagName, cli.ImageFlagName),
+		},
+		{
+			Name: "with external ingress",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				IngressPolicy:   string(knativev1alpha1.IngressPolicyExternal),
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with bogus ingress",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				IngressPolicy:   "bogus",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("bogus", cli.IngressPolicyFlagName),
+		},
+		{
+			Name: "with env",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Env:             []string{"VAR1=foo", "VAR2=bar"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with invalid env",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Env:             []string{"=foo"},
+			},
+			ExpectFieldErrors: cli.ErrInvalidArrayValue("=foo", cli.EnvFlagName, 0),
+		},
+		{
+			Name: "with envfrom secret",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				EnvFrom:         []string{"VAR1=secretKeyRef:name:key"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with invalid envfrom",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				EnvFrom:         []string{"VAR1=someOtherKeyRef:name:key"},
+			},
+			ExpectFieldErrors: cli.ErrInvalidArrayValue("VAR1=someOtherKeyRef:name:key", cli.EnvFromFlagName, 0),
+		},
+		{
+			Name: "with limits",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				LimitCPU:        "500m",
+				LimitMemory:     "512Mi",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with invalid limits",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				LimitCPU:        "50%",
+				LimitMemory:     "NaN",
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidValue("50%", cli.LimitCPUFlagName),
+				cli.ErrInvalidValue("NaN", cli.LimitMemoryFlagName),
+			),
+		},
+		{
+			Name: "with min scale",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				MinScale:        int32(1),
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with max scale",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				MaxScale:        int32(1),
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with negative min scale",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				MinScale:        int32(-1),
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue(int32(-1), cli.MinScaleFlagName),
+		},
+		{
+			Name: "with min scale greater than max scale",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				MinScale:        int32(2),
+				MaxScale:        int32(1),
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue(int32(1), cli.MaxScaleFlagName),
+		},
+		{
+			Name: "with target-port",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				TargetPort:      8888,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with invalid target-port",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				TargetPort:      -1,
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("-1", cli.TargetPortFlagName),
+		},
+		{
+			Name: "with tail",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				Tail:            true,
+				WaitTimeout:     "10m",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with tail, missing timeout",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				Tail:            true,
+			},
+			ExpectFieldErrors: cli.ErrMissingField(cli.WaitTimeoutFlagName),
+		},
+		{
+			Name: "with tail, invalid timeout",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				Tail:            true,
+				WaitTimeout:     "d",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("d", cli.WaitTimeoutFlagName),
+		},
+		{
+			Name: "dry run",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				DryRun:          true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "dry run, tail",
+			Options: &commands.DeployerUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				Tail:            true,
+				WaitTimeout:     "10m",
+				DryRun:          true,
+			},
+			ExpectFieldErrors: cli.ErrMultipleOneOf(cli.DryRunFlagName, cli.TailFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestDeployerUpdateCommand(t *testing.T) {
+	defaultNamespace := "default"
+	deployerName := "my-deployer"
+	image := "registry.example.com/repo@sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
+	applicationRef := "my-app"
+	functionRef := "my-func"
+	zero := int32(0)
+	ten := int32(10)
+
+	givenDeployer := &knativev1alpha1.Deployer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      deployerName,
+		},
+		Spec: knativev1alpha1.DeployerSpec{
+			Build: &knativev1alpha1.Build{
+				ApplicationRef: applicationRef,
+			},
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Env: []corev1.EnvVar{
+								{Name: "MY_VAR", Value: "my-value"},
+							},
+						},
+					},
+				},
+			},
+			IngressPolicy: knativev1alpha1.IngressPolicyClusterLocal,
+		},
+	}
+	withImage := givenDeployer.DeepCopy()
+	withImage.Spec.Build = nil
+	withImage.Spec.Template.Spec.Containers[0].Image = image
+	withFunctionRef := givenDeployer.DeepCopy()
+	withFunctionRef.Spec.Build = &knativev1alpha1.Build{
+		FunctionRef: functionRef,
+	}
+	withExternalIngress := givenDeployer.DeepCopy()
+	withExternalIngress.Spec.IngressPolicy = knativev1alpha1.IngressPolicyExternal
+	withEnv := givenDeployer.DeepCopy()
+	withEnv.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{
+		{Name: "MY_VAR", Value: "my-new-value"},
+		{
+			Name: "MY_VAR_FROM_SECRET",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "my-secret",
+					},
+					Key: "my-key",
+				},
+			},
+		},
+	}
+	withLimitsAndPort := givenDeployer.DeepCopy()
+	withLimitsAndPort.Spec.Template.Spec.Containers[0].Resources.Limits = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("128Mi"),
+	}
+	withLimitsAndPort.Spec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Protocol: corev1.ProtocolTCP, ContainerPort: 8888},
+	}
+
+	withScale := givenDeployer.DeepCopy()
+	withScale.Spec.Scale.Min = &zero
+	withScale.Spec.Scale.Max = &ten
+
+	two := int32(2)
+	withMinScale := givenDeployer.DeepCopy()
+	withMinScale.Spec.Scale.Min = &two
+	withMinScaleAndMaxScale := withMinScale.DeepCopy()
+	withMinScaleAndMaxScale.Spec.Scale.Max = &ten
+	withZeroMinScale := givenDeployer.DeepCopy()
+	withZeroMinScale.Spec.Scale.Min = &zero
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name: "update image",
+			Args: []string{deployerName, cli.ImageFlagName, image},
+			GivenObjects: []runtime.Object{
+				givenDeployer.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				withImage.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated deployer "my-deployer"
+`,
+		},
+		{
+			Name: "update function ref",
+			Args: []string{deployerName, cli.FunctionRefFlagName, functionRef},
+			GivenObjects: []runtime.Object{
+				withImage.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				withFunctionRef.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated deployer "my-deployer"
+`,
+		},
+		{
+			Name: "update ingress policy",
+			Args: []string{deployerName, cli.IngressPolicyFlagName, string(knativev1alpha1.IngressPolicyExternal)},
+			GivenObjects: []runtime.Object{
+				givenDeployer.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				withExternalIngress.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated deployer "my-deployer"
+`,
+		},
+		{
+			Name: "update env and env-from",
+			Args: []string{deployerName, cli.EnvFlagName, "MY_VAR=my-new-value", cli.EnvFromFlagName, "MY_VAR_FROM_SECRET=secretKeyRef:my-secret:my-key"},
+			GivenObjects: []runtime.Object{
+				givenDeployer.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				withEnv.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated deployer "my-deployer"
+`,
+		},
+		{
+			Name: "update limits and target-port",
+			Args: []string{deployerName, cli.LimitCPUFlagName, "100m", cli.LimitMemoryFlagName, "128Mi", cli.TargetPortFlagName, "8888"},
+			GivenObjects: []runtime.Object{
+				givenDeployer.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				withLimitsAndPort.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated deployer "my-deployer"
+`,
+		},
+		{
+			Name: "update scale",
+			Args: []string{deployerName, cli.MinScaleFlagName, "0", cli.MaxScaleFlagName, "10"},
+			GivenObjects: []runtime.Object{
+				givenDeployer.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				withScale.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated deployer "my-deployer"
+`,
+		},
+		{
+			Name: "update min scale to zero",
+			Args: []string{deployerName, cli.MinScaleFlagName, "0"},
+			GivenObjects: []runtime.Object{
+				withMinScale.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				withZeroMinScale.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated deployer "my-deployer"
+`,
+		},
+		{
+			Name: "update max scale below existing min scale",
+			Args: []string{deployerName, cli.MaxScaleFlagName, "1"},
+			GivenObjects: []runtime.Object{
+				withMinScale.DeepCopy(),
+			},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected, actual := `min scale 2 is greater than max scale 1 for deployer "my-deployer"`, fmt.Sprintf("%v", err); expected != actual {
+					t.Errorf("expected error %q, actually %q", expected, actual)
+				}
+			},
+		},
+		{
+			Name: "update min scale above existing max scale",
+			Args: []string{deployerName, cli.MinScaleFlagName, "11"},
+			GivenObjects: []runtime.Object{
+				withScale.DeepCopy(),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "update max scale above existing min scale",
+			Args: []string{deployerName, cli.MaxScaleFlagName, "10"},
+			GivenObjects: []runtime.Object{
+				withMinScale.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				withMinScaleAndMaxScale.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated deployer "my-deployer"
+`,
+		},
+		{
+			Name: "invalid max scale",
+			Args: []string{deployerName, cli.MaxScaleFlagName, "0"},
+			GivenObjects: []runtime.Object{
+				givenDeployer.DeepCopy(),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "dry run",
+			Args: []string{deployerName, cli.ImageFlagName, image, cli.DryRunFlagName},
+			GivenObjects: []runtime.Object{
+				givenDeployer.DeepCopy(),
+			},
+			ExpectOutput: `
+---
+apiVersion: knative.projectriff.io/v1alpha1
+kind: Deployer
+metadata:
+  creationTimestamp: null
+  name: my-deployer
+  namespace: default
+spec:
+  ingressPolicy: ClusterLocal
+  scale: {}
+  template:
+    metadata:
+      creationTimestamp: null
+    spec:
+      containers:
+      - env:
+        - name: MY_VAR
+          value: my-value
+        image: registry.example.com/repo@sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef
+        name: ""
+        resources: {}
+status: {}
+
+Updated deployer "my-deployer"
+`,
+		},
+		{
+			Name: "not found",
+			Args: []string{deployerName, cli.ImageFlagName, image},
+			ExpectOutput: `
+Deployer "default/my-deployer" not found
+`,
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if actual := err; !errors.Is(err, cli.SilentError) {
+					t.Errorf("expected error to be silent, actual %#v", actual)
+				}
+			},
+		},
+		{
+			Name: "get error",
+			Args: []string{deployerName, cli.ImageFlagName, image},
+			GivenObjects: []runtime.Object{
+				givenDeployer.DeepCopy(),
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "deployers"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "error during update",
+			Args: []string{deployerName, cli.ImageFlagName, image},
+			GivenObjects: []runtime.Object{
+				givenDeployer.DeepCopy(),
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("update", "deployers"),
+			},
+			ExpectUpdates: []runtime.Object{
+				withImage.DeepCopy(),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "tail logs",
+			Args: []string{deployerName, cli.ImageFlagName, image, cli.TailFlagName},
+			GivenObjects: []runtime.Object{
+				givenDeployer.DeepCopy(),
+			},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				lw := cachetesting.NewFakeControllerSource()
+				ctx = k8s.WithListerWatcher(ctx, lw)
+
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("KnativeDeployerLogs", mock.Anything, withImage.DeepCopy(), riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fmt.Fprintf(c.Stdout, "...log output...\n")
+				})
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*cachetesting.FakeControllerSource); ok {
+					lw.Shutdown()
+				}
+
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			ExpectUpdates: []runtime.Object{
+				withImage.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated deployer "my-deployer"
+...log output...
+Deployer "my-deployer" is ready
+`,
+		},
+		{
+			Name: "tail timeout",
+			Args: []string{deployerName, cli.ImageFlagName, image, cli.TailFlagName, cli.WaitTimeoutFlagName, "5ms"},
+			GivenObjects: []runtime.Object{
+				givenDeployer.DeepCopy(),
+			},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				lw := cachetesting.NewFakeControllerSource()
+				ctx = k8s.WithListerWatcher(ctx, lw)
+
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("KnativeDeployerLogs", mock.Anything, withImage.DeepCopy(), riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(k8s.ErrWaitTimeout).Run(func(args mock.Arguments) {
+					ctx := args[0].(context.Context)
+					fmt.Fprintf(c.Stdout, "...log output...\n")
+					// wait for context to be cancelled
+					<-ctx.Done()
+				})
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*cachetesting.FakeControllerSource); ok {
+					lw.Shutdown()
+				}
+
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			ExpectUpdates: []runtime.Object{
+				withImage.DeepCopy(),
+			},
+			ExpectOutput: `
+Updated deployer "my-deployer"
+...log output...
+Timeout after "5ms" waiting for "my-deployer" to become ready
+To view status run: riff knative deployer list --namespace default
+To continue watching logs run: riff knative deployer tail my-deployer --namespace default
+`,
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if actual := err; !errors.Is(err, cli.SilentError) {
+					t.Errorf("expected error to be silent, actual %#v", actual)
+				}
+			},
+		},
+		{
+			Name: "tail error",
+			Args: []string{deployerName, cli.ImageFlagName, image, cli.TailFlagName},
+			GivenObjects: []runtime.Object{
+				givenDeployer.DeepCopy(),
+			},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				lw := cachetesting.NewFakeControllerSource()
+				ctx = k8s.WithListerWatcher(ctx, lw)
+
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("KnativeDeployerLogs", mock.Anything, withImage.DeepCopy(), riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*cachetesting.FakeControllerSource); ok {
+					lw.Shutdown()
+				}
+
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			ExpectUpdates: []runtime.Object{
+				withImage.DeepCopy(),
+			},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewDeployerUpdateCommand)
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifests
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// serverMetadataFields are set by the api server and have no meaning in a
+// manifest.
+var serverMetadataFields = []string{
+	"creationTimestamp",
+	"deletionGracePeriodSeconds",
+	"deletionTimestamp",
+	"generation",
+	"managedFields",
+	"resourceVersion",
+	"selfLink",
+	"uid",
+}
+
+// Clean converts the resource into a manifest with the kind's apiVersion and
+// kind, and without status or server managed metadata.
+func (k *Kind) Clean(obj runtime.Object) (map[string]interface{}, error) {
+	manifest, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	apiVersion, kind := k.GroupVersionKind.ToAPIVersionAndKind()
+	manifest["apiVersion"] = apiVersion
+	manifest["kind"] = kind
+	delete(manifest, "status")
+	if metadata, ok := manifest["metadata"].(map[string]interface{}); ok {
+		for _, field := range serverMetadataFields {
+			delete(metadata, field)
+		}
+	}
+
+	pruneNulls(manifest)
+
+	return manifest, nil
+}
+
+// pruneNulls removes null values, like the creationTimestamp of a pod
+// template, that are left behind by zero value fields.
+func pruneNulls(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if item == nil {
+				delete(v, key)
+				continue
+			}
+			pruneNulls(item)
+		}
+	case []interface{}:
+		for _, item := range v {
+			pruneNulls(item)
+		}
+	}
+}
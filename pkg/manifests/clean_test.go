@@ -0,0 +1,98 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifests_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/manifests"
+	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestKind_Clean(t *testing.T) {
+	deployer := &corev1alpha1.Deployer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "my-namespace",
+			Name:              "my-deployer",
+			Labels:            map[string]string{"app": "my-app"},
+			UID:               types.UID("3d6a4c5e-a1b2-4c3d-9e8f-0a1b2c3d4e5f"),
+			ResourceVersion:   "42",
+			Generation:        2,
+			SelfLink:          "/apis/core.projectriff.io/v1alpha1/namespaces/my-namespace/deployers/my-deployer",
+			CreationTimestamp: metav1.Now(),
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "riff"},
+			},
+		},
+		Spec: corev1alpha1.DeployerSpec{
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Image: "registry.example.com/repo"},
+					},
+				},
+			},
+		},
+		Status: corev1alpha1.DeployerStatus{
+			LatestImage: "registry.example.com/repo@sha256:deadbeef",
+		},
+	}
+
+	kind := manifests.KindFor(&corev1alpha1.Deployer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "core.projectriff.io/v1alpha1",
+			Kind:       "Deployer",
+		},
+	})
+	actual, err := kind.Clean(deployer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"apiVersion": "core.projectriff.io/v1alpha1",
+		"kind":       "Deployer",
+		"metadata": map[string]interface{}{
+			"namespace": "my-namespace",
+			"name":      "my-deployer",
+			"labels": map[string]interface{}{
+				"app": "my-app",
+			},
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{},
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":      "",
+							"image":     "registry.example.com/repo",
+							"resources": map[string]interface{}{},
+						},
+					},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("Clean() (-expected, +actual): %s", diff)
+	}
+}
@@ -0,0 +1,347 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifests
+
+import (
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
+	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+// Ranks order kinds so that a resource is applied after the resources it
+// depends on.
+const (
+	GatewayRank = iota
+	StreamRank
+	BuildRank
+	RuntimeRank
+)
+
+// Kind describes a riff resource that can be read from a manifest and applied
+// to the cluster with the matching typed client.
+type Kind struct {
+	GroupVersionKind schema.GroupVersionKind
+	// Runtime that must be enabled to use the kind, empty for build resources
+	Runtime string
+	// Rank of the kind in the dependency order
+	Rank int
+	// Name of the kind on the command line
+	Name string
+	// Resource is the plural name of the kind in the REST API
+	Resource string
+
+	Get    func(c k8s.Client, namespace, name string) (runtime.Object, error)
+	List   func(c k8s.Client, namespace string) (runtime.Object, error)
+	Create func(c k8s.Client, obj runtime.Object) (runtime.Object, error)
+	Update func(c k8s.Client, obj runtime.Object) (runtime.Object, error)
+
+	RESTClient func(c k8s.Client) rest.Interface
+}
+
+// Kinds are all of the riff resources supported by manifests.
+var Kinds = []Kind{
+	{
+		GroupVersionKind: streamv1alpha1.SchemeGroupVersion.WithKind("InMemoryGateway"),
+		Runtime:          cli.StreamingRuntime,
+		Rank:             GatewayRank,
+		Name:             "inmemory-gateway",
+		Resource:         "inmemorygateways",
+		Get: func(c k8s.Client, namespace, name string) (runtime.Object, error) {
+			return c.StreamingRuntime().InMemoryGateways(namespace).Get(name, metav1.GetOptions{})
+		},
+		List: func(c k8s.Client, namespace string) (runtime.Object, error) {
+			return c.StreamingRuntime().InMemoryGateways(namespace).List(metav1.ListOptions{})
+		},
+		Create: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			gateway := obj.(*streamv1alpha1.InMemoryGateway)
+			return c.StreamingRuntime().InMemoryGateways(gateway.Namespace).Create(gateway)
+		},
+		Update: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			gateway := obj.(*streamv1alpha1.InMemoryGateway)
+			return c.StreamingRuntime().InMemoryGateways(gateway.Namespace).Update(gateway)
+		},
+		RESTClient: func(c k8s.Client) rest.Interface {
+			return c.StreamingRuntime().RESTClient()
+		},
+	},
+	{
+		GroupVersionKind: streamv1alpha1.SchemeGroupVersion.WithKind("KafkaGateway"),
+		Runtime:          cli.StreamingRuntime,
+		Rank:             GatewayRank,
+		Name:             "kafka-gateway",
+		Resource:         "kafkagateways",
+		Get: func(c k8s.Client, namespace, name string) (runtime.Object, error) {
+			return c.StreamingRuntime().KafkaGateways(namespace).Get(name, metav1.GetOptions{})
+		},
+		List: func(c k8s.Client, namespace string) (runtime.Object, error) {
+			return c.StreamingRuntime().KafkaGateways(namespace).List(metav1.ListOptions{})
+		},
+		Create: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			gateway := obj.(*streamv1alpha1.KafkaGateway)
+			return c.StreamingRuntime().KafkaGateways(gateway.Namespace).Create(gateway)
+		},
+		Update: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			gateway := obj.(*streamv1alpha1.KafkaGateway)
+			return c.StreamingRuntime().KafkaGateways(gateway.Namespace).Update(gateway)
+		},
+		RESTClient: func(c k8s.Client) rest.Interface {
+			return c.StreamingRuntime().RESTClient()
+		},
+	},
+	{
+		GroupVersionKind: streamv1alpha1.SchemeGroupVersion.WithKind("PulsarGateway"),
+		Runtime:          cli.StreamingRuntime,
+		Rank:             GatewayRank,
+		Name:             "pulsar-gateway",
+		Resource:         "pulsargateways",
+		Get: func(c k8s.Client, namespace, name string) (runtime.Object, error) {
+			return c.StreamingRuntime().PulsarGateways(namespace).Get(name, metav1.GetOptions{})
+		},
+		List: func(c k8s.Client, namespace string) (runtime.Object, error) {
+			return c.StreamingRuntime().PulsarGateways(namespace).List(metav1.ListOptions{})
+		},
+		Create: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			gateway := obj.(*streamv1alpha1.PulsarGateway)
+			return c.StreamingRuntime().PulsarGateways(gateway.Namespace).Create(gateway)
+		},
+		Update: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			gateway := obj.(*streamv1alpha1.PulsarGateway)
+			return c.StreamingRuntime().PulsarGateways(gateway.Namespace).Update(gateway)
+		},
+		RESTClient: func(c k8s.Client) rest.Interface {
+			return c.StreamingRuntime().RESTClient()
+		},
+	},
+	{
+		GroupVersionKind: streamv1alpha1.SchemeGroupVersion.WithKind("Stream"),
+		Runtime:          cli.StreamingRuntime,
+		Rank:             StreamRank,
+		Name:             "stream",
+		Resource:         "streams",
+		Get: func(c k8s.Client, namespace, name string) (runtime.Object, error) {
+			return c.StreamingRuntime().Streams(namespace).Get(name, metav1.GetOptions{})
+		},
+		List: func(c k8s.Client, namespace string) (runtime.Object, error) {
+			return c.StreamingRuntime().Streams(namespace).List(metav1.ListOptions{})
+		},
+		Create: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			stream := obj.(*streamv1alpha1.Stream)
+			return c.StreamingRuntime().Streams(stream.Namespace).Create(stream)
+		},
+		Update: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			stream := obj.(*streamv1alpha1.Stream)
+			return c.StreamingRuntime().Streams(stream.Namespace).Update(stream)
+		},
+		RESTClient: func(c k8s.Client) rest.Interface {
+			return c.StreamingRuntime().RESTClient()
+		},
+	},
+	{
+		GroupVersionKind: buildv1alpha1.SchemeGroupVersion.WithKind("Application"),
+		Rank:             BuildRank,
+		Name:             "application",
+		Resource:         "applications",
+		Get: func(c k8s.Client, namespace, name string) (runtime.Object, error) {
+			return c.Build().Applications(namespace).Get(name, metav1.GetOptions{})
+		},
+		List: func(c k8s.Client, namespace string) (runtime.Object, error) {
+			return c.Build().Applications(namespace).List(metav1.ListOptions{})
+		},
+		Create: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			application := obj.(*buildv1alpha1.Application)
+			return c.Build().Applications(application.Namespace).Create(application)
+		},
+		Update: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			application := obj.(*buildv1alpha1.Application)
+			return c.Build().Applications(application.Namespace).Update(application)
+		},
+		RESTClient: func(c k8s.Client) rest.Interface {
+			return c.Build().RESTClient()
+		},
+	},
+	{
+		GroupVersionKind: buildv1alpha1.SchemeGroupVersion.WithKind("Container"),
+		Rank:             BuildRank,
+		Name:             "container",
+		Resource:         "containers",
+		Get: func(c k8s.Client, namespace, name string) (runtime.Object, error) {
+			return c.Build().Containers(namespace).Get(name, metav1.GetOptions{})
+		},
+		List: func(c k8s.Client, namespace string) (runtime.Object, error) {
+			return c.Build().Containers(namespace).List(metav1.ListOptions{})
+		},
+		Create: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			container := obj.(*buildv1alpha1.Container)
+			return c.Build().Containers(container.Namespace).Create(container)
+		},
+		Update: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			container := obj.(*buildv1alpha1.Container)
+			return c.Build().Containers(container.Namespace).Update(container)
+		},
+		RESTClient: func(c k8s.Client) rest.Interface {
+			return c.Build().RESTClient()
+		},
+	},
+	{
+		GroupVersionKind: buildv1alpha1.SchemeGroupVersion.WithKind("Function"),
+		Rank:             BuildRank,
+		Name:             "function",
+		Resource:         "functions",
+		Get: func(c k8s.Client, namespace, name string) (runtime.Object, error) {
+			return c.Build().Functions(namespace).Get(name, metav1.GetOptions{})
+		},
+		List: func(c k8s.Client, namespace string) (runtime.Object, error) {
+			return c.Build().Functions(namespace).List(metav1.ListOptions{})
+		},
+		Create: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			function := obj.(*buildv1alpha1.Function)
+			return c.Build().Functions(function.Namespace).Create(function)
+		},
+		Update: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			function := obj.(*buildv1alpha1.Function)
+			return c.Build().Functions(function.Namespace).Update(function)
+		},
+		RESTClient: func(c k8s.Client) rest.Interface {
+			return c.Build().RESTClient()
+		},
+	},
+	{
+		GroupVersionKind: corev1alpha1.SchemeGroupVersion.WithKind("Deployer"),
+		Runtime:          cli.CoreRuntime,
+		Rank:             RuntimeRank,
+		Name:             "core-deployer",
+		Resource:         "deployers",
+		Get: func(c k8s.Client, namespace, name string) (runtime.Object, error) {
+			return c.CoreRuntime().Deployers(namespace).Get(name, metav1.GetOptions{})
+		},
+		List: func(c k8s.Client, namespace string) (runtime.Object, error) {
+			return c.CoreRuntime().Deployers(namespace).List(metav1.ListOptions{})
+		},
+		Create: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			deployer := obj.(*corev1alpha1.Deployer)
+			return c.CoreRuntime().Deployers(deployer.Namespace).Create(deployer)
+		},
+		Update: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			deployer := obj.(*corev1alpha1.Deployer)
+			return c.CoreRuntime().Deployers(deployer.Namespace).Update(deployer)
+		},
+		RESTClient: func(c k8s.Client) rest.Interface {
+			return c.CoreRuntime().RESTClient()
+		},
+	},
+	{
+		GroupVersionKind: knativev1alpha1.SchemeGroupVersion.WithKind("Adapter"),
+		Runtime:          cli.KnativeRuntime,
+		Rank:             RuntimeRank,
+		Name:             "adapter",
+		Resource:         "adapters",
+		Get: func(c k8s.Client, namespace, name string) (runtime.Object, error) {
+			return c.KnativeRuntime().Adapters(namespace).Get(name, metav1.GetOptions{})
+		},
+		List: func(c k8s.Client, namespace string) (runtime.Object, error) {
+			return c.KnativeRuntime().Adapters(namespace).List(metav1.ListOptions{})
+		},
+		Create: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			adapter := obj.(*knativev1alpha1.Adapter)
+			return c.KnativeRuntime().Adapters(adapter.Namespace).Create(adapter)
+		},
+		Update: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			adapter := obj.(*knativev1alpha1.Adapter)
+			return c.KnativeRuntime().Adapters(adapter.Namespace).Update(adapter)
+		},
+		RESTClient: func(c k8s.Client) rest.Interface {
+			return c.KnativeRuntime().RESTClient()
+		},
+	},
+	{
+		GroupVersionKind: knativev1alpha1.SchemeGroupVersion.WithKind("Deployer"),
+		Runtime:          cli.KnativeRuntime,
+		Rank:             RuntimeRank,
+		Name:             "knative-deployer",
+		Resource:         "deployers",
+		Get: func(c k8s.Client, namespace, name string) (runtime.Object, error) {
+			return c.KnativeRuntime().Deployers(namespace).Get(name, metav1.GetOptions{})
+		},
+		List: func(c k8s.Client, namespace string) (runtime.Object, error) {
+			return c.KnativeRuntime().Deployers(namespace).List(metav1.ListOptions{})
+		},
+		Create: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			deployer := obj.(*knativev1alpha1.Deployer)
+			return c.KnativeRuntime().Deployers(deployer.Namespace).Create(deployer)
+		},
+		Update: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			deployer := obj.(*knativev1alpha1.Deployer)
+			return c.KnativeRuntime().Deployers(deployer.Namespace).Update(deployer)
+		},
+		RESTClient: func(c k8s.Client) rest.Interface {
+			return c.KnativeRuntime().RESTClient()
+		},
+	},
+	{
+		GroupVersionKind: streamv1alpha1.SchemeGroupVersion.WithKind("Processor"),
+		Runtime:          cli.StreamingRuntime,
+		Rank:             RuntimeRank,
+		Name:             "processor",
+		Resource:         "processors",
+		Get: func(c k8s.Client, namespace, name string) (runtime.Object, error) {
+			return c.StreamingRuntime().Processors(namespace).Get(name, metav1.GetOptions{})
+		},
+		List: func(c k8s.Client, namespace string) (runtime.Object, error) {
+			return c.StreamingRuntime().Processors(namespace).List(metav1.ListOptions{})
+		},
+		Create: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			processor := obj.(*streamv1alpha1.Processor)
+			return c.StreamingRuntime().Processors(processor.Namespace).Create(processor)
+		},
+		Update: func(c k8s.Client, obj runtime.Object) (runtime.Object, error) {
+			processor := obj.(*streamv1alpha1.Processor)
+			return c.StreamingRuntime().Processors(processor.Namespace).Update(processor)
+		},
+		RESTClient: func(c k8s.Client) rest.Interface {
+			return c.StreamingRuntime().RESTClient()
+		},
+	},
+}
+
+// KindFor returns the kind of the object, or nil if the kind is not supported.
+func KindFor(obj runtime.Object) *Kind {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	for i := range Kinds {
+		if Kinds[i].GroupVersionKind == gvk {
+			return &Kinds[i]
+		}
+	}
+	return nil
+}
+
+// KindForName returns the kind with the command line name, or nil if the kind
+// is not supported.
+func KindForName(name string) *Kind {
+	for i := range Kinds {
+		if Kinds[i].Name == name {
+			return &Kinds[i]
+		}
+	}
+	return nil
+}
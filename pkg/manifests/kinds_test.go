@@ -0,0 +1,123 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifests_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/manifests"
+	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
+	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestKindForName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{{
+		name:     "core-deployer",
+		expected: corev1alpha1.SchemeGroupVersion.WithKind("Deployer").String(),
+	}, {
+		name:     "knative-deployer",
+		expected: knativev1alpha1.SchemeGroupVersion.WithKind("Deployer").String(),
+	}, {
+		name: "deployer",
+	}, {
+		name: "Application",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kind := manifests.KindForName(test.name)
+			actual := ""
+			if kind != nil {
+				actual = kind.GroupVersionKind.String()
+			}
+			if expected, actual := test.expected, actual; expected != actual {
+				t.Errorf("expected kind %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
+
+func TestKinds(t *testing.T) {
+	names := map[string]bool{}
+	for _, kind := range manifests.Kinds {
+		if kind.Name == "" || kind.Resource == "" || kind.RESTClient == nil {
+			t.Errorf("kind %s is missing a name, resource or rest client", kind.GroupVersionKind)
+		}
+		if names[kind.Name] {
+			t.Errorf("kind name %q is not unique", kind.Name)
+		}
+		names[kind.Name] = true
+	}
+}
+
+func TestKindsResource(t *testing.T) {
+	// the fake clientsets use different plurals than the api server, record
+	// the path requested by the typed clients instead
+	var m sync.Mutex
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.Lock()
+		defer m.Unlock()
+		path = r.URL.Path
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	kubeConfig, err := ioutil.TempFile("", "kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(kubeConfig.Name())
+	fmt.Fprintf(kubeConfig, `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`, server.URL)
+	kubeConfig.Close()
+	c := k8s.NewClient(kubeConfig.Name(), clientcmd.ConfigOverrides{})
+
+	for _, kind := range manifests.Kinds {
+		t.Run(kind.Name, func(t *testing.T) {
+			// the resource is not found, only the request matters
+			_, _ = kind.Get(c, "default", "my-resource")
+			m.Lock()
+			defer m.Unlock()
+			gv := kind.GroupVersionKind.GroupVersion()
+			if expected, actual := fmt.Sprintf("/apis/%s/namespaces/default/%s/my-resource", gv, kind.Resource), path; expected != actual {
+				t.Errorf("expected request to %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifests
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/projectriff/system/pkg/client/clientset/versioned/scheme"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// StdinPath is the path that reads manifests from stdin.
+const StdinPath = "-"
+
+var manifestExtensions = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+	".json": true,
+}
+
+// ReadPath reads the riff resources from a manifest file, every manifest file
+// within a directory, or stdin.
+func ReadPath(path string, stdin io.Reader) ([]runtime.Object, error) {
+	if path == StdinPath {
+		return Read(stdin)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return readFile(path)
+	}
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	objs := []runtime.Object{}
+	for _, file := range files {
+		if file.IsDir() || !manifestExtensions[filepath.Ext(file.Name())] {
+			continue
+		}
+		fileObjs, err := readFile(filepath.Join(path, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, fileObjs...)
+	}
+	return objs, nil
+}
+
+func readFile(path string) ([]runtime.Object, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	objs, err := Read(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return objs, nil
+}
+
+// Read decodes the riff resources from a stream of YAML documents separated
+// by "---", or JSON objects. Empty documents are skipped.
+func Read(r io.Reader) ([]runtime.Object, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	decoder := scheme.Codecs.UniversalDeserializer()
+
+	objs := []runtime.Object{}
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, gvk, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(*gvk)
+		if KindFor(obj) == nil {
+			return nil, fmt.Errorf("unsupported kind %q", gvk.String())
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// Sort orders the resources so that dependencies come first: gateways, then
+// streams, then builds, then runtimes. Resources of the same rank keep their
+// relative order.
+func Sort(objs []runtime.Object) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		return KindFor(objs[i]).Rank < KindFor(objs[j]).Rank
+	})
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifests_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/manifests"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestReadPath(t *testing.T) {
+	gateway := &streamv1alpha1.InMemoryGateway{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "streaming.projectriff.io/v1alpha1",
+			Kind:       "InMemoryGateway",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-gateway",
+		},
+	}
+	stream := &streamv1alpha1.Stream{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "streaming.projectriff.io/v1alpha1",
+			Kind:       "Stream",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-namespace",
+			Name:      "my-stream",
+		},
+		Spec: streamv1alpha1.StreamSpec{
+			Gateway:     corev1.LocalObjectReference{Name: "my-gateway"},
+			ContentType: "application/json",
+		},
+	}
+	function := &buildv1alpha1.Function{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "build.projectriff.io/v1alpha1",
+			Kind:       "Function",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-function",
+		},
+		Spec: buildv1alpha1.FunctionSpec{
+			Image: "registry.example.com/repo:tag",
+			Source: &buildv1alpha1.Source{
+				Git: &buildv1alpha1.Git{
+					URL:      "https://example.com/repo.git",
+					Revision: "master",
+				},
+			},
+		},
+	}
+	processor := &streamv1alpha1.Processor{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "streaming.projectriff.io/v1alpha1",
+			Kind:       "Processor",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-processor",
+		},
+		Spec: streamv1alpha1.ProcessorSpec{
+			Build: &streamv1alpha1.Build{
+				FunctionRef: "my-function",
+			},
+			Inputs: []streamv1alpha1.InputStreamBinding{
+				{Stream: "my-stream"},
+			},
+		},
+	}
+	application := &buildv1alpha1.Application{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "build.projectriff.io/v1alpha1",
+			Kind:       "Application",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-application",
+		},
+		Spec: buildv1alpha1.ApplicationSpec{
+			Image: "registry.example.com/repo:tag",
+		},
+	}
+	deployer := &corev1alpha1.Deployer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "core.projectriff.io/v1alpha1",
+			Kind:       "Deployer",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-deployer",
+		},
+		Spec: corev1alpha1.DeployerSpec{
+			Build: &corev1alpha1.Build{
+				ApplicationRef: "my-application",
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		stdin       string
+		expected    []runtime.Object
+		shouldError bool
+	}{{
+		name:     "file",
+		path:     "testdata/riff.yaml",
+		expected: []runtime.Object{processor, function, stream, gateway},
+	}, {
+		name:     "directory",
+		path:     "testdata/dir",
+		expected: []runtime.Object{application, deployer},
+	}, {
+		name: "stdin",
+		path: manifests.StdinPath,
+		stdin: `
+---
+apiVersion: core.projectriff.io/v1alpha1
+kind: Deployer
+metadata:
+  name: my-deployer
+spec:
+  build:
+    applicationRef: my-application
+---
+`,
+		expected: []runtime.Object{deployer},
+	}, {
+		name:     "empty stdin",
+		path:     manifests.StdinPath,
+		expected: []runtime.Object{},
+	}, {
+		name:        "missing file",
+		path:        "testdata/missing.yaml",
+		shouldError: true,
+	}, {
+		name:        "unsupported kind",
+		path:        "testdata/unsupported.yaml",
+		shouldError: true,
+	}, {
+		name:        "malformed",
+		path:        manifests.StdinPath,
+		stdin:       "kind: [",
+		shouldError: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := manifests.ReadPath(test.path, strings.NewReader(test.stdin))
+			if (err != nil) != test.shouldError {
+				t.Fatalf("ReadPath() error = %v, shouldError %v", err, test.shouldError)
+			}
+			if test.shouldError {
+				return
+			}
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("ReadPath() = (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	gateway := &streamv1alpha1.KafkaGateway{
+		TypeMeta: metav1.TypeMeta{APIVersion: "streaming.projectriff.io/v1alpha1", Kind: "KafkaGateway"},
+	}
+	stream := &streamv1alpha1.Stream{
+		TypeMeta: metav1.TypeMeta{APIVersion: "streaming.projectriff.io/v1alpha1", Kind: "Stream"},
+	}
+	function := &buildv1alpha1.Function{
+		TypeMeta: metav1.TypeMeta{APIVersion: "build.projectriff.io/v1alpha1", Kind: "Function"},
+	}
+	container := &buildv1alpha1.Container{
+		TypeMeta: metav1.TypeMeta{APIVersion: "build.projectriff.io/v1alpha1", Kind: "Container"},
+	}
+	processor := &streamv1alpha1.Processor{
+		TypeMeta: metav1.TypeMeta{APIVersion: "streaming.projectriff.io/v1alpha1", Kind: "Processor"},
+	}
+	deployer := &corev1alpha1.Deployer{
+		TypeMeta: metav1.TypeMeta{APIVersion: "core.projectriff.io/v1alpha1", Kind: "Deployer"},
+	}
+
+	objs := []runtime.Object{deployer, processor, function, stream, container, gateway}
+	manifests.Sort(objs)
+
+	expected := []runtime.Object{gateway, stream, function, container, deployer, processor}
+	if diff := cmp.Diff(expected, objs); diff != "" {
+		t.Errorf("Sort() = (-expected, +actual): %s", diff)
+	}
+}
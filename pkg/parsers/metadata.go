@@ -0,0 +1,43 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"strings"
+)
+
+func KeyValue(str string) (string, string) {
+	parts := strings.SplitN(str, "=", 2)
+
+	return parts[0], parts[1]
+}
+
+// KeyValues parses key value pairs into a map, like labels or annotations. The
+// map is nil when there are no pairs.
+func KeyValues(strs []string) map[string]string {
+	if len(strs) == 0 {
+		return nil
+	}
+
+	values := map[string]string{}
+	for _, str := range strs {
+		key, value := KeyValue(str)
+		values[key] = value
+	}
+
+	return values
+}
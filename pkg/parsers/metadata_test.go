@@ -0,0 +1,100 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/parsers"
+)
+
+func TestKeyValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		expectedKey   string
+		expectedValue string
+		value         string
+	}{{
+		name:          "valid",
+		value:         "team=payments",
+		expectedKey:   "team",
+		expectedValue: "payments",
+	}, {
+		name:          "prefixed key",
+		value:         "example.com/team=payments",
+		expectedKey:   "example.com/team",
+		expectedValue: "payments",
+	}, {
+		name:          "empty value",
+		value:         "team=",
+		expectedKey:   "team",
+		expectedValue: "",
+	}, {
+		name:          "value with equals",
+		value:         "description=a=b",
+		expectedKey:   "description",
+		expectedValue: "a=b",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, value := parsers.KeyValue(test.value)
+			if diff := cmp.Diff(test.expectedKey, key); diff != "" {
+				t.Errorf("%s() key = (-expected, +actual): %s", test.name, diff)
+			}
+			if diff := cmp.Diff(test.expectedValue, value); diff != "" {
+				t.Errorf("%s() value = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestKeyValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected map[string]string
+		values   []string
+	}{{
+		name:     "empty",
+		expected: nil,
+		values:   []string{},
+	}, {
+		name: "multiple",
+		expected: map[string]string{
+			"team":        "payments",
+			"cost-center": "1234",
+		},
+		values: []string{"team=payments", "cost-center=1234"},
+	}, {
+		name: "last value wins",
+		expected: map[string]string{
+			"team": "billing",
+		},
+		values: []string{"team=payments", "team=billing"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := parsers.KeyValues(test.values)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
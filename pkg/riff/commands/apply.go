@@ -0,0 +1,143 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/manifests"
+	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type ApplyOptions struct {
+	Namespace string
+	Filename  string
+}
+
+var (
+	_ cli.Validatable = (*ApplyOptions)(nil)
+	_ cli.Executable  = (*ApplyOptions)(nil)
+)
+
+func (opts *ApplyOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if opts.Namespace == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.NamespaceFlagName))
+	}
+
+	if opts.Filename == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.FilenameFlagName))
+	}
+
+	return errs
+}
+
+func (opts *ApplyOptions) Exec(ctx context.Context, c *cli.Config) error {
+	objs, err := readManifests(c, opts.Filename, opts.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objs {
+		kind := manifests.KindFor(obj)
+		resource := obj.(metav1.Object)
+		name := kind.Name
+
+		existing, err := kind.Get(c, resource.GetNamespace(), resource.GetName())
+		if err != nil {
+			if !apierrs.IsNotFound(err) {
+				return err
+			}
+			if _, err := kind.Create(c, obj); err != nil {
+				return err
+			}
+			c.Successf("Created %s %q\n", name, resource.GetName())
+			continue
+		}
+
+		resource.SetResourceVersion(existing.(metav1.Object).GetResourceVersion())
+		if _, err := kind.Update(c, obj); err != nil {
+			return err
+		}
+		c.Successf("Updated %s %q\n", name, resource.GetName())
+	}
+
+	return nil
+}
+
+// readManifests reads the riff resources at the path in dependency order.
+// Resources without a namespace are placed in the default namespace.
+func readManifests(c *cli.Config, path, namespace string) ([]runtime.Object, error) {
+	objs, err := manifests.ReadPath(path, c.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		kind := manifests.KindFor(obj)
+		if kind.Runtime != "" && !c.Runtimes[kind.Runtime] {
+			return nil, fmt.Errorf("unable to read %s, the %s runtime is not enabled", kind.GroupVersionKind.String(), kind.Runtime)
+		}
+		if resource := obj.(metav1.Object); resource.GetNamespace() == "" {
+			resource.SetNamespace(namespace)
+		}
+	}
+	manifests.Sort(objs)
+	return objs, nil
+}
+
+func NewApplyCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ApplyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "create or update resources from manifests",
+		Long: strings.TrimSpace(`
+Create or update ` + c.Name + ` resources from YAML or JSON manifests. The output of
+any create command run with ` + cli.DryRunFlagName + ` is a valid manifest.
+
+Manifests are read from a file, every .yaml, .yml and .json file within a
+directory, or stdin when the file is "-". A file may contain multiple resources
+separated by "---". Resources without a namespace are applied to the namespace
+of this command.
+
+Resources are applied in dependency order: gateways, then streams, then builds
+(applications, containers and functions), then runtimes (deployers, adapters and
+processors). A resource that does not exist is created, an existing resource is
+replaced by the manifest.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s apply %s riff.yaml", c.Name, cli.FilenameFlagName),
+			fmt.Sprintf("%s apply %s ./manifests/", c.Name, cli.FilenameFlagName),
+			fmt.Sprintf("%s function create my-func %s https://example.com/my-func.git %s | %s apply %s -", c.Name, cli.GitRepoFlagName, cli.DryRunFlagName, c.Name, cli.FilenameFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().StringVarP(&opts.Filename, cli.StripDash(cli.FilenameFlagName), "f", "", "manifest `file` or directory to apply, or - for stdin")
+	_ = cmd.MarkFlagFilename(cli.StripDash(cli.FilenameFlagName), "yaml", "yml", "json")
+
+	return cmd
+}
@@ -0,0 +1,319 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
+	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestApplyOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "valid",
+			Options: &commands.ApplyOptions{
+				Namespace: "default",
+				Filename:  "riff.yaml",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name:    "invalid",
+			Options: &commands.ApplyOptions{},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrMissingField(cli.NamespaceFlagName),
+				cli.ErrMissingField(cli.FilenameFlagName),
+			),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestApplyCommand(t *testing.T) {
+	defaultNamespace := "default"
+	manifest := "testdata/apply.yaml"
+
+	gateway := &streamv1alpha1.InMemoryGateway{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "streaming.projectriff.io/v1alpha1",
+			Kind:       "InMemoryGateway",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      "my-gateway",
+		},
+	}
+	stream := &streamv1alpha1.Stream{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "streaming.projectriff.io/v1alpha1",
+			Kind:       "Stream",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      "my-stream",
+		},
+		Spec: streamv1alpha1.StreamSpec{
+			Gateway:     corev1.LocalObjectReference{Name: "my-gateway"},
+			ContentType: "application/json",
+		},
+	}
+	function := &buildv1alpha1.Function{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "build.projectriff.io/v1alpha1",
+			Kind:       "Function",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      "my-function",
+		},
+		Spec: buildv1alpha1.FunctionSpec{
+			Image: "registry.example.com/repo:tag",
+			Source: &buildv1alpha1.Source{
+				Git: &buildv1alpha1.Git{
+					URL:      "https://example.com/repo.git",
+					Revision: "master",
+				},
+			},
+		},
+	}
+	deployer := &corev1alpha1.Deployer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "core.projectriff.io/v1alpha1",
+			Kind:       "Deployer",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      "my-deployer",
+		},
+		Spec: corev1alpha1.DeployerSpec{
+			Build: &corev1alpha1.Build{
+				FunctionRef: "my-function",
+			},
+			IngressPolicy: corev1alpha1.IngressPolicyClusterLocal,
+		},
+	}
+	existingFunction := &buildv1alpha1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       defaultNamespace,
+			Name:            "my-function",
+			ResourceVersion: "42",
+		},
+		Spec: buildv1alpha1.FunctionSpec{
+			Image: "registry.example.com/repo:tag",
+			Source: &buildv1alpha1.Source{
+				Git: &buildv1alpha1.Git{
+					URL:      "https://example.com/repo.git",
+					Revision: "v0.0.1",
+				},
+			},
+		},
+	}
+	updatedFunction := function.DeepCopy()
+	updatedFunction.ResourceVersion = "42"
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "missing file",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name:     "create resources",
+			Args:     []string{cli.FilenameFlagName, manifest},
+			Runtimes: &[]string{cli.CoreRuntime, cli.StreamingRuntime},
+			ExpectCreates: []runtime.Object{
+				gateway.DeepCopy(),
+				stream.DeepCopy(),
+				function.DeepCopy(),
+				deployer.DeepCopy(),
+			},
+			ExpectOutput: `
+Created inmemory-gateway "my-gateway"
+Created stream "my-stream"
+Created function "my-function"
+Created core-deployer "my-deployer"
+`,
+		},
+		{
+			Name:     "update existing resources",
+			Args:     []string{cli.FilenameFlagName, manifest},
+			Runtimes: &[]string{cli.CoreRuntime, cli.StreamingRuntime},
+			GivenObjects: []runtime.Object{
+				existingFunction.DeepCopy(),
+			},
+			ExpectCreates: []runtime.Object{
+				gateway.DeepCopy(),
+				stream.DeepCopy(),
+				deployer.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				updatedFunction.DeepCopy(),
+			},
+			ExpectOutput: `
+Created inmemory-gateway "my-gateway"
+Created stream "my-stream"
+Updated function "my-function"
+Created core-deployer "my-deployer"
+`,
+		},
+		{
+			Name:  "stdin",
+			Args:  []string{cli.FilenameFlagName, "-", cli.NamespaceFlagName, "my-namespace"},
+			Stdin: []byte("apiVersion: build.projectriff.io/v1alpha1\nkind: Container\nmetadata:\n  name: my-container\nspec:\n  image: registry.example.com/repo\n"),
+			ExpectCreates: []runtime.Object{
+				&buildv1alpha1.Container{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "build.projectriff.io/v1alpha1",
+						Kind:       "Container",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-namespace",
+						Name:      "my-container",
+					},
+					Spec: buildv1alpha1.ContainerSpec{
+						Image: "registry.example.com/repo",
+					},
+				},
+			},
+			ExpectOutput: `
+Created container "my-container"
+`,
+		},
+		{
+			Name:     "deployers of the same name",
+			Args:     []string{cli.FilenameFlagName, "-"},
+			Runtimes: &[]string{cli.CoreRuntime, cli.KnativeRuntime},
+			Stdin:    []byte("apiVersion: core.projectriff.io/v1alpha1\nkind: Deployer\nmetadata:\n  name: my-deployer\nspec:\n  build:\n    functionRef: my-function\n---\napiVersion: knative.projectriff.io/v1alpha1\nkind: Deployer\nmetadata:\n  name: my-deployer\nspec:\n  build:\n    functionRef: my-function\n"),
+			ExpectCreates: []runtime.Object{
+				&corev1alpha1.Deployer{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "core.projectriff.io/v1alpha1",
+						Kind:       "Deployer",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "my-deployer",
+					},
+					Spec: corev1alpha1.DeployerSpec{
+						Build: &corev1alpha1.Build{
+							FunctionRef: "my-function",
+						},
+					},
+				},
+				&knativev1alpha1.Deployer{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "knative.projectriff.io/v1alpha1",
+						Kind:       "Deployer",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "my-deployer",
+					},
+					Spec: knativev1alpha1.DeployerSpec{
+						Build: &knativev1alpha1.Build{
+							FunctionRef: "my-function",
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created core-deployer "my-deployer"
+Created knative-deployer "my-deployer"
+`,
+		},
+		{
+			Name:        "runtime not enabled",
+			Args:        []string{cli.FilenameFlagName, manifest},
+			Runtimes:    &[]string{cli.CoreRuntime},
+			ShouldError: true,
+		},
+		{
+			Name:        "file not found",
+			Args:        []string{cli.FilenameFlagName, "testdata/missing.yaml"},
+			ShouldError: true,
+		},
+		{
+			Name:     "get error",
+			Args:     []string{cli.FilenameFlagName, manifest},
+			Runtimes: &[]string{cli.CoreRuntime, cli.StreamingRuntime},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "functions"),
+			},
+			ExpectCreates: []runtime.Object{
+				gateway.DeepCopy(),
+				stream.DeepCopy(),
+			},
+			ExpectOutput: `
+Created inmemory-gateway "my-gateway"
+Created stream "my-stream"
+`,
+			ShouldError: true,
+		},
+		{
+			Name:     "create error",
+			Args:     []string{cli.FilenameFlagName, manifest},
+			Runtimes: &[]string{cli.CoreRuntime, cli.StreamingRuntime},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("create", "streams"),
+			},
+			ExpectCreates: []runtime.Object{
+				gateway.DeepCopy(),
+				stream.DeepCopy(),
+			},
+			ExpectOutput: `
+Created inmemory-gateway "my-gateway"
+`,
+			ShouldError: true,
+		},
+		{
+			Name:     "update error",
+			Args:     []string{cli.FilenameFlagName, manifest},
+			Runtimes: &[]string{cli.CoreRuntime, cli.StreamingRuntime},
+			GivenObjects: []runtime.Object{
+				existingFunction.DeepCopy(),
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("update", "functions"),
+			},
+			ExpectCreates: []runtime.Object{
+				gateway.DeepCopy(),
+				stream.DeepCopy(),
+			},
+			ExpectUpdates: []runtime.Object{
+				updatedFunction.DeepCopy(),
+			},
+			ExpectOutput: `
+Created inmemory-gateway "my-gateway"
+Created stream "my-stream"
+`,
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewApplyCommand)
+}
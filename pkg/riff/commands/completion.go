@@ -60,7 +60,7 @@ func (opts *CompletionOptions) Exec(ctx context.Context, c *cli.Config) error {
 
 func (opts *CompletionOptions) MakeBashCompletion(c *cli.Config) string {
 	return `
-__` + c.Name + `_override_flag_list=(--kubeconfig --namespace -n)
+__` + c.Name + `_override_flag_list=(--kubeconfig --context --cluster --user --namespace -n)
 __` + c.Name + `_override_flags()
 {
 	local ${__` + c.Name + `_override_flag_list[*]##*-} two_word_of of var
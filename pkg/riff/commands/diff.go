@@ -0,0 +1,178 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/manifests"
+	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type DiffOptions struct {
+	Namespace string
+	Filename  string
+}
+
+var (
+	_ cli.Validatable = (*DiffOptions)(nil)
+	_ cli.Executable  = (*DiffOptions)(nil)
+)
+
+func (opts *DiffOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if opts.Namespace == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.NamespaceFlagName))
+	}
+
+	if opts.Filename == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.FilenameFlagName))
+	}
+
+	return errs
+}
+
+func (opts *DiffOptions) Exec(ctx context.Context, c *cli.Config) error {
+	objs, err := readManifests(c, opts.Filename, opts.Namespace)
+	if err != nil {
+		return err
+	}
+
+	drift := 0
+	for _, obj := range objs {
+		kind := manifests.KindFor(obj)
+		resource := obj.(metav1.Object)
+		path := fmt.Sprintf("%s/%s/%s", kind.Name, resource.GetNamespace(), resource.GetName())
+
+		live, err := kind.Get(c, resource.GetNamespace(), resource.GetName())
+		if err != nil {
+			if !apierrs.IsNotFound(err) {
+				return err
+			}
+			live = nil
+		}
+
+		liveLines, err := diffLines(kind, live)
+		if err != nil {
+			return err
+		}
+		localLines, err := diffLines(kind, obj)
+		if err != nil {
+			return err
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        liveLines,
+			FromFile: "live/" + path,
+			B:        localLines,
+			ToFile:   "local/" + path,
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		if diff == "" {
+			continue
+		}
+
+		drift++
+		for _, line := range difflib.SplitLines(strings.TrimSuffix(diff, "\n")) {
+			switch {
+			case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+				c.Printf("%s", line)
+			case strings.HasPrefix(line, "-"):
+				c.Printf("%s", cli.Serrorf("%s", line))
+			case strings.HasPrefix(line, "+"):
+				c.Printf("%s", cli.Ssuccessf("%s", line))
+			case strings.HasPrefix(line, "@@"):
+				c.Printf("%s", cli.Sinfof("%s", line))
+			default:
+				c.Printf("%s", line)
+			}
+		}
+	}
+
+	if drift != 0 {
+		// the diff is the output, exit with an error so drift can be detected
+		return cli.SilenceError(fmt.Errorf("%d resource(s) differ from the cluster", drift))
+	}
+
+	return nil
+}
+
+// diffLines renders the defaulted resource as YAML lines without server
+// managed fields. A nil resource has no lines.
+func diffLines(kind *manifests.Kind, obj runtime.Object) ([]string, error) {
+	if obj == nil {
+		return []string{}, nil
+	}
+	obj = obj.DeepCopyObject()
+	if defaultable, ok := obj.(interface{ Default() }); ok {
+		defaultable.Default()
+	}
+	manifest, err := kind.Clean(obj)
+	if err != nil {
+		return nil, err
+	}
+	b, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	return difflib.SplitLines(strings.TrimSuffix(string(b), "\n")), nil
+}
+
+func NewDiffCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &DiffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "compare manifests with resources on the cluster",
+		Long: strings.TrimSpace(`
+Compare ` + c.Name + ` resources from YAML or JSON manifests with the resources on the
+cluster. Manifests are read the same way as the apply command.
+
+Status and metadata managed by the cluster, like the uid and resourceVersion,
+are ignored. Each resource that differs is printed as a unified diff from the
+live resource to the manifest. A resource that does not exist on the cluster is
+shown as entirely added.
+
+The command exits with a non-zero status when any resource differs, so it can be
+used to detect drift.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s diff %s riff.yaml", c.Name, cli.FilenameFlagName),
+			fmt.Sprintf("%s diff %s ./manifests/", c.Name, cli.FilenameFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().StringVarP(&opts.Filename, cli.StripDash(cli.FilenameFlagName), "f", "", "manifest `file` or directory to compare, or - for stdin")
+	_ = cmd.MarkFlagFilename(cli.StripDash(cli.FilenameFlagName), "yaml", "yml", "json")
+
+	return cmd
+}
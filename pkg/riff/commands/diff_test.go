@@ -0,0 +1,226 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestDiffOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "valid",
+			Options: &commands.DiffOptions{
+				Namespace: "default",
+				Filename:  "riff.yaml",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name:    "invalid",
+			Options: &commands.DiffOptions{},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrMissingField(cli.NamespaceFlagName),
+				cli.ErrMissingField(cli.FilenameFlagName),
+			),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestDiffCommand(t *testing.T) {
+	defaultNamespace := "default"
+	manifest := "testdata/apply.yaml"
+
+	gateway := &streamv1alpha1.InMemoryGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       defaultNamespace,
+			Name:            "my-gateway",
+			UID:             types.UID("3d6a4c5e-a1b2-4c3d-9e8f-0a1b2c3d4e5f"),
+			ResourceVersion: "1",
+			Generation:      1,
+		},
+		Status: streamv1alpha1.InMemoryGatewayStatus{
+			GatewayImage: "projectriff/inmemory-gateway",
+		},
+	}
+	stream := &streamv1alpha1.Stream{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       defaultNamespace,
+			Name:            "my-stream",
+			ResourceVersion: "2",
+		},
+		Spec: streamv1alpha1.StreamSpec{
+			Gateway:     corev1.LocalObjectReference{Name: "my-gateway"},
+			ContentType: "application/json",
+		},
+	}
+	function := &buildv1alpha1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       defaultNamespace,
+			Name:            "my-function",
+			ResourceVersion: "3",
+		},
+		Spec: buildv1alpha1.FunctionSpec{
+			Image: "registry.example.com/repo:tag",
+			Source: &buildv1alpha1.Source{
+				Git: &buildv1alpha1.Git{
+					URL:      "https://example.com/repo.git",
+					Revision: "master",
+				},
+			},
+		},
+		Status: buildv1alpha1.FunctionStatus{
+			BuildStatus: buildv1alpha1.BuildStatus{
+				LatestImage: "registry.example.com/repo@sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+			},
+		},
+	}
+	deployer := &corev1alpha1.Deployer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       defaultNamespace,
+			Name:            "my-deployer",
+			ResourceVersion: "4",
+		},
+		Spec: corev1alpha1.DeployerSpec{
+			Build: &corev1alpha1.Build{
+				FunctionRef: "my-function",
+			},
+			IngressPolicy: corev1alpha1.IngressPolicyClusterLocal,
+		},
+	}
+	driftedFunction := function.DeepCopy()
+	driftedFunction.Spec.Source.Git.Revision = "v0.0.1"
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "missing file",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name:     "no drift",
+			Args:     []string{cli.FilenameFlagName, manifest},
+			Runtimes: &[]string{cli.CoreRuntime, cli.StreamingRuntime},
+			GivenObjects: []runtime.Object{
+				gateway.DeepCopy(),
+				stream.DeepCopy(),
+				function.DeepCopy(),
+				deployer.DeepCopy(),
+			},
+		},
+		{
+			Name:     "changed resource",
+			Args:     []string{cli.FilenameFlagName, manifest},
+			Runtimes: &[]string{cli.CoreRuntime, cli.StreamingRuntime},
+			GivenObjects: []runtime.Object{
+				gateway.DeepCopy(),
+				stream.DeepCopy(),
+				driftedFunction.DeepCopy(),
+				deployer.DeepCopy(),
+			},
+			ExpectOutput: `
+--- live/function/default/my-function
++++ local/function/default/my-function
+@@ -9,5 +9,5 @@
+   image: registry.example.com/repo:tag
+   source:
+     git:
+-      revision: v0.0.1
++      revision: master
+       url: https://example.com/repo.git
+`,
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if !errors.Is(err, cli.SilentError) {
+					t.Errorf("expected error to be silent, actual %#v", err)
+				}
+			},
+		},
+		{
+			Name:     "missing resource",
+			Args:     []string{cli.FilenameFlagName, manifest},
+			Runtimes: &[]string{cli.CoreRuntime, cli.StreamingRuntime},
+			GivenObjects: []runtime.Object{
+				gateway.DeepCopy(),
+				stream.DeepCopy(),
+				function.DeepCopy(),
+			},
+			ExpectOutput: `
+--- live/core-deployer/default/my-deployer
++++ local/core-deployer/default/my-deployer
+@@ -0,0 +1,19 @@
++apiVersion: core.projectriff.io/v1alpha1
++kind: Deployer
++metadata:
++  name: my-deployer
++  namespace: default
++spec:
++  build:
++    functionRef: my-function
++  ingressPolicy: ClusterLocal
++  template:
++    metadata: {}
++    spec:
++      containers:
++      - name: handler
++        ports:
++        - containerPort: 8080
++          name: http
++          protocol: TCP
++        resources: {}
+`,
+			ShouldError: true,
+		},
+		{
+			Name:        "runtime not enabled",
+			Args:        []string{cli.FilenameFlagName, manifest},
+			Runtimes:    &[]string{cli.CoreRuntime},
+			ShouldError: true,
+		},
+		{
+			Name:     "get error",
+			Args:     []string{cli.FilenameFlagName, manifest},
+			Runtimes: &[]string{cli.CoreRuntime, cli.StreamingRuntime},
+			GivenObjects: []runtime.Object{
+				gateway.DeepCopy(),
+				stream.DeepCopy(),
+				function.DeepCopy(),
+				deployer.DeepCopy(),
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "functions"),
+			},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewDiffCommand)
+}
@@ -18,21 +18,47 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/projectriff/system/pkg/apis"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
+	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
 	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const riffSystemNamespace = "riff-system"
 
+// riffGroupVersions are the versions of the riff API groups this CLI is
+// compiled against
+var riffGroupVersions = map[string]string{
+	buildv1alpha1.SchemeGroupVersion.Group:   buildv1alpha1.SchemeGroupVersion.Version,
+	corev1alpha1.SchemeGroupVersion.Group:    corev1alpha1.SchemeGroupVersion.Version,
+	knativev1alpha1.SchemeGroupVersion.Group: knativev1alpha1.SchemeGroupVersion.Version,
+	streamv1alpha1.SchemeGroupVersion.Group:  streamv1alpha1.SchemeGroupVersion.Version,
+}
+
 type DoctorOptions struct {
-	Namespace string
+	Namespace   string
+	Install     bool
+	SuggestRBAC bool
+	Output      string
+	Quiet       bool
 }
 
 var (
@@ -47,6 +73,19 @@ func (opts *DoctorOptions) Validate(ctx context.Context) cli.FieldErrors {
 		errs = errs.Also(cli.ErrMissingField(cli.NamespaceFlagName))
 	}
 
+	if opts.Output != "" && opts.Output != printers.OutputFormatJSON && opts.Output != printers.OutputFormatYAML {
+		errs = errs.Also(cli.ErrInvalidValue(opts.Output, cli.OutputFlagName))
+	}
+	if opts.Output != "" && opts.Quiet {
+		errs = errs.Also(cli.ErrMultipleOneOf(cli.OutputFlagName, cli.QuietFlagName))
+	}
+	if opts.SuggestRBAC && opts.Output != "" {
+		errs = errs.Also(cli.ErrMultipleOneOf(cli.OutputFlagName, cli.SuggestRBACFlagName))
+	}
+	if opts.SuggestRBAC && opts.Quiet {
+		errs = errs.Also(cli.ErrMultipleOneOf(cli.QuietFlagName, cli.SuggestRBACFlagName))
+	}
+
 	return errs
 }
 
@@ -55,7 +94,7 @@ func (opts *DoctorOptions) Exec(ctx context.Context, c *cli.Config) error {
 		opts.Namespace,
 		riffSystemNamespace,
 	}
-	err := opts.checkNamespaces(c, riffNamespaces)
+	namespaceChecks, err := opts.checkNamespaces(c, riffNamespaces)
 	if err != nil {
 		return err
 	}
@@ -93,11 +132,52 @@ func (opts *DoctorOptions) Exec(ctx context.Context, c *cli.Config) error {
 		)
 	}
 
-	err = opts.checkAccess(c, accessChecks)
+	err = accessChecks.ResolveStatus(c)
+	if err != nil {
+		return err
+	}
+
+	dependencyChecks, err := opts.checkDependencies(c)
+	if err != nil {
+		return err
+	}
+
+	var installChecks doctorResourceChecks
+	if opts.Install {
+		installChecks, err = opts.checkInstall(c, accessChecks)
+		if err != nil {
+			return err
+		}
+	}
+
+	healthy := namespaceChecks.IsHealthy() && accessChecks.IsHealthy() && dependencyChecks.IsHealthy() && installChecks.IsHealthy()
+	switch {
+	case opts.Quiet:
+		// the exit code is the only output
+	case opts.SuggestRBAC:
+		err = opts.printSuggestedRBAC(c, accessChecks)
+	case opts.Output != "":
+		err = opts.printReport(c, healthy, namespaceChecks, accessChecks, dependencyChecks, installChecks)
+	default:
+		opts.printNamespaces(c, namespaceChecks)
+		c.Printf("\n")
+		opts.printAccess(c, accessChecks)
+		if len(dependencyChecks) != 0 {
+			c.Printf("\n")
+			opts.printResources(c, dependencyChecks)
+		}
+		if opts.Install {
+			c.Printf("\n")
+			opts.printResources(c, installChecks)
+		}
+	}
 	if err != nil {
 		return err
 	}
 
+	if !healthy {
+		return cli.SilenceError(fmt.Errorf("doctor found problems in namespace %q", opts.Namespace))
+	}
 	return nil
 }
 
@@ -112,68 +192,592 @@ func NewDoctorCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 The doctor checks that the current user has permission to access ` + c.Name + `, and ` + c.Name + `
 related, resources in a namespace.
 
-The doctor is not a tool for monitoring the health of the cluster or the ` + c.Name + `
-install.
+With ` + cli.InstallFlagName + `, the doctor also checks the ` + c.Name + ` install: the custom resource
+definitions are served at the version this CLI uses, the controllers in the
+` + riffSystemNamespace + ` namespace are available, the builders are defined, and the
+namespace has a riff-build ConfigMap and credentials. The doctor is not a tool
+for monitoring the health of the cluster.
+
+The dependencies of the enabled runtimes are always checked: the Knative
+runtime needs the Knative Serving custom resource definitions, and streams for
+the streaming runtime need a ready gateway in the namespace.
+
+Use ` + cli.AsFlagName + ` and ` + cli.AsGroupFlagName + ` to check the permissions of another user.
+
+The command exits with a non-zero status when a namespace is missing or access
+to a resource is not allowed. Use ` + cli.OutputFlagName + ` to print the results as json or
+yaml, or ` + cli.QuietFlagName + ` to only report the result with the exit status.
+
+With ` + cli.SuggestRBACFlagName + `, a Role and RoleBinding is printed for each namespace
+where access is not allowed, instead of the results. The Role grants exactly the
+verbs that were not allowed and is bound to the user from ` + cli.AsFlagName + `, or the
+current user. A cluster admin can review and apply the resources with kubectl.
 `),
-		Example: "riff doctor",
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s doctor", c.Name),
+			fmt.Sprintf("%s doctor %s my-user %s my-group", c.Name, cli.AsFlagName, cli.AsGroupFlagName),
+			fmt.Sprintf("%s doctor %s", c.Name, cli.InstallFlagName),
+			fmt.Sprintf("%s doctor %s %s my-user | kubectl apply -f -", c.Name, cli.SuggestRBACFlagName, cli.AsFlagName),
+			fmt.Sprintf("%s doctor %s json", c.Name, cli.OutputFlagName),
+			fmt.Sprintf("%s doctor %s", c.Name, cli.QuietFlagName),
+		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().BoolVar(&opts.Install, cli.StripDash(cli.InstallFlagName), false, "also check the health of the "+c.Name+" install")
+	cmd.Flags().BoolVar(&opts.SuggestRBAC, cli.StripDash(cli.SuggestRBACFlagName), false, "print a Role and RoleBinding that grant the access that is not allowed")
+	cmd.Flags().StringVarP(&opts.Output, cli.StripDash(cli.OutputFlagName), "o", "", fmt.Sprintf("output `format`, one of: %s, %s (defaults to a table)", printers.OutputFormatJSON, printers.OutputFormatYAML))
+	cmd.Flags().BoolVarP(&opts.Quiet, cli.StripDash(cli.QuietFlagName), "q", false, "suppress output, the exit status reports the result")
 
 	return cmd
 }
 
-func (*DoctorOptions) checkNamespaces(c *cli.Config, requiredNamespaces []string) error {
-	printer := printers.GetNewTabWriter(c.Stdout)
-	defer printer.Flush()
-	fmt.Fprintf(printer, "NAMESPACE\tSTATUS\n")
+func (*DoctorOptions) checkNamespaces(c *cli.Config, requiredNamespaces []string) (doctorNamespaceChecks, error) {
+	checks := doctorNamespaceChecks{}
 	for _, namespace := range requiredNamespaces {
-		status := cli.Ssuccessf("ok")
+		check := &doctorNamespaceCheck{Name: namespace, Exists: true}
 		_, err := c.Core().Namespaces().Get(namespace, metav1.GetOptions{})
 		if err != nil {
 			if !errors.IsNotFound(err) {
-				return err
+				return nil, err
 			}
-			status = cli.Serrorf("missing")
+			check.Exists = false
 		}
-		fmt.Fprintf(printer, "%s\t%s\n", namespace, status)
+		checks = append(checks, check)
 	}
-	return nil
+	return checks, nil
 }
 
-func (*DoctorOptions) checkAccess(c *cli.Config, accessChecks doctorAccessChecks) error {
-	err := accessChecks.ResolveStatus(c)
-	if err != nil {
-		return err
+func (*DoctorOptions) printNamespaces(c *cli.Config, namespaceChecks doctorNamespaceChecks) {
+	printer := printers.GetNewTabWriter(c.Stdout)
+	defer printer.Flush()
+	fmt.Fprintf(printer, "NAMESPACE\tSTATUS\n")
+	for _, check := range namespaceChecks {
+		status := cli.Ssuccessf(check.Status())
+		if !check.Exists {
+			status = cli.Serrorf(check.Status())
+		}
+		fmt.Fprintf(printer, "%s\t%s\n", check.Name, status)
 	}
-	c.Printf("\n")
+}
+
+func (*DoctorOptions) printAccess(c *cli.Config, accessChecks doctorAccessChecks) {
 	printer := printers.GetNewTabWriter(c.Stdout)
 	defer printer.Flush()
 	fmt.Fprintf(printer, "RESOURCE\tNAMESPACE\tNAME\tREAD\tWRITE\n")
 	for _, check := range accessChecks {
-		resource := check.Attributes.Resource
-		if check.Attributes.Group != "core" {
-			resource = fmt.Sprintf("%s.%s", resource, check.Attributes.Group)
+		fmt.Fprintf(printer, "%s\t%s\t%s\t%s\t%s\n", check.Resource(), check.Attributes.Namespace, check.Name(), check.ReadStatus.String(), check.WriteStatus.String())
+	}
+}
+
+func (*DoctorOptions) printResources(c *cli.Config, resourceChecks doctorResourceChecks) {
+	printer := printers.GetNewTabWriter(c.Stdout)
+	defer printer.Flush()
+	fmt.Fprintf(printer, "RESOURCE\tNAMESPACE\tNAME\tSTATUS\n")
+	for _, check := range resourceChecks {
+		fmt.Fprintf(printer, "%s\t%s\t%s\t%s\n", check.Resource, check.Namespace, check.Name, check.String())
+	}
+}
+
+// printSuggestedRBAC prints a Role and RoleBinding for each namespace with
+// verbs that are not allowed, granting the verbs to the user
+func (*DoctorOptions) printSuggestedRBAC(c *cli.Config, accessChecks doctorAccessChecks) error {
+	namespaces := []string{}
+	rules := map[string][]rbacv1.PolicyRule{}
+	for _, check := range accessChecks {
+		if len(check.FailedVerbs) == 0 {
+			continue
+		}
+		namespace := check.Attributes.Namespace
+		if _, ok := rules[namespace]; !ok {
+			namespaces = append(namespaces, namespace)
+		}
+		rule := rbacv1.PolicyRule{
+			APIGroups: []string{check.Attributes.Group},
+			Resources: []string{check.Attributes.Resource},
+			Verbs:     check.FailedVerbs,
+		}
+		if rule.APIGroups[0] == "core" {
+			rule.APIGroups[0] = ""
 		}
 		if check.Attributes.Subresource != "" {
-			resource = fmt.Sprintf("%s/%s", resource, check.Attributes.Subresource)
+			rule.Resources[0] = fmt.Sprintf("%s/%s", check.Attributes.Resource, check.Attributes.Subresource)
+		}
+		if check.Attributes.Name != "" {
+			rule.ResourceNames = []string{check.Attributes.Name}
 		}
-		name := check.Attributes.Name
-		if name == "" {
-			name = "*"
+		rules[namespace] = append(rules[namespace], rule)
+	}
+	if len(namespaces) == 0 {
+		c.Einfof("No access to grant\n")
+		return nil
+	}
+
+	subject, err := doctorRBACSubject(c)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-doctor-%s", c.Name, rbacNameCleaner.ReplaceAllString(strings.ToLower(subject.Name), "-"))
+
+	resources := []runtime.Object{}
+	for _, namespace := range namespaces {
+		resources = append(resources,
+			&rbacv1.Role{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+				Rules:      rules[namespace],
+			},
+			&rbacv1.RoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+				Subjects:   []rbacv1.Subject{subject},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+			},
+		)
+	}
+	for _, resource := range resources {
+		b, err := yaml.Marshal(resource)
+		if err != nil {
+			return err
 		}
-		fmt.Fprintf(printer, "%s\t%s\t%s\t%s\t%s\n", resource, check.Attributes.Namespace, name, check.ReadStatus.String(), check.WriteStatus.String())
+		c.Printf("---\n%s", b)
 	}
 	return nil
 }
 
+// rbacNameCleaner matches the characters of a user name that are not valid in
+// a resource name
+var rbacNameCleaner = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// doctorRBACSubject is the impersonated user, or the user from the kube config
+func doctorRBACSubject(c *cli.Config) (rbacv1.Subject, error) {
+	restConfig := c.KubeRestConfig()
+	user := restConfig.Impersonate.UserName
+	if user == "" {
+		user = restConfig.Username
+	}
+	if user == "" {
+		return rbacv1.Subject{}, fmt.Errorf("unable to determine the current user, set %s to the user to grant access to", cli.AsFlagName)
+	}
+	if strings.HasPrefix(user, serviceAccountUserPrefix) {
+		// service account users are system:serviceaccount:<namespace>:<name>
+		parts := strings.Split(strings.TrimPrefix(user, serviceAccountUserPrefix), ":")
+		if len(parts) == 2 {
+			return rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: parts[0], Name: parts[1]}, nil
+		}
+	}
+	return rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: user}, nil
+}
+
+const serviceAccountUserPrefix = "system:serviceaccount:"
+
+// doctorReport is the structured form of the doctor output
+type doctorReport struct {
+	Healthy      bool                    `json:"healthy"`
+	Namespaces   []doctorNamespaceReport `json:"namespaces"`
+	Access       []doctorAccessReport    `json:"access"`
+	Dependencies []doctorResourceReport  `json:"dependencies,omitempty"`
+	Install      []doctorResourceReport  `json:"install,omitempty"`
+}
+
+type doctorNamespaceReport struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type doctorAccessReport struct {
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Read      string `json:"read"`
+	Write     string `json:"write"`
+}
+
+type doctorResourceReport struct {
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+func (opts *DoctorOptions) printReport(c *cli.Config, healthy bool, namespaceChecks doctorNamespaceChecks, accessChecks doctorAccessChecks, dependencyChecks, installChecks doctorResourceChecks) error {
+	report := doctorReport{
+		Healthy:      healthy,
+		Namespaces:   make([]doctorNamespaceReport, len(namespaceChecks)),
+		Access:       make([]doctorAccessReport, len(accessChecks)),
+		Dependencies: dependencyChecks.Report(),
+		Install:      installChecks.Report(),
+	}
+	for i, check := range namespaceChecks {
+		report.Namespaces[i] = doctorNamespaceReport{
+			Name:   check.Name,
+			Status: check.Status(),
+		}
+	}
+	for i, check := range accessChecks {
+		report.Access[i] = doctorAccessReport{
+			Resource:  check.Resource(),
+			Namespace: check.Attributes.Namespace,
+			Name:      check.Name(),
+			Read:      check.ReadStatus.Name(),
+			Write:     check.WriteStatus.Name(),
+		}
+	}
+
+	var data []byte
+	var err error
+	if opts.Output == printers.OutputFormatJSON {
+		data, err = json.MarshalIndent(report, "", "    ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(report)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = c.Stdout.Write(data)
+	return err
+}
+
+type doctorNamespaceCheck struct {
+	Name   string
+	Exists bool
+}
+
+func (check *doctorNamespaceCheck) Status() string {
+	if check.Exists {
+		return "ok"
+	}
+	return "missing"
+}
+
+type doctorNamespaceChecks []*doctorNamespaceCheck
+
+func (checks doctorNamespaceChecks) IsHealthy() bool {
+	for _, check := range checks {
+		if !check.Exists {
+			return false
+		}
+	}
+	return true
+}
+
+// checkInstall checks the custom resource definitions of the access checks,
+// the controllers for the enabled runtimes and the build configuration
+func (opts *DoctorOptions) checkInstall(c *cli.Config, accessChecks doctorAccessChecks) (doctorResourceChecks, error) {
+	checks := doctorResourceChecks{}
+
+	for _, accessCheck := range accessChecks {
+		version, ok := riffGroupVersions[accessCheck.Attributes.Group]
+		if !ok {
+			continue
+		}
+		check, err := opts.checkCustomResourceDefinition(c, fmt.Sprintf("%s.%s", accessCheck.Attributes.Resource, accessCheck.Attributes.Group), version)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+
+	controllers := []string{"riff-build-controller-manager"}
+	for _, runtime := range []string{cli.CoreRuntime, cli.StreamingRuntime, cli.KnativeRuntime} {
+		if c.Runtimes[runtime] {
+			controllers = append(controllers, fmt.Sprintf("riff-%s-controller-manager", runtime))
+		}
+	}
+	for _, controller := range controllers {
+		check, err := opts.checkDeployment(c, riffSystemNamespace, controller)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+
+	check, err := opts.checkConfigMap(c, riffSystemNamespace, "builders", "riff-function", "riff-application")
+	if err != nil {
+		return nil, err
+	}
+	checks = append(checks, check)
+
+	check, err = opts.checkConfigMap(c, opts.Namespace, "riff-build")
+	if err != nil {
+		return nil, err
+	}
+	checks = append(checks, check)
+
+	check, err = opts.checkCredentials(c, opts.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	checks = append(checks, check)
+
+	return checks, nil
+}
+
+// checkDependencies checks the resources the enabled runtimes depend on
+func (opts *DoctorOptions) checkDependencies(c *cli.Config) (doctorResourceChecks, error) {
+	checks := doctorResourceChecks{}
+
+	if c.Runtimes[cli.KnativeRuntime] {
+		for _, name := range []string{"configurations.serving.knative.dev", "services.serving.knative.dev"} {
+			check, err := opts.checkCustomResourceDefinition(c, name, "")
+			if err != nil {
+				return nil, err
+			}
+			checks = append(checks, check)
+		}
+	}
+
+	if c.Runtimes[cli.StreamingRuntime] {
+		gatewayChecks, err := opts.checkGateways(c, opts.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, gatewayChecks...)
+	}
+
+	return checks, nil
+}
+
+// checkGateways checks the readiness of each gateway in the namespace. Gateways
+// the user is not able to list are skipped, the access checks report them.
+func (*DoctorOptions) checkGateways(c *cli.Config, namespace string) (doctorResourceChecks, error) {
+	checks := doctorResourceChecks{}
+	skip := func(err error) bool {
+		return errors.IsNotFound(err) || errors.IsForbidden(err)
+	}
+
+	inMemoryGateways, err := c.StreamingRuntime().InMemoryGateways(namespace).List(metav1.ListOptions{})
+	if err != nil && !skip(err) {
+		return nil, err
+	}
+	if err == nil {
+		for _, gateway := range inMemoryGateways.Items {
+			checks = append(checks, readyCheck("inmemorygateways.streaming.projectriff.io", gateway.Namespace, gateway.Name, gateway.Status.GetCondition(streamv1alpha1.InMemoryGatewayConditionReady)))
+		}
+	}
+
+	kafkaGateways, err := c.StreamingRuntime().KafkaGateways(namespace).List(metav1.ListOptions{})
+	if err != nil && !skip(err) {
+		return nil, err
+	}
+	if err == nil {
+		for _, gateway := range kafkaGateways.Items {
+			checks = append(checks, readyCheck("kafkagateways.streaming.projectriff.io", gateway.Namespace, gateway.Name, gateway.Status.GetCondition(streamv1alpha1.KafkaGatewayConditionReady)))
+		}
+	}
+
+	pulsarGateways, err := c.StreamingRuntime().PulsarGateways(namespace).List(metav1.ListOptions{})
+	if err != nil && !skip(err) {
+		return nil, err
+	}
+	if err == nil {
+		for _, gateway := range pulsarGateways.Items {
+			checks = append(checks, readyCheck("pulsargateways.streaming.projectriff.io", gateway.Namespace, gateway.Name, gateway.Status.GetCondition(streamv1alpha1.PulsarGatewayConditionReady)))
+		}
+	}
+
+	sort.Slice(checks, func(i, j int) bool {
+		if checks[i].Resource != checks[j].Resource {
+			return checks[i].Resource < checks[j].Resource
+		}
+		return checks[i].Name < checks[j].Name
+	})
+
+	return checks, nil
+}
+
+// readyCheck reports the status of the resource's ready condition
+func readyCheck(resource, namespace, name string, ready *apis.Condition) *doctorResourceCheck {
+	check := &doctorResourceCheck{Resource: resource, Namespace: namespace, Name: name, Status: doctorResourceUnknown}
+	if ready == nil {
+		return check
+	}
+	check.Message = ready.Message
+	switch ready.Status {
+	case corev1.ConditionTrue:
+		check.Status = doctorResourceOK
+		check.Message = ""
+	case corev1.ConditionFalse:
+		check.Status = doctorResourceNotReady
+	}
+	return check
+}
+
+func (*DoctorOptions) checkCustomResourceDefinition(c *cli.Config, name, version string) (*doctorResourceCheck, error) {
+	check := &doctorResourceCheck{Resource: "customresourcedefinitions", Name: name, Status: doctorResourceOK}
+	crd, err := c.APIExtension().CustomResourceDefinitions().Get(name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		check.Status = doctorResourceMissing
+		return check, nil
+	}
+	if version == "" {
+		// any version is acceptable
+		return check, nil
+	}
+	served := crd.Spec.Version == version
+	if len(crd.Spec.Versions) != 0 {
+		served = false
+		for _, v := range crd.Spec.Versions {
+			if v.Name == version && v.Served {
+				served = true
+			}
+		}
+	}
+	if !served {
+		check.Status = doctorResourceUnserved
+		check.Message = fmt.Sprintf("version %s is not served", version)
+	}
+	return check, nil
+}
+
+func (*DoctorOptions) checkDeployment(c *cli.Config, namespace, name string) (*doctorResourceCheck, error) {
+	check := &doctorResourceCheck{Resource: "deployments.apps", Namespace: namespace, Name: name, Status: doctorResourceOK}
+	deployment, err := c.Apps().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		check.Status = doctorResourceMissing
+		return check, nil
+	}
+	check.Status = doctorResourceUnavailable
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type != appsv1.DeploymentAvailable {
+			continue
+		}
+		if cond.Status == corev1.ConditionTrue {
+			check.Status = doctorResourceOK
+		} else {
+			check.Message = cond.Message
+		}
+	}
+	return check, nil
+}
+
+func (*DoctorOptions) checkConfigMap(c *cli.Config, namespace, name string, keys ...string) (*doctorResourceCheck, error) {
+	check := &doctorResourceCheck{Resource: "configmaps", Namespace: namespace, Name: name, Status: doctorResourceOK}
+	configMap, err := c.Core().ConfigMaps(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		check.Status = doctorResourceMissing
+		return check, nil
+	}
+	missing := []string{}
+	for _, key := range keys {
+		if configMap.Data[key] == "" {
+			missing = append(missing, fmt.Sprintf("%q", key))
+		}
+	}
+	if len(missing) != 0 {
+		check.Status = doctorResourceIncomplete
+		check.Message = fmt.Sprintf("missing %s", strings.Join(missing, ", "))
+	}
+	return check, nil
+}
+
+func (*DoctorOptions) checkCredentials(c *cli.Config, namespace string) (*doctorResourceCheck, error) {
+	check := &doctorResourceCheck{Resource: "secrets", Namespace: namespace, Name: "*", Status: doctorResourceOK}
+	secrets, err := c.Core().Secrets(namespace).List(metav1.ListOptions{LabelSelector: buildv1alpha1.CredentialLabelKey})
+	if err != nil {
+		return nil, err
+	}
+	if len(secrets.Items) == 0 {
+		check.Status = doctorResourceMissing
+		check.Message = "no credentials found"
+	}
+	return check, nil
+}
+
+const (
+	doctorResourceOK          = "ok"
+	doctorResourceMissing     = "missing"
+	doctorResourceUnserved    = "unserved"
+	doctorResourceUnavailable = "unavailable"
+	doctorResourceIncomplete  = "incomplete"
+	doctorResourceNotReady    = "not-ready"
+	doctorResourceUnknown     = "unknown"
+)
+
+type doctorResourceCheck struct {
+	Resource  string
+	Namespace string
+	Name      string
+	Status    string
+	Message   string
+}
+
+func (check *doctorResourceCheck) String() string {
+	if check.Status == doctorResourceOK {
+		return cli.Ssuccessf(check.Status)
+	}
+	if check.Message == "" {
+		return cli.Serrorf("%s", check.Status)
+	}
+	return cli.Serrorf("%s: %s", check.Status, check.Message)
+}
+
+type doctorResourceChecks []*doctorResourceCheck
+
+// Report returns the structured form of the checks, nil when there are no checks
+func (checks doctorResourceChecks) Report() []doctorResourceReport {
+	var reports []doctorResourceReport
+	for _, check := range checks {
+		reports = append(reports, doctorResourceReport{
+			Resource:  check.Resource,
+			Namespace: check.Namespace,
+			Name:      check.Name,
+			Status:    check.Status,
+			Message:   check.Message,
+		})
+	}
+	return reports
+}
+
+func (checks doctorResourceChecks) IsHealthy() bool {
+	for _, check := range checks {
+		if check.Status != doctorResourceOK {
+			return false
+		}
+	}
+	return true
+}
+
 type doctorAccessCheck struct {
 	Attributes  *authv1.ResourceAttributes
 	Verbs       []string
 	ReadStatus  doctorAccessStatus
 	WriteStatus doctorAccessStatus
+	// FailedVerbs are the verbs that are not allowed
+	FailedVerbs []string
+}
+
+// Resource is the resource name qualified by the group and subresource
+func (check *doctorAccessCheck) Resource() string {
+	resource := check.Attributes.Resource
+	if check.Attributes.Group != "core" {
+		resource = fmt.Sprintf("%s.%s", resource, check.Attributes.Group)
+	}
+	if check.Attributes.Subresource != "" {
+		resource = fmt.Sprintf("%s/%s", resource, check.Attributes.Subresource)
+	}
+	return resource
+}
+
+// Name is the resource name, or "*" for every resource
+func (check *doctorAccessCheck) Name() string {
+	if check.Attributes.Name == "" {
+		return "*"
+	}
+	return check.Attributes.Name
 }
 
 func (check *doctorAccessCheck) ResolveStatus(c *cli.Config) error {
@@ -210,6 +814,9 @@ func (check *doctorAccessCheck) ResolveStatus(c *cli.Config) error {
 		} else {
 			status = doctorAccessUnknown
 		}
+		if status != doctorAccessAllowed {
+			check.FailedVerbs = append(check.FailedVerbs, verb)
+		}
 		if verb == "get" || verb == "list" || verb == "watch" {
 			check.ReadStatus = check.ReadStatus.Combine(status)
 		} else {
@@ -277,18 +884,31 @@ func (das doctorAccessStatus) Combine(new doctorAccessStatus) doctorAccessStatus
 	return doctorAccessDenied
 }
 
-func (das doctorAccessStatus) String() string {
+// Name is the status without color
+func (das doctorAccessStatus) Name() string {
 	switch das {
 	case doctorAccessAllowed:
-		return cli.Ssuccessf("allowed")
+		return "allowed"
 	case doctorAccessMixed:
-		return cli.Swarnf("mixed")
+		return "mixed"
 	case doctorAccessDenied:
-		return cli.Swarnf("denied")
+		return "denied"
 	case doctorAccessMissing:
-		return cli.Serrorf("missing")
+		return "missing"
 	case doctorAccessUnknown:
-		return cli.Serrorf("unknown")
+		return "unknown"
 	}
 	return "n/a"
 }
+
+func (das doctorAccessStatus) String() string {
+	switch das {
+	case doctorAccessAllowed:
+		return cli.Ssuccessf(das.Name())
+	case doctorAccessMixed, doctorAccessDenied:
+		return cli.Swarnf(das.Name())
+	case doctorAccessMissing, doctorAccessUnknown:
+		return cli.Serrorf(das.Name())
+	}
+	return das.Name()
+}
@@ -17,12 +17,16 @@
 package commands_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/riff/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"github.com/projectriff/system/pkg/apis"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
@@ -45,6 +49,73 @@ func TestDoctorOptions(t *testing.T) {
 			Options:           &commands.DoctorOptions{},
 			ExpectFieldErrors: cli.ErrMissingField(cli.NamespaceFlagName),
 		},
+		{
+			Name: "json output",
+			Options: &commands.DoctorOptions{
+				Namespace: "default",
+				Output:    "json",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "yaml output",
+			Options: &commands.DoctorOptions{
+				Namespace: "default",
+				Output:    "yaml",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid output",
+			Options: &commands.DoctorOptions{
+				Namespace: "default",
+				Output:    "wide",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("wide", cli.OutputFlagName),
+		},
+		{
+			Name: "quiet",
+			Options: &commands.DoctorOptions{
+				Namespace: "default",
+				Quiet:     true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "output and quiet",
+			Options: &commands.DoctorOptions{
+				Namespace: "default",
+				Output:    "json",
+				Quiet:     true,
+			},
+			ExpectFieldErrors: cli.ErrMultipleOneOf(cli.OutputFlagName, cli.QuietFlagName),
+		},
+		{
+			Name: "suggest rbac",
+			Options: &commands.DoctorOptions{
+				Namespace:   "default",
+				SuggestRBAC: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "suggest rbac and output",
+			Options: &commands.DoctorOptions{
+				Namespace:   "default",
+				SuggestRBAC: true,
+				Output:      "yaml",
+			},
+			ExpectFieldErrors: cli.ErrMultipleOneOf(cli.OutputFlagName, cli.SuggestRBACFlagName),
+		},
+		{
+			Name: "suggest rbac and quiet",
+			Options: &commands.DoctorOptions{
+				Namespace:   "default",
+				SuggestRBAC: true,
+				Quiet:       true,
+			},
+			ExpectFieldErrors: cli.ErrMultipleOneOf(cli.QuietFlagName, cli.SuggestRBACFlagName),
+		},
 	}
 
 	table.Run(t)
@@ -68,6 +139,7 @@ func TestDoctorCommand(t *testing.T) {
 			WithReactors: []rifftesting.ReactionFunc{
 				passAccessReview(),
 			},
+			ShouldError: true,
 			ExpectOutput: `
 NAMESPACE     STATUS
 default       missing
@@ -124,6 +196,743 @@ containers.build.projectriff.io     default       *          allowed   allowed
 functions.build.projectriff.io      default       *          allowed   allowed
 `,
 		},
+		{
+			Name:     "installed, json output",
+			Args:     []string{cli.OutputFlagName, "json"},
+			Runtimes: &[]string{},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "containers.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"}},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+			},
+			ExpectOutput: `
+{
+    "healthy": true,
+    "namespaces": [
+        {
+            "name": "default",
+            "status": "ok"
+        },
+        {
+            "name": "riff-system",
+            "status": "ok"
+        }
+    ],
+    "access": [
+        {
+            "resource": "configmaps",
+            "namespace": "riff-system",
+            "name": "builders",
+            "read": "allowed",
+            "write": "n/a"
+        },
+        {
+            "resource": "configmaps",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "allowed"
+        },
+        {
+            "resource": "secrets",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "allowed"
+        },
+        {
+            "resource": "pods",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "n/a"
+        },
+        {
+            "resource": "pods/log",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "n/a"
+        },
+        {
+            "resource": "applications.build.projectriff.io",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "allowed"
+        },
+        {
+            "resource": "containers.build.projectriff.io",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "allowed"
+        },
+        {
+            "resource": "functions.build.projectriff.io",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "allowed"
+        }
+    ]
+}
+`,
+		},
+		{
+			Name:     "installed, suggest rbac",
+			Args:     []string{cli.SuggestRBACFlagName},
+			Runtimes: &[]string{},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "containers.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"}},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+			},
+			ExpectOutput: `
+No access to grant
+`,
+		},
+		{
+			Name:     "installed, quiet",
+			Args:     []string{cli.QuietFlagName},
+			Runtimes: &[]string{},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "containers.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"}},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				if output != "" {
+					t.Errorf("expected no output, actually %q", output)
+				}
+			},
+		},
+		{
+			Name:     "not installed, yaml output",
+			Args:     []string{cli.OutputFlagName, "yaml"},
+			Runtimes: &[]string{},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+access:
+- name: builders
+  namespace: riff-system
+  read: allowed
+  resource: configmaps
+  write: n/a
+- name: '*'
+  namespace: default
+  read: allowed
+  resource: configmaps
+  write: allowed
+- name: '*'
+  namespace: default
+  read: allowed
+  resource: secrets
+  write: allowed
+- name: '*'
+  namespace: default
+  read: allowed
+  resource: pods
+  write: n/a
+- name: '*'
+  namespace: default
+  read: allowed
+  resource: pods/log
+  write: n/a
+- name: '*'
+  namespace: default
+  read: missing
+  resource: applications.build.projectriff.io
+  write: missing
+- name: '*'
+  namespace: default
+  read: missing
+  resource: containers.build.projectriff.io
+  write: missing
+- name: '*'
+  namespace: default
+  read: missing
+  resource: functions.build.projectriff.io
+  write: missing
+healthy: false
+namespaces:
+- name: default
+  status: missing
+- name: riff-system
+  status: missing
+`,
+		},
+		{
+			Name:     "not installed, quiet",
+			Args:     []string{cli.QuietFlagName},
+			Runtimes: &[]string{},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+			},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if output != "" {
+					t.Errorf("expected no output, actually %q", output)
+				}
+			},
+		},
+		{
+			Name:     "install, healthy",
+			Args:     []string{cli.InstallFlagName},
+			Runtimes: &[]string{},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				servedCustomResourceDefinition("applications.build.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("containers.build.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("functions.build.projectriff.io", "v1alpha1"),
+				availableDeployment("riff-system", "riff-build-controller-manager", corev1.ConditionTrue, ""),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "riff-system", Name: "builders"},
+					Data: map[string]string{
+						"riff-application": "projectriff/builder:application",
+						"riff-function":    "projectriff/builder:function",
+					},
+				},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "riff-build"}},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-credential",
+						Labels:    map[string]string{"build.projectriff.io/credential": "docker-hub"},
+					},
+				},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+			},
+			ExpectOutput: `
+NAMESPACE     STATUS
+default       ok
+riff-system   ok
+
+RESOURCE                            NAMESPACE     NAME       READ      WRITE
+configmaps                          riff-system   builders   allowed   n/a
+configmaps                          default       *          allowed   allowed
+secrets                             default       *          allowed   allowed
+pods                                default       *          allowed   n/a
+pods/log                            default       *          allowed   n/a
+applications.build.projectriff.io   default       *          allowed   allowed
+containers.build.projectriff.io     default       *          allowed   allowed
+functions.build.projectriff.io      default       *          allowed   allowed
+
+RESOURCE                    NAMESPACE     NAME                                STATUS
+customresourcedefinitions                 applications.build.projectriff.io   ok
+customresourcedefinitions                 containers.build.projectriff.io     ok
+customresourcedefinitions                 functions.build.projectriff.io      ok
+deployments.apps            riff-system   riff-build-controller-manager       ok
+configmaps                  riff-system   builders                            ok
+configmaps                  default       riff-build                          ok
+secrets                     default       *                                   ok
+`,
+		},
+		{
+			Name:     "install, unhealthy",
+			Args:     []string{cli.InstallFlagName},
+			Runtimes: &[]string{},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{
+					ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"},
+					Spec:       apiextensionsv1beta1.CustomResourceDefinitionSpec{Version: "v1alpha1"},
+				},
+				servedCustomResourceDefinition("containers.build.projectriff.io", "v1alpha2"),
+				&apiextensionsv1beta1.CustomResourceDefinition{
+					ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"},
+					Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+						Versions: []apiextensionsv1beta1.CustomResourceDefinitionVersion{
+							{Name: "v1alpha1", Served: false, Storage: true},
+						},
+					},
+				},
+				availableDeployment("riff-system", "riff-build-controller-manager", corev1.ConditionFalse, "Deployment does not have minimum availability."),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "riff-system", Name: "builders"},
+					Data: map[string]string{
+						"riff-function": "projectriff/builder:function",
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "not-a-credential"},
+				},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+NAMESPACE     STATUS
+default       ok
+riff-system   ok
+
+RESOURCE                            NAMESPACE     NAME       READ      WRITE
+configmaps                          riff-system   builders   allowed   n/a
+configmaps                          default       *          allowed   allowed
+secrets                             default       *          allowed   allowed
+pods                                default       *          allowed   n/a
+pods/log                            default       *          allowed   n/a
+applications.build.projectriff.io   default       *          allowed   allowed
+containers.build.projectriff.io     default       *          allowed   allowed
+functions.build.projectriff.io      default       *          allowed   allowed
+
+RESOURCE                    NAMESPACE     NAME                                STATUS
+customresourcedefinitions                 applications.build.projectriff.io   ok
+customresourcedefinitions                 containers.build.projectriff.io     unserved: version v1alpha1 is not served
+customresourcedefinitions                 functions.build.projectriff.io      unserved: version v1alpha1 is not served
+deployments.apps            riff-system   riff-build-controller-manager       unavailable: Deployment does not have minimum availability.
+configmaps                  riff-system   builders                            incomplete: missing "riff-application"
+configmaps                  default       riff-build                          missing
+secrets                     default       *                                   missing: no credentials found
+`,
+		},
+		{
+			Name:     "install, unhealthy, json output",
+			Args:     []string{cli.InstallFlagName, cli.OutputFlagName, "json"},
+			Runtimes: &[]string{},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{
+					ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"},
+					Spec:       apiextensionsv1beta1.CustomResourceDefinitionSpec{Version: "v1alpha1"},
+				},
+				servedCustomResourceDefinition("containers.build.projectriff.io", "v1alpha2"),
+				&apiextensionsv1beta1.CustomResourceDefinition{
+					ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"},
+					Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+						Versions: []apiextensionsv1beta1.CustomResourceDefinitionVersion{
+							{Name: "v1alpha1", Served: false, Storage: true},
+						},
+					},
+				},
+				availableDeployment("riff-system", "riff-build-controller-manager", corev1.ConditionFalse, "Deployment does not have minimum availability."),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "riff-system", Name: "builders"},
+					Data: map[string]string{
+						"riff-function": "projectriff/builder:function",
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "not-a-credential"},
+				},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+{
+    "healthy": false,
+    "namespaces": [
+        {
+            "name": "default",
+            "status": "ok"
+        },
+        {
+            "name": "riff-system",
+            "status": "ok"
+        }
+    ],
+    "access": [
+        {
+            "resource": "configmaps",
+            "namespace": "riff-system",
+            "name": "builders",
+            "read": "allowed",
+            "write": "n/a"
+        },
+        {
+            "resource": "configmaps",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "allowed"
+        },
+        {
+            "resource": "secrets",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "allowed"
+        },
+        {
+            "resource": "pods",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "n/a"
+        },
+        {
+            "resource": "pods/log",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "n/a"
+        },
+        {
+            "resource": "applications.build.projectriff.io",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "allowed"
+        },
+        {
+            "resource": "containers.build.projectriff.io",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "allowed"
+        },
+        {
+            "resource": "functions.build.projectriff.io",
+            "namespace": "default",
+            "name": "*",
+            "read": "allowed",
+            "write": "allowed"
+        }
+    ],
+    "install": [
+        {
+            "resource": "customresourcedefinitions",
+            "name": "applications.build.projectriff.io",
+            "status": "ok"
+        },
+        {
+            "resource": "customresourcedefinitions",
+            "name": "containers.build.projectriff.io",
+            "status": "unserved",
+            "message": "version v1alpha1 is not served"
+        },
+        {
+            "resource": "customresourcedefinitions",
+            "name": "functions.build.projectriff.io",
+            "status": "unserved",
+            "message": "version v1alpha1 is not served"
+        },
+        {
+            "resource": "deployments.apps",
+            "namespace": "riff-system",
+            "name": "riff-build-controller-manager",
+            "status": "unavailable",
+            "message": "Deployment does not have minimum availability."
+        },
+        {
+            "resource": "configmaps",
+            "namespace": "riff-system",
+            "name": "builders",
+            "status": "incomplete",
+            "message": "missing \"riff-application\""
+        },
+        {
+            "resource": "configmaps",
+            "namespace": "default",
+            "name": "riff-build",
+            "status": "missing"
+        },
+        {
+            "resource": "secrets",
+            "namespace": "default",
+            "name": "*",
+            "status": "missing",
+            "message": "no credentials found"
+        }
+    ]
+}
+`,
+		},
+		{
+			Name:     "install, all runtimes",
+			Args:     []string{cli.InstallFlagName},
+			Runtimes: &[]string{cli.CoreRuntime, cli.StreamingRuntime, cli.KnativeRuntime},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				servedCustomResourceDefinition("applications.build.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("containers.build.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("functions.build.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("deployers.core.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("processors.streaming.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("streams.streaming.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("inmemorygateways.streaming.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("kafkagateways.streaming.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("pulsargateways.streaming.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("adapters.knative.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("deployers.knative.projectriff.io", "v1alpha1"),
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "configurations.serving.knative.dev"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "services.serving.knative.dev"}},
+				availableDeployment("riff-system", "riff-build-controller-manager", corev1.ConditionTrue, ""),
+				availableDeployment("riff-system", "riff-core-controller-manager", corev1.ConditionTrue, ""),
+				availableDeployment("riff-system", "riff-streaming-controller-manager", corev1.ConditionTrue, ""),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "riff-system", Name: "builders"},
+					Data: map[string]string{
+						"riff-application": "projectriff/builder:application",
+						"riff-function":    "projectriff/builder:function",
+					},
+				},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "riff-build"}},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-credential",
+						Labels:    map[string]string{"build.projectriff.io/credential": "docker-hub"},
+					},
+				},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core.projectriff.io", "deployers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "processors", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "streams", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "inmemorygateways", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "kafkagateways", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "pulsargateways", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "knative.projectriff.io", "adapters", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "knative.projectriff.io", "deployers", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+NAMESPACE     STATUS
+default       ok
+riff-system   ok
+
+RESOURCE                                    NAMESPACE     NAME       READ      WRITE
+configmaps                                  riff-system   builders   allowed   n/a
+configmaps                                  default       *          allowed   allowed
+secrets                                     default       *          allowed   allowed
+pods                                        default       *          allowed   n/a
+pods/log                                    default       *          allowed   n/a
+applications.build.projectriff.io           default       *          allowed   allowed
+containers.build.projectriff.io             default       *          allowed   allowed
+functions.build.projectriff.io              default       *          allowed   allowed
+deployers.core.projectriff.io               default       *          allowed   allowed
+processors.streaming.projectriff.io         default       *          allowed   allowed
+streams.streaming.projectriff.io            default       *          allowed   allowed
+inmemorygateways.streaming.projectriff.io   default       *          allowed   allowed
+kafkagateways.streaming.projectriff.io      default       *          allowed   allowed
+pulsargateways.streaming.projectriff.io     default       *          allowed   allowed
+adapters.knative.projectriff.io             default       *          allowed   allowed
+deployers.knative.projectriff.io            default       *          allowed   allowed
+
+RESOURCE                    NAMESPACE   NAME                                 STATUS
+customresourcedefinitions               configurations.serving.knative.dev   ok
+customresourcedefinitions               services.serving.knative.dev         ok
+
+RESOURCE                    NAMESPACE     NAME                                        STATUS
+customresourcedefinitions                 applications.build.projectriff.io           ok
+customresourcedefinitions                 containers.build.projectriff.io             ok
+customresourcedefinitions                 functions.build.projectriff.io              ok
+customresourcedefinitions                 deployers.core.projectriff.io               ok
+customresourcedefinitions                 processors.streaming.projectriff.io         ok
+customresourcedefinitions                 streams.streaming.projectriff.io            ok
+customresourcedefinitions                 inmemorygateways.streaming.projectriff.io   ok
+customresourcedefinitions                 kafkagateways.streaming.projectriff.io      ok
+customresourcedefinitions                 pulsargateways.streaming.projectriff.io     ok
+customresourcedefinitions                 adapters.knative.projectriff.io             ok
+customresourcedefinitions                 deployers.knative.projectriff.io            ok
+deployments.apps            riff-system   riff-build-controller-manager               ok
+deployments.apps            riff-system   riff-core-controller-manager                ok
+deployments.apps            riff-system   riff-streaming-controller-manager           ok
+deployments.apps            riff-system   riff-knative-controller-manager             missing
+configmaps                  riff-system   builders                                    ok
+configmaps                  default       riff-build                                  ok
+secrets                     default       *                                           ok
+`,
+		},
+		{
+			Name:     "install, error getting deployment",
+			Args:     []string{cli.InstallFlagName},
+			Runtimes: &[]string{},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				servedCustomResourceDefinition("applications.build.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("containers.build.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("functions.build.projectriff.io", "v1alpha1"),
+				availableDeployment("riff-system", "riff-build-controller-manager", corev1.ConditionTrue, ""),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "riff-system", Name: "builders"},
+					Data: map[string]string{
+						"riff-application": "projectriff/builder:application",
+						"riff-function":    "projectriff/builder:function",
+					},
+				},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "riff-build"}},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-credential",
+						Labels:    map[string]string{"build.projectriff.io/credential": "docker-hub"},
+					},
+				},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+				rifftesting.InduceFailure("get", "deployments"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name:     "install, error listing secrets",
+			Args:     []string{cli.InstallFlagName},
+			Runtimes: &[]string{},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				servedCustomResourceDefinition("applications.build.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("containers.build.projectriff.io", "v1alpha1"),
+				servedCustomResourceDefinition("functions.build.projectriff.io", "v1alpha1"),
+				availableDeployment("riff-system", "riff-build-controller-manager", corev1.ConditionTrue, ""),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "riff-system", Name: "builders"},
+					Data: map[string]string{
+						"riff-application": "projectriff/builder:application",
+						"riff-function":    "projectriff/builder:function",
+					},
+				},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "riff-build"}},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-credential",
+						Labels:    map[string]string{"build.projectriff.io/credential": "docker-hub"},
+					},
+				},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+				rifftesting.InduceFailure("list", "secrets"),
+			},
+			ShouldError: true,
+		},
 		{
 			Name:     "custom namespace",
 			Args:     []string{cli.NamespaceFlagName, "my-namespace"},
@@ -165,23 +974,133 @@ functions.build.projectriff.io      my-namespace   *          allowed   allowed
 `,
 		},
 		{
-			Name:     "all runtimes",
+			Name:     "all runtimes",
+			Args:     []string{},
+			Runtimes: &cli.AllRuntimes,
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "containers.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "deployers.core.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "processors.streaming.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "streams.streaming.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "inmemorygateways.streaming.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "kafkagateways.streaming.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "pulsargateways.streaming.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "adapters.knative.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "deployers.knative.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "configurations.serving.knative.dev"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "services.serving.knative.dev"}},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core.projectriff.io", "deployers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "processors", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "streams", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "inmemorygateways", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "kafkagateways", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "pulsargateways", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "knative.projectriff.io", "adapters", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "knative.projectriff.io", "deployers", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+			},
+			ExpectOutput: `
+NAMESPACE     STATUS
+default       ok
+riff-system   ok
+
+RESOURCE                                    NAMESPACE     NAME       READ      WRITE
+configmaps                                  riff-system   builders   allowed   n/a
+configmaps                                  default       *          allowed   allowed
+secrets                                     default       *          allowed   allowed
+pods                                        default       *          allowed   n/a
+pods/log                                    default       *          allowed   n/a
+applications.build.projectriff.io           default       *          allowed   allowed
+containers.build.projectriff.io             default       *          allowed   allowed
+functions.build.projectriff.io              default       *          allowed   allowed
+deployers.core.projectriff.io               default       *          allowed   allowed
+processors.streaming.projectriff.io         default       *          allowed   allowed
+streams.streaming.projectriff.io            default       *          allowed   allowed
+inmemorygateways.streaming.projectriff.io   default       *          allowed   allowed
+kafkagateways.streaming.projectriff.io      default       *          allowed   allowed
+pulsargateways.streaming.projectriff.io     default       *          allowed   allowed
+adapters.knative.projectriff.io             default       *          allowed   allowed
+deployers.knative.projectriff.io            default       *          allowed   allowed
+
+RESOURCE                    NAMESPACE   NAME                                 STATUS
+customresourcedefinitions               configurations.serving.knative.dev   ok
+customresourcedefinitions               services.serving.knative.dev         ok
+`,
+		},
+		{
+			Name:     "core runtime",
+			Args:     []string{},
+			Runtimes: &[]string{cli.CoreRuntime},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "containers.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "deployers.core.projectriff.io"}},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core.projectriff.io", "deployers", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+			},
+			ExpectOutput: `
+NAMESPACE     STATUS
+default       ok
+riff-system   ok
+
+RESOURCE                            NAMESPACE     NAME       READ      WRITE
+configmaps                          riff-system   builders   allowed   n/a
+configmaps                          default       *          allowed   allowed
+secrets                             default       *          allowed   allowed
+pods                                default       *          allowed   n/a
+pods/log                            default       *          allowed   n/a
+applications.build.projectriff.io   default       *          allowed   allowed
+containers.build.projectriff.io     default       *          allowed   allowed
+functions.build.projectriff.io      default       *          allowed   allowed
+deployers.core.projectriff.io       default       *          allowed   allowed
+`,
+		},
+		{
+			Name:     "streaming runtime",
 			Args:     []string{},
-			Runtimes: &cli.AllRuntimes,
+			Runtimes: &[]string{cli.StreamingRuntime},
 			GivenObjects: []runtime.Object{
 				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
 				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
 				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"}},
 				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "containers.build.projectriff.io"}},
 				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"}},
-				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "deployers.core.projectriff.io"}},
 				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "processors.streaming.projectriff.io"}},
 				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "streams.streaming.projectriff.io"}},
 				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "inmemorygateways.streaming.projectriff.io"}},
 				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "kafkagateways.streaming.projectriff.io"}},
 				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "pulsargateways.streaming.projectriff.io"}},
-				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "adapters.knative.projectriff.io"}},
-				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "deployers.knative.projectriff.io"}},
 			},
 			ExpectCreates: merge(
 				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
@@ -192,14 +1111,11 @@ functions.build.projectriff.io      my-namespace   *          allowed   allowed
 				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
 				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
 				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
-				selfSubjectAccessReviewRequests("default", "", "core.projectriff.io", "deployers", "", verbs...),
 				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "processors", "", verbs...),
 				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "streams", "", verbs...),
 				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "inmemorygateways", "", verbs...),
 				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "kafkagateways", "", verbs...),
 				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "pulsargateways", "", verbs...),
-				selfSubjectAccessReviewRequests("default", "", "knative.projectriff.io", "adapters", "", verbs...),
-				selfSubjectAccessReviewRequests("default", "", "knative.projectriff.io", "deployers", "", verbs...),
 			),
 			WithReactors: []rifftesting.ReactionFunc{
 				passAccessReview(),
@@ -218,27 +1134,54 @@ pods/log                                    default       *          allowed   n
 applications.build.projectriff.io           default       *          allowed   allowed
 containers.build.projectriff.io             default       *          allowed   allowed
 functions.build.projectriff.io              default       *          allowed   allowed
-deployers.core.projectriff.io               default       *          allowed   allowed
 processors.streaming.projectriff.io         default       *          allowed   allowed
 streams.streaming.projectriff.io            default       *          allowed   allowed
 inmemorygateways.streaming.projectriff.io   default       *          allowed   allowed
 kafkagateways.streaming.projectriff.io      default       *          allowed   allowed
 pulsargateways.streaming.projectriff.io     default       *          allowed   allowed
-adapters.knative.projectriff.io             default       *          allowed   allowed
-deployers.knative.projectriff.io            default       *          allowed   allowed
 `,
 		},
 		{
-			Name:     "core runtime",
+			Name:     "streaming runtime, gateways",
 			Args:     []string{},
-			Runtimes: &[]string{cli.CoreRuntime},
+			Runtimes: &[]string{cli.StreamingRuntime},
 			GivenObjects: []runtime.Object{
 				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
 				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
 				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"}},
 				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "containers.build.projectriff.io"}},
 				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"}},
-				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "deployers.core.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "processors.streaming.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "streams.streaming.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "inmemorygateways.streaming.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "kafkagateways.streaming.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "pulsargateways.streaming.projectriff.io"}},
+				&streamv1alpha1.InMemoryGateway{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-inmemory-gateway"},
+					Status: streamv1alpha1.InMemoryGatewayStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{Type: streamv1alpha1.InMemoryGatewayConditionReady, Status: corev1.ConditionTrue},
+							},
+						},
+					},
+				},
+				&streamv1alpha1.InMemoryGateway{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "other-namespace", Name: "other-inmemory-gateway"},
+				},
+				&streamv1alpha1.KafkaGateway{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-kafka-gateway"},
+					Status: streamv1alpha1.KafkaGatewayStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{Type: streamv1alpha1.KafkaGatewayConditionReady, Status: corev1.ConditionFalse, Message: "kafka is unreachable"},
+							},
+						},
+					},
+				},
+				&streamv1alpha1.PulsarGateway{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-pulsar-gateway"},
+				},
 			},
 			ExpectCreates: merge(
 				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
@@ -249,30 +1192,44 @@ deployers.knative.projectriff.io            default       *          allowed   a
 				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
 				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
 				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
-				selfSubjectAccessReviewRequests("default", "", "core.projectriff.io", "deployers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "processors", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "streams", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "inmemorygateways", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "kafkagateways", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "pulsargateways", "", verbs...),
 			),
 			WithReactors: []rifftesting.ReactionFunc{
 				passAccessReview(),
 			},
+			ShouldError: true,
 			ExpectOutput: `
 NAMESPACE     STATUS
 default       ok
 riff-system   ok
 
-RESOURCE                            NAMESPACE     NAME       READ      WRITE
-configmaps                          riff-system   builders   allowed   n/a
-configmaps                          default       *          allowed   allowed
-secrets                             default       *          allowed   allowed
-pods                                default       *          allowed   n/a
-pods/log                            default       *          allowed   n/a
-applications.build.projectriff.io   default       *          allowed   allowed
-containers.build.projectriff.io     default       *          allowed   allowed
-functions.build.projectriff.io      default       *          allowed   allowed
-deployers.core.projectriff.io       default       *          allowed   allowed
+RESOURCE                                    NAMESPACE     NAME       READ      WRITE
+configmaps                                  riff-system   builders   allowed   n/a
+configmaps                                  default       *          allowed   allowed
+secrets                                     default       *          allowed   allowed
+pods                                        default       *          allowed   n/a
+pods/log                                    default       *          allowed   n/a
+applications.build.projectriff.io           default       *          allowed   allowed
+containers.build.projectriff.io             default       *          allowed   allowed
+functions.build.projectriff.io              default       *          allowed   allowed
+processors.streaming.projectriff.io         default       *          allowed   allowed
+streams.streaming.projectriff.io            default       *          allowed   allowed
+inmemorygateways.streaming.projectriff.io   default       *          allowed   allowed
+kafkagateways.streaming.projectriff.io      default       *          allowed   allowed
+pulsargateways.streaming.projectriff.io     default       *          allowed   allowed
+
+RESOURCE                                    NAMESPACE   NAME                  STATUS
+inmemorygateways.streaming.projectriff.io   default     my-inmemory-gateway   ok
+kafkagateways.streaming.projectriff.io      default     my-kafka-gateway      not-ready: kafka is unreachable
+pulsargateways.streaming.projectriff.io     default     my-pulsar-gateway     unknown
 `,
 		},
 		{
-			Name:     "streaming runtime",
+			Name:     "streaming runtime, error listing gateways",
 			Args:     []string{},
 			Runtimes: &[]string{cli.StreamingRuntime},
 			GivenObjects: []runtime.Object{
@@ -302,6 +1259,39 @@ deployers.core.projectriff.io       default       *          allowed   allowed
 				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "kafkagateways", "", verbs...),
 				selfSubjectAccessReviewRequests("default", "", "streaming.projectriff.io", "pulsargateways", "", verbs...),
 			),
+			WithReactors: []rifftesting.ReactionFunc{
+				passAccessReview(),
+				rifftesting.InduceFailure("list", "kafkagatewaies"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name:     "knative runtime",
+			Args:     []string{},
+			Runtimes: &[]string{cli.KnativeRuntime},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "containers.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "adapters.knative.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "deployers.knative.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "configurations.serving.knative.dev"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "services.serving.knative.dev"}},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "knative.projectriff.io", "adapters", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "knative.projectriff.io", "deployers", "", verbs...),
+			),
 			WithReactors: []rifftesting.ReactionFunc{
 				passAccessReview(),
 			},
@@ -310,24 +1300,25 @@ NAMESPACE     STATUS
 default       ok
 riff-system   ok
 
-RESOURCE                                    NAMESPACE     NAME       READ      WRITE
-configmaps                                  riff-system   builders   allowed   n/a
-configmaps                                  default       *          allowed   allowed
-secrets                                     default       *          allowed   allowed
-pods                                        default       *          allowed   n/a
-pods/log                                    default       *          allowed   n/a
-applications.build.projectriff.io           default       *          allowed   allowed
-containers.build.projectriff.io             default       *          allowed   allowed
-functions.build.projectriff.io              default       *          allowed   allowed
-processors.streaming.projectriff.io         default       *          allowed   allowed
-streams.streaming.projectriff.io            default       *          allowed   allowed
-inmemorygateways.streaming.projectriff.io   default       *          allowed   allowed
-kafkagateways.streaming.projectriff.io      default       *          allowed   allowed
-pulsargateways.streaming.projectriff.io     default       *          allowed   allowed
+RESOURCE                            NAMESPACE     NAME       READ      WRITE
+configmaps                          riff-system   builders   allowed   n/a
+configmaps                          default       *          allowed   allowed
+secrets                             default       *          allowed   allowed
+pods                                default       *          allowed   n/a
+pods/log                            default       *          allowed   n/a
+applications.build.projectriff.io   default       *          allowed   allowed
+containers.build.projectriff.io     default       *          allowed   allowed
+functions.build.projectriff.io      default       *          allowed   allowed
+adapters.knative.projectriff.io     default       *          allowed   allowed
+deployers.knative.projectriff.io    default       *          allowed   allowed
+
+RESOURCE                    NAMESPACE   NAME                                 STATUS
+customresourcedefinitions               configurations.serving.knative.dev   ok
+customresourcedefinitions               services.serving.knative.dev         ok
 `,
 		},
 		{
-			Name:     "knative runtime",
+			Name:     "knative runtime, serving missing",
 			Args:     []string{},
 			Runtimes: &[]string{cli.KnativeRuntime},
 			GivenObjects: []runtime.Object{
@@ -354,6 +1345,7 @@ pulsargateways.streaming.projectriff.io     default       *          allowed   a
 			WithReactors: []rifftesting.ReactionFunc{
 				passAccessReview(),
 			},
+			ShouldError: true,
 			ExpectOutput: `
 NAMESPACE     STATUS
 default       ok
@@ -370,6 +1362,10 @@ containers.build.projectriff.io     default       *          allowed   allowed
 functions.build.projectriff.io      default       *          allowed   allowed
 adapters.knative.projectriff.io     default       *          allowed   allowed
 deployers.knative.projectriff.io    default       *          allowed   allowed
+
+RESOURCE                    NAMESPACE   NAME                                 STATUS
+customresourcedefinitions               configurations.serving.knative.dev   missing
+customresourcedefinitions               services.serving.knative.dev         missing
 `,
 		},
 		{
@@ -400,6 +1396,7 @@ deployers.knative.projectriff.io    default       *          allowed   allowed
 				denyAccessReviewOn("*", "patch"),
 				passAccessReview(),
 			},
+			ShouldError: true,
 			ExpectOutput: `
 NAMESPACE     STATUS
 default       ok
@@ -441,6 +1438,7 @@ functions.build.projectriff.io      default       *          allowed   denied
 				denyAccessReviewOn("*", "watch"),
 				passAccessReview(),
 			},
+			ShouldError: true,
 			ExpectOutput: `
 NAMESPACE     STATUS
 default       ok
@@ -457,6 +1455,345 @@ containers.build.projectriff.io     default       *          mixed   allowed
 functions.build.projectriff.io      default       *          mixed   allowed
 `,
 		},
+		{
+			Name:     "suggest rbac, read-only access",
+			Args:     []string{cli.SuggestRBACFlagName},
+			Runtimes: &[]string{},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				c.Client.(*rifftesting.FakeClient).FakeKubeRestConfig.Impersonate.UserName = "my-user"
+				return ctx, nil
+			},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "containers.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"}},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				denyAccessReviewOn("*", "create"),
+				denyAccessReviewOn("*", "update"),
+				denyAccessReviewOn("*", "delete"),
+				denyAccessReviewOn("*", "patch"),
+				passAccessReview(),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  creationTimestamp: null
+  name: riff-doctor-my-user
+  namespace: default
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - configmaps
+  verbs:
+  - create
+  - update
+  - delete
+  - patch
+- apiGroups:
+  - ""
+  resources:
+  - secrets
+  verbs:
+  - create
+  - update
+  - delete
+  - patch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - applications
+  verbs:
+  - create
+  - update
+  - delete
+  - patch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - containers
+  verbs:
+  - create
+  - update
+  - delete
+  - patch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - functions
+  verbs:
+  - create
+  - update
+  - delete
+  - patch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  creationTimestamp: null
+  name: riff-doctor-my-user
+  namespace: default
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: riff-doctor-my-user
+subjects:
+- apiGroup: rbac.authorization.k8s.io
+  kind: User
+  name: my-user
+`,
+		},
+		{
+			Name:     "suggest rbac, mixed and unknown access",
+			Args:     []string{cli.SuggestRBACFlagName},
+			Runtimes: &[]string{},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				c.Client.(*rifftesting.FakeClient).FakeKubeRestConfig.Impersonate.UserName = "system:serviceaccount:riff-system:my-sa"
+				return ctx, nil
+			},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "containers.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"}},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				denyAccessReviewOn("*", "watch"),
+				unknownAccessReviewOn("secrets", "delete"),
+				passAccessReview(),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  creationTimestamp: null
+  name: riff-doctor-my-sa
+  namespace: riff-system
+rules:
+- apiGroups:
+  - ""
+  resourceNames:
+  - builders
+  resources:
+  - configmaps
+  verbs:
+  - watch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  creationTimestamp: null
+  name: riff-doctor-my-sa
+  namespace: riff-system
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: riff-doctor-my-sa
+subjects:
+- kind: ServiceAccount
+  name: my-sa
+  namespace: riff-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  creationTimestamp: null
+  name: riff-doctor-my-sa
+  namespace: default
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - configmaps
+  verbs:
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - secrets
+  verbs:
+  - delete
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - pods/log
+  verbs:
+  - watch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - applications
+  verbs:
+  - watch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - containers
+  verbs:
+  - watch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - functions
+  verbs:
+  - watch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  creationTimestamp: null
+  name: riff-doctor-my-sa
+  namespace: default
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: riff-doctor-my-sa
+subjects:
+- kind: ServiceAccount
+  name: my-sa
+  namespace: riff-system
+`,
+		},
+		{
+			Name:     "suggest rbac, current user",
+			Args:     []string{cli.SuggestRBACFlagName},
+			Runtimes: &[]string{},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				c.Client.(*rifftesting.FakeClient).FakeKubeRestConfig.Username = "My.User@example.com"
+				return ctx, nil
+			},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "containers.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"}},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				denyAccessReviewOn("pods", "*"),
+				passAccessReview(),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  creationTimestamp: null
+  name: riff-doctor-my.user-example.com
+  namespace: default
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - pods/log
+  verbs:
+  - get
+  - list
+  - watch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  creationTimestamp: null
+  name: riff-doctor-my.user-example.com
+  namespace: default
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: riff-doctor-my.user-example.com
+subjects:
+- apiGroup: rbac.authorization.k8s.io
+  kind: User
+  name: My.User@example.com
+`,
+		},
+		{
+			Name:     "suggest rbac, unknown user",
+			Args:     []string{cli.SuggestRBACFlagName},
+			Runtimes: &[]string{},
+			GivenObjects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "riff-system"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "applications.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "containers.build.projectriff.io"}},
+				&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "functions.build.projectriff.io"}},
+			},
+			ExpectCreates: merge(
+				selfSubjectAccessReviewRequests("riff-system", "builders", "core", "configmaps", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "configmaps", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "secrets", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "core", "pods", "log", readVerbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "applications", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "containers", "", verbs...),
+				selfSubjectAccessReviewRequests("default", "", "build.projectriff.io", "functions", "", verbs...),
+			),
+			WithReactors: []rifftesting.ReactionFunc{
+				denyAccessReviewOn("pods", "*"),
+				passAccessReview(),
+			},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected, actual := "unable to determine the current user, set --as to the user to grant access to", err.Error(); expected != actual {
+					t.Errorf("expected error %q, actually %q", expected, actual)
+				}
+			},
+		},
 		{
 			Name:     "error getting namespace",
 			Args:     []string{},
@@ -579,6 +1916,7 @@ functions.build.projectriff.io      default       *          mixed   allowed
 			WithReactors: []rifftesting.ReactionFunc{
 				unknownAccessReviewOn("*", "*"),
 			},
+			ShouldError: true,
 			ExpectOutput: `
 NAMESPACE     STATUS
 default       ok
@@ -608,6 +1946,28 @@ func merge(objectSets ...[]runtime.Object) []runtime.Object {
 	return result
 }
 
+func servedCustomResourceDefinition(name, version string) *apiextensionsv1beta1.CustomResourceDefinition {
+	return &apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1beta1.CustomResourceDefinitionVersion{
+				{Name: version, Served: true, Storage: true},
+			},
+		},
+	}
+}
+
+func availableDeployment(namespace, name string, status corev1.ConditionStatus, message string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: status, Message: message},
+			},
+		},
+	}
+}
+
 func selfSubjectAccessReviewRequests(namespace, name, group, resource string, subresource string, verbs ...string) []runtime.Object {
 	result := make([]runtime.Object, len(verbs))
 	for i, verb := range verbs {
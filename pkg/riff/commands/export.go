@@ -0,0 +1,173 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/manifests"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ExportOptions struct {
+	Namespace string
+	Filename  string
+	Directory string
+}
+
+var (
+	_ cli.Validatable = (*ExportOptions)(nil)
+	_ cli.Executable  = (*ExportOptions)(nil)
+)
+
+func (opts *ExportOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if opts.Namespace == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.NamespaceFlagName))
+	}
+
+	if opts.Filename != "" && opts.Directory != "" {
+		errs = errs.Also(cli.ErrMultipleOneOf(cli.FilenameFlagName, cli.DirectoryFlagName))
+	}
+
+	return errs
+}
+
+// exportedManifest is a cleaned resource along with the file name used when
+// exporting to a directory.
+type exportedManifest struct {
+	name     string
+	manifest []byte
+}
+
+func (opts *ExportOptions) Exec(ctx context.Context, c *cli.Config) error {
+	exported := []exportedManifest{}
+	for i := range manifests.Kinds {
+		kind := &manifests.Kinds[i]
+		if kind.Runtime != "" && !c.Runtimes[kind.Runtime] {
+			continue
+		}
+
+		list, err := kind.List(c, opts.Namespace)
+		if err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].(metav1.Object).GetName() < items[j].(metav1.Object).GetName()
+		})
+
+		for _, item := range items {
+			manifest, err := kind.Clean(item)
+			if err != nil {
+				return err
+			}
+			// resources are exported without a namespace so they can be
+			// applied to any namespace
+			if metadata, ok := manifest["metadata"].(map[string]interface{}); ok {
+				delete(metadata, "namespace")
+			}
+			b, err := yaml.Marshal(manifest)
+			if err != nil {
+				return err
+			}
+			exported = append(exported, exportedManifest{
+				name:     fmt.Sprintf("%s-%s.yaml", kind.Name, item.(metav1.Object).GetName()),
+				manifest: b,
+			})
+		}
+	}
+
+	if opts.Directory != "" {
+		if err := os.MkdirAll(opts.Directory, 0755); err != nil {
+			return err
+		}
+		for _, e := range exported {
+			if err := ioutil.WriteFile(filepath.Join(opts.Directory, e.name), e.manifest, 0644); err != nil {
+				return err
+			}
+		}
+		c.Successf("Exported %d resources to %s\n", len(exported), opts.Directory)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, e := range exported {
+		fmt.Fprintf(&buf, "---\n%s", e.manifest)
+	}
+	if opts.Filename != "" {
+		if err := ioutil.WriteFile(opts.Filename, buf.Bytes(), 0644); err != nil {
+			return err
+		}
+		c.Successf("Exported %d resources to %s\n", len(exported), opts.Filename)
+		return nil
+	}
+	c.Printf("%s", buf.String())
+
+	return nil
+}
+
+func NewExportCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ExportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "write the resources in a namespace as manifests",
+		Long: strings.TrimSpace(`
+Write the ` + c.Name + ` resources in a namespace as YAML manifests. Build resources
+and the resources of each enabled runtime are exported.
+
+Status and metadata managed by the cluster, like the uid and resourceVersion,
+are removed. The namespace is also removed so the manifests can be applied to
+any namespace with the apply command.
+
+Manifests are written to stdout as a single stream of YAML documents, to a file
+with ` + cli.FilenameFlagName + `, or as one file per resource in a directory with
+` + cli.DirectoryFlagName + `. Resources are written in the order they are applied.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s export %s my-namespace", c.Name, cli.NamespaceFlagName),
+			fmt.Sprintf("%s export %s riff.yaml", c.Name, cli.FilenameFlagName),
+			fmt.Sprintf("%s export %s ./manifests/", c.Name, cli.DirectoryFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().StringVarP(&opts.Filename, cli.StripDash(cli.FilenameFlagName), "f", "", "manifest `file` to write")
+	_ = cmd.MarkFlagFilename(cli.StripDash(cli.FilenameFlagName), "yaml", "yml")
+	cmd.Flags().StringVarP(&opts.Directory, cli.StripDash(cli.DirectoryFlagName), "d", "", "`directory` to write a manifest file per resource")
+	_ = cmd.MarkFlagDirname(cli.StripDash(cli.DirectoryFlagName))
+
+	return cmd
+}
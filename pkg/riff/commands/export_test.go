@@ -0,0 +1,371 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/manifests"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
+	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestExportOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "valid",
+			Options: &commands.ExportOptions{
+				Namespace: "default",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "valid filename",
+			Options: &commands.ExportOptions{
+				Namespace: "default",
+				Filename:  "riff.yaml",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "valid directory",
+			Options: &commands.ExportOptions{
+				Namespace: "default",
+				Directory: "manifests",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name:              "missing namespace",
+			Options:           &commands.ExportOptions{},
+			ExpectFieldErrors: cli.ErrMissingField(cli.NamespaceFlagName),
+		},
+		{
+			Name: "filename and directory",
+			Options: &commands.ExportOptions{
+				Namespace: "default",
+				Filename:  "riff.yaml",
+				Directory: "manifests",
+			},
+			ExpectFieldErrors: cli.ErrMultipleOneOf(cli.FilenameFlagName, cli.DirectoryFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestExportCommand(t *testing.T) {
+	defaultNamespace := "default"
+	otherNamespace := "other-namespace"
+
+	dir, err := ioutil.TempDir("", "export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	gateway := &streamv1alpha1.InMemoryGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       defaultNamespace,
+			Name:            "my-gateway",
+			UID:             types.UID("3d6a4c5e-a1b2-4c3d-9e8f-0a1b2c3d4e5f"),
+			ResourceVersion: "1",
+			Generation:      1,
+		},
+		Status: streamv1alpha1.InMemoryGatewayStatus{
+			GatewayImage: "projectriff/inmemory-gateway",
+		},
+	}
+	stream := &streamv1alpha1.Stream{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       defaultNamespace,
+			Name:            "my-stream",
+			ResourceVersion: "2",
+		},
+		Spec: streamv1alpha1.StreamSpec{
+			Gateway:     corev1.LocalObjectReference{Name: "my-gateway"},
+			ContentType: "application/json",
+		},
+	}
+	function := &buildv1alpha1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       defaultNamespace,
+			Name:            "my-function",
+			ResourceVersion: "3",
+			Labels:          map[string]string{"app": "my-app"},
+		},
+		Spec: buildv1alpha1.FunctionSpec{
+			Image: "registry.example.com/repo:tag",
+			Source: &buildv1alpha1.Source{
+				Git: &buildv1alpha1.Git{
+					URL:      "https://example.com/repo.git",
+					Revision: "master",
+				},
+			},
+		},
+		Status: buildv1alpha1.FunctionStatus{
+			BuildStatus: buildv1alpha1.BuildStatus{
+				LatestImage: "registry.example.com/repo@sha256:deadbeef",
+			},
+		},
+	}
+	container := &buildv1alpha1.Container{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      "a-container",
+		},
+		Spec: buildv1alpha1.ContainerSpec{
+			Image: "registry.example.com/container",
+		},
+	}
+	deployer := &corev1alpha1.Deployer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       defaultNamespace,
+			Name:            "my-deployer",
+			ResourceVersion: "4",
+		},
+		Spec: corev1alpha1.DeployerSpec{
+			Build: &corev1alpha1.Build{
+				FunctionRef: "my-function",
+			},
+			IngressPolicy: corev1alpha1.IngressPolicyClusterLocal,
+		},
+	}
+	knativeDeployer := &knativev1alpha1.Deployer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      "my-deployer",
+		},
+		Spec: knativev1alpha1.DeployerSpec{
+			Build: &knativev1alpha1.Build{
+				FunctionRef: "my-function",
+			},
+		},
+	}
+	otherFunction := &buildv1alpha1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: otherNamespace,
+			Name:      "other-function",
+		},
+	}
+	givenObjects := []runtime.Object{
+		deployer.DeepCopy(),
+		function.DeepCopy(),
+		container.DeepCopy(),
+		stream.DeepCopy(),
+		gateway.DeepCopy(),
+		otherFunction.DeepCopy(),
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name:         "export namespace",
+			Args:         []string{},
+			Runtimes:     &[]string{cli.CoreRuntime, cli.StreamingRuntime},
+			GivenObjects: givenObjects,
+			ExpectOutput: `
+---
+apiVersion: streaming.projectriff.io/v1alpha1
+kind: InMemoryGateway
+metadata:
+  name: my-gateway
+spec: {}
+---
+apiVersion: streaming.projectriff.io/v1alpha1
+kind: Stream
+metadata:
+  name: my-stream
+spec:
+  contentType: application/json
+  gateway:
+    name: my-gateway
+  provider: ""
+---
+apiVersion: build.projectriff.io/v1alpha1
+kind: Container
+metadata:
+  name: a-container
+spec:
+  image: registry.example.com/container
+---
+apiVersion: build.projectriff.io/v1alpha1
+kind: Function
+metadata:
+  labels:
+    app: my-app
+  name: my-function
+spec:
+  build:
+    resources: {}
+  image: registry.example.com/repo:tag
+  source:
+    git:
+      revision: master
+      url: https://example.com/repo.git
+---
+apiVersion: core.projectriff.io/v1alpha1
+kind: Deployer
+metadata:
+  name: my-deployer
+spec:
+  build:
+    functionRef: my-function
+  ingressPolicy: ClusterLocal
+`,
+			Verify: func(t *testing.T, output string, err error) {
+				objs, err := manifests.Read(strings.NewReader(output))
+				if err != nil {
+					t.Errorf("unable to read exported manifests: %v", err)
+				}
+				if expected, actual := 5, len(objs); expected != actual {
+					t.Errorf("expected %d exported resources, found %d", expected, actual)
+				}
+			},
+		},
+		{
+			Name:         "enabled runtimes only",
+			Args:         []string{},
+			Runtimes:     &[]string{},
+			GivenObjects: givenObjects,
+			ExpectOutput: `
+---
+apiVersion: build.projectriff.io/v1alpha1
+kind: Container
+metadata:
+  name: a-container
+spec:
+  image: registry.example.com/container
+---
+apiVersion: build.projectriff.io/v1alpha1
+kind: Function
+metadata:
+  labels:
+    app: my-app
+  name: my-function
+spec:
+  build:
+    resources: {}
+  image: registry.example.com/repo:tag
+  source:
+    git:
+      revision: master
+      url: https://example.com/repo.git
+`,
+		},
+		{
+			Name:         "empty namespace",
+			Args:         []string{cli.NamespaceFlagName, "empty"},
+			GivenObjects: givenObjects,
+		},
+		{
+			Name:         "export to file",
+			Args:         []string{cli.FilenameFlagName, filepath.Join(dir, "riff.yaml")},
+			Runtimes:     &[]string{cli.CoreRuntime, cli.StreamingRuntime},
+			GivenObjects: givenObjects,
+			ExpectOutput: "\nExported 5 resources to " + filepath.Join(dir, "riff.yaml") + "\n",
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				objs, err := manifests.ReadPath(filepath.Join(dir, "riff.yaml"), nil)
+				if err != nil {
+					t.Errorf("unable to read exported manifests: %v", err)
+				}
+				if expected, actual := 5, len(objs); expected != actual {
+					t.Errorf("expected %d exported resources, found %d", expected, actual)
+				}
+				return os.RemoveAll(filepath.Join(dir, "riff.yaml"))
+			},
+		},
+		{
+			Name:         "export to directory",
+			Args:         []string{cli.DirectoryFlagName, filepath.Join(dir, "manifests")},
+			Runtimes:     &[]string{cli.CoreRuntime, cli.StreamingRuntime},
+			GivenObjects: givenObjects,
+			ExpectOutput: "\nExported 5 resources to " + filepath.Join(dir, "manifests") + "\n",
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				files, err := ioutil.ReadDir(filepath.Join(dir, "manifests"))
+				if err != nil {
+					t.Error(err)
+				}
+				names := []string{}
+				for _, file := range files {
+					names = append(names, file.Name())
+				}
+				expected := "container-a-container.yaml,core-deployer-my-deployer.yaml,function-my-function.yaml,inmemory-gateway-my-gateway.yaml,stream-my-stream.yaml"
+				if actual := strings.Join(names, ","); expected != actual {
+					t.Errorf("expected files %q, found %q", expected, actual)
+				}
+				objs, err := manifests.ReadPath(filepath.Join(dir, "manifests"), nil)
+				if err != nil {
+					t.Errorf("unable to read exported manifests: %v", err)
+				}
+				if expected, actual := 5, len(objs); expected != actual {
+					t.Errorf("expected %d exported resources, found %d", expected, actual)
+				}
+				return os.RemoveAll(filepath.Join(dir, "manifests"))
+			},
+		},
+		{
+			Name:     "export to directory with deployers of the same name",
+			Args:     []string{cli.DirectoryFlagName, filepath.Join(dir, "deployers")},
+			Runtimes: &[]string{cli.CoreRuntime, cli.KnativeRuntime},
+			GivenObjects: []runtime.Object{
+				deployer.DeepCopy(),
+				knativeDeployer.DeepCopy(),
+			},
+			ExpectOutput: "\nExported 2 resources to " + filepath.Join(dir, "deployers") + "\n",
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				files, err := ioutil.ReadDir(filepath.Join(dir, "deployers"))
+				if err != nil {
+					t.Error(err)
+				}
+				names := []string{}
+				for _, file := range files {
+					names = append(names, file.Name())
+				}
+				expected := "core-deployer-my-deployer.yaml,knative-deployer-my-deployer.yaml"
+				if actual := strings.Join(names, ","); expected != actual {
+					t.Errorf("expected files %q, found %q", expected, actual)
+				}
+				return os.RemoveAll(filepath.Join(dir, "deployers"))
+			},
+		},
+		{
+			Name:         "list error",
+			Args:         []string{},
+			GivenObjects: givenObjects,
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("list", "functions"),
+			},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewExportCommand)
+}
@@ -51,6 +51,12 @@ func NewRootCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	cmd.PersistentFlags().StringVar(&c.KubeConfigFile, cli.StripDash(cli.KubeConfigFlagName), "", "kubectl config `file` (default is $HOME/.kube/config)")
 	cmd.PersistentFlags().StringVar(&c.KubeConfigFile, cli.StripDash(cli.KubeConfigFlagNameDeprecated), "", "kubectl config `file` (default is $HOME/.kube/config)")
 	cmd.PersistentFlags().MarkDeprecated(cli.StripDash(cli.KubeConfigFlagNameDeprecated), fmt.Sprintf("renamed to %s", cli.KubeConfigFlagName))
+	cmd.PersistentFlags().StringVar(&c.KubeConfigOverrides.CurrentContext, cli.StripDash(cli.ContextFlagName), "", "`name` of the kubectl config context to use (default is the current context)")
+	cmd.PersistentFlags().StringVar(&c.KubeConfigOverrides.Context.Cluster, cli.StripDash(cli.ClusterFlagName), "", "`name` of the kubectl config cluster to use (default is the cluster of the context)")
+	cmd.PersistentFlags().StringVar(&c.KubeConfigOverrides.Context.AuthInfo, cli.StripDash(cli.UserFlagName), "", "`name` of the kubectl config user to use (default is the user of the context)")
+	cmd.PersistentFlags().StringVar(&c.KubeConfigOverrides.Timeout, cli.StripDash(cli.RequestTimeoutFlagName), "", "`duration` to wait for a single request to the cluster, e.g. 30s (default is no timeout)")
+	cmd.PersistentFlags().StringVar(&c.KubeConfigOverrides.AuthInfo.Impersonate, cli.StripDash(cli.AsFlagName), "", "`user` to impersonate for requests to the cluster")
+	cmd.PersistentFlags().StringArrayVar(&c.KubeConfigOverrides.AuthInfo.ImpersonateGroups, cli.StripDash(cli.AsGroupFlagName), []string{}, "`group` to impersonate for requests to the cluster (may be set multiple times)")
 	cmd.PersistentFlags().BoolVar(&color.NoColor, cli.StripDash(cli.NoColorFlagName), color.NoColor, "disable color output in terminals")
 
 	// add runtimes
@@ -89,9 +95,13 @@ zero-to-n autoscaling and managed ingress.
 	}
 
 	// add root-only commands
+	cmd.AddCommand(NewApplyCommand(ctx, c))
+	cmd.AddCommand(NewDiffCommand(ctx, c))
+	cmd.AddCommand(NewExportCommand(ctx, c))
 	cmd.AddCommand(NewCompletionCommand(ctx, c))
 	cmd.AddCommand(NewDocsCommand(ctx, c))
 	cmd.AddCommand(NewDoctorCommand(ctx, c))
+	cmd.AddCommand(NewWaitCommand(ctx, c))
 
 	// override usage template to add arguments
 	cmd.SetUsageTemplate(strings.ReplaceAll(cmd.UsageTemplate(), "{{.UseLine}}", "{{useLine .}}"))
@@ -0,0 +1,216 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/manifests"
+	"github.com/projectriff/cli/pkg/race"
+	"github.com/projectriff/cli/pkg/validation"
+	"github.com/projectriff/system/pkg/apis"
+	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// WaitForReady waits for the Ready condition of each resource to be True
+	WaitForReady = "ready"
+)
+
+// Outcomes of waiting for a resource, printed after the resource reference.
+const (
+	waitReady    = "ready"
+	waitFailed   = "failed"
+	waitNotFound = "not-found"
+	waitTimeout  = "timeout"
+)
+
+type WaitOptions struct {
+	Namespace string
+	Resources []string
+	For       string
+	Timeout   time.Duration
+}
+
+var (
+	_ cli.Validatable = (*WaitOptions)(nil)
+	_ cli.Executable  = (*WaitOptions)(nil)
+)
+
+func (opts *WaitOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if opts.Namespace == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.NamespaceFlagName))
+	}
+
+	if len(opts.Resources) == 0 {
+		errs = errs.Also(cli.ErrMissingField(cli.ResourcesArgumentName))
+	}
+	for i, resource := range opts.Resources {
+		kind, name := parseResourceRef(resource)
+		if manifests.KindForName(kind) == nil || len(validation.K8sName(name, cli.CurrentField)) != 0 {
+			errs = errs.Also(cli.ErrInvalidArrayValue(resource, cli.ResourcesArgumentName, i))
+		}
+	}
+
+	if opts.For != WaitForReady {
+		errs = errs.Also(cli.ErrInvalidValue(opts.For, cli.ForFlagName))
+	}
+
+	if opts.Timeout <= 0 {
+		errs = errs.Also(cli.ErrInvalidValue(opts.Timeout, cli.TimeoutFlagName))
+	}
+
+	return errs
+}
+
+// parseResourceRef splits a <kind>/<name> reference
+func parseResourceRef(ref string) (string, string) {
+	parts := strings.SplitN(ref, "/", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+	return parts[0], parts[1]
+}
+
+// readyResource is a riff resource with a Ready condition
+type readyResource interface {
+	apis.Resource
+	metav1.Object
+	runtime.Object
+}
+
+func (opts *WaitOptions) Exec(ctx context.Context, c *cli.Config) error {
+	for _, resource := range opts.Resources {
+		kindName, _ := parseResourceRef(resource)
+		kind := manifests.KindForName(kindName)
+		if kind.Runtime != "" && !c.Runtimes[kind.Runtime] {
+			return fmt.Errorf("the %s runtime is not enabled, unable to wait for %s", kind.Runtime, resource)
+		}
+	}
+
+	// wait for each resource in parallel, the outcomes are printed in the
+	// order the resources were requested
+	outcomes := make([]string, len(opts.Resources))
+	var wg sync.WaitGroup
+	for i := range opts.Resources {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outcomes[i] = opts.wait(ctx, c, opts.Resources[i])
+		}(i)
+	}
+	wg.Wait()
+
+	notReady := 0
+	for i, outcome := range outcomes {
+		c.Printf("%s %s\n", opts.Resources[i], outcome)
+		if outcome != waitReady {
+			notReady++
+		}
+	}
+	if notReady != 0 {
+		return cli.SilenceError(fmt.Errorf("%d of %d resources are not %s", notReady, len(outcomes), opts.For))
+	}
+	return nil
+}
+
+// wait blocks until the resource is ready, or is not able to become ready,
+// returning the outcome
+func (opts *WaitOptions) wait(ctx context.Context, c *cli.Config, resource string) string {
+	kindName, name := parseResourceRef(resource)
+	kind := manifests.KindForName(kindName)
+
+	obj, err := kind.Get(c, opts.Namespace, name)
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			return waitNotFound
+		}
+		return fmt.Sprintf("%s: %s", waitFailed, err)
+	}
+	target, ok := obj.(readyResource)
+	if !ok {
+		return fmt.Sprintf("%s: %s does not have a ready condition", waitFailed, kind.GroupVersionKind.Kind)
+	}
+
+	err = race.Run(ctx, opts.Timeout,
+		func(ctx context.Context) error {
+			return k8s.WaitUntilReady(ctx, kind.RESTClient(c), kind.Resource, target)
+		},
+	)
+	if err == context.DeadlineExceeded {
+		return waitTimeout
+	}
+	if err != nil {
+		return fmt.Sprintf("%s: %s", waitFailed, err)
+	}
+	return waitReady
+}
+
+func NewWaitCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &WaitOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "wait for resources to become ready",
+		Long: strings.TrimSpace(`
+Wait for one or more resources to become ready. Resources are referenced as
+<kind>/<name>, where the kind is one of:
+- application, container, function
+- core-deployer
+- adapter, knative-deployer
+- inmemory-gateway, kafka-gateway, pulsar-gateway, stream, processor
+
+Each resource is waited on in parallel. When every resource is ready, or cannot
+become ready, a line is printed for each resource with the reference followed
+by the outcome:
+- ready
+- failed: <reason>, when the Ready condition is False or the resource is deleted
+- not-found
+- timeout
+
+The command exits with an error unless every resource is ready, allowing
+scripts to block until a deployment completes without tailing logs.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s wait function/square", c.Name),
+			fmt.Sprintf("%s wait function/square core-deployer/square %s 5m", c.Name, cli.TimeoutFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.ResourcesArg(&opts.Resources),
+	)
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().StringVar(&opts.For, cli.StripDash(cli.ForFlagName), WaitForReady, fmt.Sprintf("`condition` to wait for, one of: %s", WaitForReady))
+	cmd.Flags().DurationVar(&opts.Timeout, cli.StripDash(cli.TimeoutFlagName), time.Minute*5, "`duration` to wait for each resource to become ready")
+
+	return cmd
+}
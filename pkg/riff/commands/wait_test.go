@@ -0,0 +1,257 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"github.com/projectriff/system/pkg/apis"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	cachetesting "k8s.io/client-go/tools/cache/testing"
+)
+
+func TestWaitOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "valid",
+			Options: &commands.WaitOptions{
+				Namespace: "default",
+				Resources: []string{"function/my-function", "core-deployer/my-deployer"},
+				For:       commands.WaitForReady,
+				Timeout:   time.Minute,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "missing namespace",
+			Options: &commands.WaitOptions{
+				Resources: []string{"function/my-function"},
+				For:       commands.WaitForReady,
+				Timeout:   time.Minute,
+			},
+			ExpectFieldErrors: cli.ErrMissingField(cli.NamespaceFlagName),
+		},
+		{
+			Name: "missing resources",
+			Options: &commands.WaitOptions{
+				Namespace: "default",
+				For:       commands.WaitForReady,
+				Timeout:   time.Minute,
+			},
+			ExpectFieldErrors: cli.ErrMissingField(cli.ResourcesArgumentName),
+		},
+		{
+			Name: "invalid resources",
+			Options: &commands.WaitOptions{
+				Namespace: "default",
+				Resources: []string{"function/my-function", "my-function", "deployer/my-deployer", "function/My-Function"},
+				For:       commands.WaitForReady,
+				Timeout:   time.Minute,
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidArrayValue("my-function", cli.ResourcesArgumentName, 1),
+				cli.ErrInvalidArrayValue("deployer/my-deployer", cli.ResourcesArgumentName, 2),
+				cli.ErrInvalidArrayValue("function/My-Function", cli.ResourcesArgumentName, 3),
+			),
+		},
+		{
+			Name: "invalid for",
+			Options: &commands.WaitOptions{
+				Namespace: "default",
+				Resources: []string{"function/my-function"},
+				For:       "deleted",
+				Timeout:   time.Minute,
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("deleted", cli.ForFlagName),
+		},
+		{
+			Name: "invalid timeout",
+			Options: &commands.WaitOptions{
+				Namespace: "default",
+				Resources: []string{"function/my-function"},
+				For:       commands.WaitForReady,
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue(time.Duration(0), cli.TimeoutFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestWaitCommand(t *testing.T) {
+	defaultNamespace := "default"
+	functionName := "my-function"
+	deployerName := "my-deployer"
+
+	readyFunction := &buildv1alpha1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      functionName,
+			UID:       types.UID("function-uid"),
+		},
+		Status: buildv1alpha1.FunctionStatus{
+			Status: apis.Status{
+				Conditions: apis.Conditions{
+					{Type: buildv1alpha1.FunctionConditionReady, Status: corev1.ConditionTrue},
+				},
+			},
+		},
+	}
+	readyDeployer := &corev1alpha1.Deployer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      deployerName,
+			UID:       types.UID("deployer-uid"),
+		},
+		Status: corev1alpha1.DeployerStatus{
+			Status: apis.Status{
+				Conditions: apis.Conditions{
+					{Type: corev1alpha1.DeployerConditionReady, Status: corev1.ConditionTrue},
+				},
+			},
+		},
+	}
+	failedFunction := readyFunction.DeepCopy()
+	failedFunction.Status.Conditions = apis.Conditions{
+		{Type: buildv1alpha1.FunctionConditionReady, Status: corev1.ConditionFalse, Reason: "BuildFailed", Message: "the build failed"},
+	}
+	unknownFunction := readyFunction.DeepCopy()
+	unknownFunction.Status.Conditions = apis.Conditions{
+		{Type: buildv1alpha1.FunctionConditionReady, Status: corev1.ConditionUnknown},
+	}
+
+	// the lister watcher is shared by every resource waited on
+	listerWith := func(objs ...runtime.Object) func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+		return func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+			lister := cachetesting.NewFakeControllerSource()
+			for _, obj := range objs {
+				lister.Add(obj)
+			}
+			return k8s.WithListerWatcher(ctx, lister), nil
+		}
+	}
+	shutdownLister := func(t *testing.T, ctx context.Context, c *cli.Config) error {
+		if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*cachetesting.FakeControllerSource); ok {
+			lw.Shutdown()
+		}
+		return nil
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name: "ready",
+			Args: []string{"function/" + functionName},
+			GivenObjects: []runtime.Object{
+				readyFunction,
+			},
+			Prepare: listerWith(readyFunction),
+			CleanUp: shutdownLister,
+			ExpectOutput: `
+function/my-function ready
+`,
+		},
+		{
+			Name: "multiple resources",
+			Args: []string{"function/" + functionName, "core-deployer/" + deployerName},
+			GivenObjects: []runtime.Object{
+				readyFunction,
+				readyDeployer,
+			},
+			Prepare: listerWith(readyFunction, readyDeployer),
+			CleanUp: shutdownLister,
+			ExpectOutput: `
+function/my-function ready
+core-deployer/my-deployer ready
+`,
+		},
+		{
+			Name: "not found",
+			Args: []string{"function/" + functionName, "core-deployer/" + deployerName},
+			GivenObjects: []runtime.Object{
+				readyFunction,
+			},
+			Prepare:     listerWith(readyFunction),
+			CleanUp:     shutdownLister,
+			ShouldError: true,
+			ExpectOutput: `
+function/my-function ready
+core-deployer/my-deployer not-found
+`,
+		},
+		{
+			Name: "failed",
+			Args: []string{"function/" + functionName},
+			GivenObjects: []runtime.Object{
+				failedFunction,
+			},
+			Prepare:     listerWith(failedFunction),
+			CleanUp:     shutdownLister,
+			ShouldError: true,
+			ExpectOutput: `
+function/my-function failed: failed to become ready: the build failed
+`,
+		},
+		{
+			Name: "timeout",
+			Args: []string{"function/" + functionName, cli.TimeoutFlagName, "10ms"},
+			GivenObjects: []runtime.Object{
+				unknownFunction,
+			},
+			Prepare:     listerWith(unknownFunction),
+			CleanUp:     shutdownLister,
+			ShouldError: true,
+			ExpectOutput: `
+function/my-function timeout
+`,
+		},
+		{
+			Name: "get error",
+			Args: []string{"function/" + functionName},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "functions"),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+function/my-function failed: inducing failure for get functions
+`,
+		},
+		{
+			Name:        "runtime not enabled",
+			Args:        []string{"core-deployer/" + deployerName},
+			Runtimes:    &[]string{},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewWaitCommand)
+}
@@ -29,6 +29,7 @@ import (
 	cachetesting "k8s.io/client-go/tools/cache/testing"
 
 	"github.com/projectriff/cli/pkg/cli"
+	riffkail "github.com/projectriff/cli/pkg/kail"
 	"github.com/projectriff/cli/pkg/streaming/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
@@ -99,6 +100,24 @@ func TestInMemoryGatewayCreateCommand(t *testing.T) {
 			},
 			ExpectOutput: `
 Created in-memory gateway "my-inmemory-gateway"
+`,
+		},
+		{
+			Name: "create with labels and annotations",
+			Args: []string{inmemoryGatewayName, cli.LabelFlagName, "team=payments", cli.AnnotationFlagName, "owner=jane@example.com"},
+			ExpectCreates: []runtime.Object{
+				&streamv1alpha1.InMemoryGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   defaultNamespace,
+						Name:        inmemoryGatewayName,
+						Labels:      map[string]string{"team": "payments"},
+						Annotations: map[string]string{"owner": "jane@example.com"},
+					},
+					Spec: streamv1alpha1.InMemoryGatewaySpec{},
+				},
+			},
+			ExpectOutput: `
+Created in-memory gateway "my-inmemory-gateway"
 `,
 		},
 		{
@@ -172,7 +191,7 @@ Created in-memory gateway "my-inmemory-gateway"
 						Name:      "franz",
 					},
 					Spec: streamv1alpha1.InMemoryGatewaySpec{},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -216,7 +235,7 @@ InMemoryGateway "franz" is ready
 						Name:      "franz",
 					},
 					Spec: streamv1alpha1.InMemoryGatewaySpec{},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 					// wait for context to be cancelled
 					<-args[0].(context.Context).Done()
@@ -269,7 +288,7 @@ To view status run: riff streaming inmemory-gateway list --namespace default
 						Name:      "franz",
 					},
 					Spec: streamv1alpha1.InMemoryGatewaySpec{},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(fmt.Errorf("kail error"))
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
 				return ctx, nil
 			},
 			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
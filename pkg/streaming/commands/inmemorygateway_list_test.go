@@ -150,6 +150,37 @@ other-namespace   test-other-inmemory-gateway   <unknown>   <unknown>
 			ExpectOutput: `
 NAME          STATUS   AGE
 my-inmemory   Ready    <unknown>
+`,
+		},
+		{
+			Name: "wide output",
+			Args: []string{cli.OutputFlagName, "wide"},
+			GivenObjects: []runtime.Object{
+				&streamv1alpha1.InMemoryGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      inmemoryGatewayName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+NAME                    STATUS      AGE         ADDRESS
+test-inmemory-gateway   <unknown>   <unknown>   <empty>
+`,
+		},
+		{
+			Name: "name output",
+			Args: []string{cli.OutputFlagName, "name"},
+			GivenObjects: []runtime.Object{
+				&streamv1alpha1.InMemoryGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      inmemoryGatewayName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+inmemorygateway/test-inmemory-gateway
 `,
 		},
 		{
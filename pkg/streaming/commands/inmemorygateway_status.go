@@ -23,6 +23,8 @@ import (
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/projectriff/cli/pkg/validation"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -31,6 +33,8 @@ import (
 
 type InMemoryGatewayStatusOptions struct {
 	options.ResourceOptions
+
+	Output string
 }
 
 var (
@@ -43,6 +47,8 @@ func (opts *InMemoryGatewayStatusOptions) Validate(ctx context.Context) cli.Fiel
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
 
+	errs = errs.Also(validation.StructuredOutputFormat(opts.Output, cli.OutputFlagName))
+
 	return errs
 }
 
@@ -56,10 +62,26 @@ func (opts *InMemoryGatewayStatusOptions) Exec(ctx context.Context, c *cli.Confi
 		return cli.SilenceError(err)
 	}
 
+	if opts.Output != "" {
+		return printers.PrintObject(opts.Output, gateway, streamv1alpha1.SchemeGroupVersion.WithKind("InMemoryGateway"), c.Stdout)
+	}
+
 	ready := gateway.Status.GetCondition(streamv1alpha1.InMemoryGatewayConditionReady)
 	cli.PrintResourceStatus(c, gateway.Name, ready)
 
-	return nil
+	return cli.PrintResourceStatusDetails(c, cli.ResourceStatusDetails{
+		GroupVersionKind: gateway.GetGroupVersionKind(),
+		Namespace:        gateway.Namespace,
+		Name:             gateway.Name,
+		Conditions:       gateway.Status.Conditions,
+		Details: []cli.StatusDetail{
+			{Name: "Address", Value: cli.StatusAddress(gateway.Status.Address)},
+			{Name: "Gateway", Value: cli.StatusRefName(gateway.Status.GatewayRef)},
+			{Name: "Gateway Image", Value: gateway.Status.GatewayImage},
+			{Name: "Provisioner Image", Value: gateway.Status.ProvisionerImage},
+		},
+		PodSelector: fmt.Sprintf("%s=%s", streamv1alpha1.InMemoryGatewayLabelKey, gateway.Name),
+	})
 }
 
 func NewInMemoryGatewayStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -75,9 +97,13 @@ The Ready condition is shown which should include a reason code and a
 descriptive message when the status is not "True". The status for the condition
 may be: "True", "False" or "Unknown". An "Unknown" status is common while the
 in-memory gateway roll out is being processed.
+
+Key fields from the status, every condition and the most recent events for
+the in-memory gateway are shown after the Ready condition.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s streamming inmemory-gateway status my-inmemory-gateway", c.Name),
+			fmt.Sprintf("%s streaming inmemory-gateway status my-inmemory-gateway %s jsonpath={.status.address.url}", c.Name, cli.OutputFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -88,6 +114,7 @@ in-memory gateway roll out is being processed.
 	)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cli.StructuredOutputFlag(cmd, &opts.Output)
 
 	return cmd
 }
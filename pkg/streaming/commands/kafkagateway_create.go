@@ -25,7 +25,9 @@ import (
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
 	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/parsers"
 	"github.com/projectriff/cli/pkg/race"
+	"github.com/projectriff/cli/pkg/validation"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,6 +35,10 @@ import (
 
 type KafkaGatewayCreateOptions struct {
 	options.ResourceOptions
+	options.TailOptions
+
+	Labels      []string
+	Annotations []string
 
 	BootstrapServers string
 
@@ -52,6 +58,8 @@ func (opts *KafkaGatewayCreateOptions) Validate(ctx context.Context) cli.FieldEr
 	errs := cli.FieldErrors{}
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+	errs = errs.Also(validation.Labels(opts.Labels, cli.LabelFlagName))
+	errs = errs.Also(validation.Annotations(opts.Annotations, cli.AnnotationFlagName))
 
 	if opts.BootstrapServers == "" {
 		errs = errs.Also(cli.ErrMissingField(cli.BootstrapServersFlagName))
@@ -59,6 +67,7 @@ func (opts *KafkaGatewayCreateOptions) Validate(ctx context.Context) cli.FieldEr
 	if opts.DryRun && opts.Tail {
 		errs = errs.Also(cli.ErrMultipleOneOf(cli.DryRunFlagName, cli.TailFlagName))
 	}
+	errs = errs.Also(opts.TailOptions.Validate(ctx, cli.TailOutputFlagName))
 	if opts.WaitTimeout < 0 {
 		errs = errs.Also(cli.ErrInvalidValue(opts.WaitTimeout, cli.WaitTimeoutFlagName))
 	}
@@ -69,8 +78,10 @@ func (opts *KafkaGatewayCreateOptions) Validate(ctx context.Context) cli.FieldEr
 func (opts *KafkaGatewayCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
 	gateway := &streamv1alpha1.KafkaGateway{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: opts.Namespace,
-			Name:      opts.Name,
+			Namespace:   opts.Namespace,
+			Name:        opts.Name,
+			Labels:      parsers.KeyValues(opts.Labels),
+			Annotations: parsers.KeyValues(opts.Annotations),
 		},
 		Spec: streamv1alpha1.KafkaGatewaySpec{
 			BootstrapServers: opts.BootstrapServers,
@@ -90,10 +101,10 @@ func (opts *KafkaGatewayCreateOptions) Exec(ctx context.Context, c *cli.Config)
 	if opts.Tail {
 		err := race.Run(ctx, opts.WaitTimeout,
 			func(ctx context.Context) error {
-				return k8s.WaitUntilReady(ctx, c.StreamingRuntime().RESTClient(), "kafkagatewaies", gateway)
+				return k8s.WaitUntilReady(ctx, c.StreamingRuntime().RESTClient(), "kafkagateways", gateway)
 			},
 			func(ctx context.Context) error {
-				return c.Kail.KafkaGatewayLogs(ctx, gateway, cli.TailSinceCreateDefault, c.Stdout)
+				return c.Kail.KafkaGatewayLogs(ctx, gateway, opts.LogOptions(cli.TailSinceCreateDefault), c.Stdout)
 			},
 		)
 		if err == context.DeadlineExceeded {
@@ -135,7 +146,10 @@ func NewKafkaGatewayCreateCommand(ctx context.Context, c *cli.Config) *cobra.Com
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().StringVar(&opts.BootstrapServers, cli.StripDash(cli.BootstrapServersFlagName), "", "`address` of the kafka broker")
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().StringArrayVar(&opts.Labels, cli.StripDash(cli.LabelFlagName), []string{}, fmt.Sprintf("`label` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s team=payments", cli.LabelFlagName)))
+	cmd.Flags().StringArrayVar(&opts.Annotations, cli.StripDash(cli.AnnotationFlagName), []string{}, fmt.Sprintf("`annotation` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s owner=jane@example.com", cli.AnnotationFlagName)))
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch creation progress")
+	cli.TailFlags(cmd, cli.TailOutputFlagName, &opts.Output, &opts.Grep, &opts.Timestamps)
 	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), time.Minute*1, "`duration` to wait for the gateway to become ready when watching progress")
 
 	return cmd
@@ -29,6 +29,7 @@ import (
 	cachetesting "k8s.io/client-go/tools/cache/testing"
 
 	"github.com/projectriff/cli/pkg/cli"
+	riffkail "github.com/projectriff/cli/pkg/kail"
 	"github.com/projectriff/cli/pkg/streaming/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
@@ -115,6 +116,26 @@ func TestKafkaGatewayCreateCommand(t *testing.T) {
 			},
 			ExpectOutput: `
 Created kafka gateway "my-kafka-gateway"
+`,
+		},
+		{
+			Name: "create with labels and annotations",
+			Args: []string{kafkaGatewayName, cli.BootstrapServersFlagName, bootstrapServers, cli.LabelFlagName, "team=payments", cli.AnnotationFlagName, "owner=jane@example.com"},
+			ExpectCreates: []runtime.Object{
+				&streamv1alpha1.KafkaGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   defaultNamespace,
+						Name:        kafkaGatewayName,
+						Labels:      map[string]string{"team": "payments"},
+						Annotations: map[string]string{"owner": "jane@example.com"},
+					},
+					Spec: streamv1alpha1.KafkaGatewaySpec{
+						BootstrapServers: bootstrapServers,
+					},
+				},
+			},
+			ExpectOutput: `
+Created kafka gateway "my-kafka-gateway"
 `,
 		},
 		{
@@ -195,7 +216,7 @@ Created kafka gateway "my-kafka-gateway"
 					Spec: streamv1alpha1.KafkaGatewaySpec{
 						BootstrapServers: "some-host",
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -243,7 +264,7 @@ KafkaGateway "franz" is ready
 					Spec: streamv1alpha1.KafkaGatewaySpec{
 						BootstrapServers: "some-host",
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 					// wait for context to be cancelled
 					<-args[0].(context.Context).Done()
@@ -300,7 +321,7 @@ To view status run: riff streaming kafka-gateway list --namespace default
 					Spec: streamv1alpha1.KafkaGatewaySpec{
 						BootstrapServers: "some-host",
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(fmt.Errorf("kail error"))
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
 				return ctx, nil
 			},
 			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
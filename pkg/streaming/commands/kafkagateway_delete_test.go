@@ -17,14 +17,19 @@
 package commands_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
 	"github.com/projectriff/cli/pkg/streaming/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	cachetesting "k8s.io/client-go/tools/cache/testing"
 )
 
 func TestKafkaGatewayDeleteOptions(t *testing.T) {
@@ -53,6 +58,30 @@ func TestKafkaGatewayDeleteCommand(t *testing.T) {
 	kafkaGatewayOtherName := "test-other-kafka-gateway"
 	defaultNamespace := "default"
 
+	gateway := &streamv1alpha1.KafkaGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kafkaGatewayName,
+			Namespace: defaultNamespace,
+		},
+	}
+
+	var gatewayLister, podLister *cachetesting.FakeControllerSource
+	listers := func(ctx context.Context, gateways []runtime.Object) context.Context {
+		gatewayLister = cachetesting.NewFakeControllerSource()
+		for _, gateway := range gateways {
+			gatewayLister.Add(gateway.DeepCopyObject())
+		}
+		podLister = cachetesting.NewFakeControllerSource()
+		// the lister watcher is found by the resource name used by the api server
+		ctx = k8s.WithResourceListerWatcher(ctx, "kafkagateways", gatewayLister)
+		return k8s.WithResourceListerWatcher(ctx, "pods", podLister)
+	}
+	shutdownListers := func(t *testing.T, ctx context.Context, c *cli.Config) error {
+		gatewayLister.Shutdown()
+		podLister.Shutdown()
+		return nil
+	}
+
 	table := rifftesting.CommandTable{
 		{
 			Name:        "invalid args",
@@ -152,6 +181,38 @@ Deleted kafka gateway "test-kafka-gateway"
 			ExpectOutput: `
 Deleted kafka gateway "test-kafka-gateway"
 Deleted kafka gateway "test-other-kafka-gateway"
+`,
+		},
+		{
+			Name: "delete kafka gateway and wait",
+			Args: []string{kafkaGatewayName, cli.WaitFlagName, cli.WaitTimeoutFlagName, "5s"},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				return listers(ctx, []runtime.Object{gateway}), nil
+			},
+			CleanUp: shutdownListers,
+			GivenObjects: []runtime.Object{
+				gateway,
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				func(action clientgotesting.Action) (handled bool, ret runtime.Object, err error) {
+					if action.Matches("delete", "kafkagatewaies") {
+						gatewayLister := gatewayLister
+						go func() {
+							time.Sleep(150 * time.Millisecond)
+							gatewayLister.Delete(gateway.DeepCopy())
+						}()
+					}
+					return false, nil, nil
+				},
+			},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "streaming.projectriff.io",
+				Resource:  "kafkagatewaies",
+				Namespace: defaultNamespace,
+				Name:      kafkaGatewayName,
+			}},
+			ExpectOutput: `
+Deleted kafka gateway "test-kafka-gateway"
 `,
 		},
 		{
@@ -27,7 +27,6 @@ import (
 	"github.com/projectriff/cli/pkg/cli/printers"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -50,18 +49,15 @@ func (opts *KafkaGatewayListOptions) Validate(ctx context.Context) cli.FieldErro
 }
 
 func (opts *KafkaGatewayListOptions) Exec(ctx context.Context, c *cli.Config) error {
-	gateways, err := c.StreamingRuntime().KafkaGateways(opts.Namespace).List(metav1.ListOptions{})
+	listOptions := opts.K8sListOptions()
+	gateways, err := c.StreamingRuntime().KafkaGateways(opts.Namespace).List(listOptions)
 	if err != nil {
 		return err
 	}
 
-	if len(gateways.Items) == 0 {
-		c.Infof("No kafka gateways found.\n")
-		return nil
-	}
-
 	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{
 		WithNamespace: opts.AllNamespaces,
+		Wide:          opts.Output == printers.OutputFormatWide,
 	}).With(func(h printers.PrintHandler) {
 		columns := opts.printColumns()
 		h.TableHandler(columns, opts.printList)
@@ -71,7 +67,25 @@ func (opts *KafkaGatewayListOptions) Exec(ctx context.Context, c *cli.Config) er
 	gateways = gateways.DeepCopy()
 	cli.SortByNamespaceAndName(gateways.Items)
 
-	return tablePrinter.PrintObj(gateways, c.Stdout)
+	if len(gateways.Items) == 0 && printers.IsHumanReadable(opts.Output) {
+		c.Infof("No kafka gateways found.\n")
+	} else if err := printers.PrintList(opts.Output, tablePrinter, gateways, streamv1alpha1.SchemeGroupVersion.WithKind("KafkaGateway"), c.Stdout); err != nil {
+		return err
+	}
+
+	if opts.Watch {
+		return cli.WatchList(ctx, c, gateways, cli.WatchListOptions{
+			Client:       c.StreamingRuntime().RESTClient(),
+			Resource:     "kafkagateways",
+			Namespace:    opts.Namespace,
+			ListOptions:  listOptions,
+			Output:       opts.Output,
+			TablePrinter: tablePrinter,
+			ItemKind:     streamv1alpha1.SchemeGroupVersion.WithKind("KafkaGateway"),
+		})
+	}
+
+	return nil
 }
 
 func NewKafkaGatewayListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -90,12 +104,17 @@ For detail regarding the status of a single kafka gateway, run:
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s streaming kafka-gateway list", c.Name),
 			fmt.Sprintf("%s streaming kafka-gateway list %s", c.Name, cli.AllNamespacesFlagName),
+			fmt.Sprintf("%s streaming kafka-gateway list %s json", c.Name, cli.OutputFlagName),
+			fmt.Sprintf("%s streaming kafka-gateway list %s", c.Name, cli.WatchFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.AllNamespacesFlag(cmd, c, &opts.Namespace, &opts.AllNamespaces)
+	cli.OutputFlag(cmd, &opts.Output)
+	cli.WatchFlag(cmd, &opts.Watch)
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
 
 	return cmd
 }
@@ -112,8 +131,12 @@ func (opts *KafkaGatewayListOptions) printList(gateways *streamv1alpha1.KafkaGat
 	return rows, nil
 }
 
-func (opts *KafkaGatewayListOptions) print(gateway *streamv1alpha1.KafkaGateway, _ printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+func (opts *KafkaGatewayListOptions) print(gateway *streamv1alpha1.KafkaGateway, printOpts printers.PrintOptions) ([]metav1beta1.TableRow, error) {
 	now := time.Now()
+	var address string
+	if gateway.Status.Address != nil {
+		address = gateway.Status.Address.URL
+	}
 	row := metav1beta1.TableRow{
 		Object: runtime.RawExtension{Object: gateway},
 	}
@@ -123,6 +146,11 @@ func (opts *KafkaGatewayListOptions) print(gateway *streamv1alpha1.KafkaGateway,
 		cli.FormatConditionStatus(gateway.Status.GetCondition(streamv1alpha1.KafkaGatewayConditionReady)),
 		cli.FormatTimestampSince(gateway.CreationTimestamp, now),
 	)
+	if printOpts.Wide {
+		row.Cells = append(row.Cells,
+			cli.FormatEmptyString(address),
+		)
+	}
 	return []metav1beta1.TableRow{row}, nil
 }
 
@@ -132,5 +160,6 @@ func (opts *KafkaGatewayListOptions) printColumns() []metav1beta1.TableColumnDef
 		{Name: "Bootstrap Servers", Type: "string"},
 		{Name: "Status", Type: "string"},
 		{Name: "Age", Type: "string"},
+		{Name: "Address", Type: "string", Priority: 1},
 	}
 }
@@ -152,6 +152,37 @@ other-namespace   test-other-kafka-gateway   <empty>             <unknown>   <un
 			ExpectOutput: `
 NAME       BOOTSTRAP SERVERS   STATUS   AGE
 my-kafka   localhost:9092      Ready    <unknown>
+`,
+		},
+		{
+			Name: "wide output",
+			Args: []string{cli.OutputFlagName, "wide"},
+			GivenObjects: []runtime.Object{
+				&streamv1alpha1.KafkaGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      kafkaGatewayName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+NAME                 BOOTSTRAP SERVERS   STATUS      AGE         ADDRESS
+test-kafka-gateway   <empty>             <unknown>   <unknown>   <empty>
+`,
+		},
+		{
+			Name: "name output",
+			Args: []string{cli.OutputFlagName, "name"},
+			GivenObjects: []runtime.Object{
+				&streamv1alpha1.KafkaGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      kafkaGatewayName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+kafkagateway/test-kafka-gateway
 `,
 		},
 		{
@@ -20,11 +20,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type ProcessorDeleteOptions struct {
@@ -46,13 +47,23 @@ func (opts *ProcessorDeleteOptions) Validate(ctx context.Context) cli.FieldError
 
 func (opts *ProcessorDeleteOptions) Exec(ctx context.Context, c *cli.Config) error {
 	client := c.StreamingRuntime().Processors(opts.Namespace)
+	deleted := options.DeletedResource{
+		Client:      c.StreamingRuntime().RESTClient(),
+		Resource:    "processors",
+		ObjType:     &streamv1alpha1.Processor{},
+		PodLabelKey: streamv1alpha1.ProcessorLabelKey,
+	}
 
 	if opts.All {
-		if err := client.DeleteCollection(nil, metav1.ListOptions{}); err != nil {
+		names, err := opts.DeletedNames(ctx, deleted, opts.K8sListOptions())
+		if err != nil {
+			return err
+		}
+		if err := client.DeleteCollection(nil, opts.K8sListOptions()); err != nil {
 			return err
 		}
 		c.Successf("Deleted processors in namespace %q\n", opts.Namespace)
-		return nil
+		return opts.WaitForDeletion(ctx, c, deleted, names)
 	}
 
 	for _, name := range opts.Names {
@@ -62,7 +73,7 @@ func (opts *ProcessorDeleteOptions) Exec(ctx context.Context, c *cli.Config) err
 		c.Successf("Deleted processor %q\n", name)
 	}
 
-	return nil
+	return opts.WaitForDeletion(ctx, c, deleted, opts.Names)
 }
 
 func NewProcessorDeleteCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -79,7 +90,9 @@ to the output streams. The streams and messages in each stream are preserved.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s streaming processor delete my-processor", c.Name),
+			fmt.Sprintf("%s streaming processor delete my-processor %s", c.Name, cli.WaitFlagName),
 			fmt.Sprintf("%s streaming processor delete %s ", c.Name, cli.AllFlagName),
+			fmt.Sprintf("%s streaming processor delete %s %s team=payments", c.Name, cli.AllFlagName, cli.SelectorFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -91,6 +104,9 @@ to the output streams. The streams and messages in each stream are preserved.
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all processors within the namespace")
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
+	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(cli.WaitFlagName), false, "wait for the processors and their pods to be removed")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), time.Minute, "`duration` to wait for the processors to be removed")
 
 	return cmd
 }
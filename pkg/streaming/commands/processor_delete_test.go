@@ -17,14 +17,20 @@
 package commands_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
 	"github.com/projectriff/cli/pkg/streaming/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	cachetesting "k8s.io/client-go/tools/cache/testing"
 )
 
 func TestProcessorDeleteOptions(t *testing.T) {
@@ -53,6 +59,41 @@ func TestProcessorDeleteCommand(t *testing.T) {
 	processorOtherName := "test-other-processor"
 	defaultNamespace := "default"
 
+	processor := &streamv1alpha1.Processor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      processorName,
+			Namespace: defaultNamespace,
+		},
+	}
+	processorPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      processorName + "-processor-abc123",
+			Namespace: defaultNamespace,
+			Labels: map[string]string{
+				streamv1alpha1.ProcessorLabelKey: processorName,
+			},
+		},
+	}
+
+	var processorLister, podLister *cachetesting.FakeControllerSource
+	listers := func(ctx context.Context, processors, pods []runtime.Object) context.Context {
+		processorLister = cachetesting.NewFakeControllerSource()
+		for _, processor := range processors {
+			processorLister.Add(processor.DeepCopyObject())
+		}
+		podLister = cachetesting.NewFakeControllerSource()
+		for _, pod := range pods {
+			podLister.Add(pod.DeepCopyObject())
+		}
+		ctx = k8s.WithResourceListerWatcher(ctx, "processors", processorLister)
+		return k8s.WithResourceListerWatcher(ctx, "pods", podLister)
+	}
+	shutdownListers := func(t *testing.T, ctx context.Context, c *cli.Config) error {
+		processorLister.Shutdown()
+		podLister.Shutdown()
+		return nil
+	}
+
 	table := rifftesting.CommandTable{
 		{
 			Name:        "invalid args",
@@ -152,6 +193,94 @@ Deleted processor "test-processor"
 			ExpectOutput: `
 Deleted processor "test-processor"
 Deleted processor "test-other-processor"
+`,
+		},
+		{
+			Name: "delete processor and wait",
+			Args: []string{processorName, cli.WaitFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				return listers(ctx, []runtime.Object{processor}, []runtime.Object{processorPod}), nil
+			},
+			CleanUp: shutdownListers,
+			GivenObjects: []runtime.Object{
+				processor,
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				func(action clientgotesting.Action) (handled bool, ret runtime.Object, err error) {
+					if action.Matches("delete", "processors") {
+						// the pods are removed after the processor
+						processorLister, podLister := processorLister, podLister
+						go func() {
+							time.Sleep(150 * time.Millisecond)
+							processorLister.Delete(processor.DeepCopy())
+							time.Sleep(50 * time.Millisecond)
+							podLister.Delete(processorPod.DeepCopy())
+						}()
+					}
+					return false, nil, nil
+				},
+			},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "streaming.projectriff.io",
+				Resource:  "processors",
+				Namespace: defaultNamespace,
+				Name:      processorName,
+			}},
+			ExpectOutput: `
+Deleted processor "test-processor"
+`,
+		},
+		{
+			Name: "delete all processors and wait",
+			Args: []string{cli.AllFlagName, cli.WaitFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				return listers(ctx, []runtime.Object{processor}, []runtime.Object{}), nil
+			},
+			CleanUp: shutdownListers,
+			GivenObjects: []runtime.Object{
+				processor,
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				func(action clientgotesting.Action) (handled bool, ret runtime.Object, err error) {
+					if action.Matches("delete-collection", "processors") {
+						processorLister := processorLister
+						go func() {
+							time.Sleep(150 * time.Millisecond)
+							processorLister.Delete(processor.DeepCopy())
+						}()
+					}
+					return false, nil, nil
+				},
+			},
+			ExpectDeleteCollections: []rifftesting.DeleteCollectionRef{{
+				Group:     "streaming.projectriff.io",
+				Resource:  "processors",
+				Namespace: defaultNamespace,
+			}},
+			ExpectOutput: `
+Deleted processors in namespace "default"
+`,
+		},
+		{
+			Name: "delete processor wait timeout",
+			Args: []string{processorName, cli.WaitFlagName, cli.WaitTimeoutFlagName, "200ms"},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				return listers(ctx, []runtime.Object{processor}, []runtime.Object{processorPod}), nil
+			},
+			CleanUp: shutdownListers,
+			GivenObjects: []runtime.Object{
+				processor,
+			},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "streaming.projectriff.io",
+				Resource:  "processors",
+				Namespace: defaultNamespace,
+				Name:      processorName,
+			}},
+			ShouldError: true,
+			ExpectOutput: `
+Deleted processor "test-processor"
+Timeout after "200ms" waiting for test-processor to be deleted
 `,
 		},
 		{
@@ -27,7 +27,6 @@ import (
 	"github.com/projectriff/cli/pkg/cli/printers"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -50,18 +49,15 @@ func (opts *ProcessorListOptions) Validate(ctx context.Context) cli.FieldErrors
 }
 
 func (opts *ProcessorListOptions) Exec(ctx context.Context, c *cli.Config) error {
-	processors, err := c.StreamingRuntime().Processors(opts.Namespace).List(metav1.ListOptions{})
+	listOptions := opts.K8sListOptions()
+	processors, err := c.StreamingRuntime().Processors(opts.Namespace).List(listOptions)
 	if err != nil {
 		return err
 	}
 
-	if len(processors.Items) == 0 {
-		c.Infof("No processors found.\n")
-		return nil
-	}
-
 	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{
 		WithNamespace: opts.AllNamespaces,
+		Wide:          opts.Output == printers.OutputFormatWide,
 	}).With(func(h printers.PrintHandler) {
 		columns := opts.printColumns()
 		h.TableHandler(columns, opts.printList)
@@ -71,7 +67,25 @@ func (opts *ProcessorListOptions) Exec(ctx context.Context, c *cli.Config) error
 	processors = processors.DeepCopy()
 	cli.SortByNamespaceAndName(processors.Items)
 
-	return tablePrinter.PrintObj(processors, c.Stdout)
+	if len(processors.Items) == 0 && printers.IsHumanReadable(opts.Output) {
+		c.Infof("No processors found.\n")
+	} else if err := printers.PrintList(opts.Output, tablePrinter, processors, streamv1alpha1.SchemeGroupVersion.WithKind("Processor"), c.Stdout); err != nil {
+		return err
+	}
+
+	if opts.Watch {
+		return cli.WatchList(ctx, c, processors, cli.WatchListOptions{
+			Client:       c.StreamingRuntime().RESTClient(),
+			Resource:     "processors",
+			Namespace:    opts.Namespace,
+			ListOptions:  listOptions,
+			Output:       opts.Output,
+			TablePrinter: tablePrinter,
+			ItemKind:     streamv1alpha1.SchemeGroupVersion.WithKind("Processor"),
+		})
+	}
+
+	return nil
 }
 
 func NewProcessorListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -90,12 +104,17 @@ For detail regarding the status of a single processor, run:
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s streaming processor list", c.Name),
 			fmt.Sprintf("%s streaming processor list %s", c.Name, cli.AllNamespacesFlagName),
+			fmt.Sprintf("%s streaming processor list %s json", c.Name, cli.OutputFlagName),
+			fmt.Sprintf("%s streaming processor list %s", c.Name, cli.WatchFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.AllNamespacesFlag(cmd, c, &opts.Namespace, &opts.AllNamespaces)
+	cli.OutputFlag(cmd, &opts.Output)
+	cli.WatchFlag(cmd, &opts.Watch)
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
 
 	return cmd
 }
@@ -112,7 +131,7 @@ func (opts *ProcessorListOptions) printList(processors *streamv1alpha1.Processor
 	return rows, nil
 }
 
-func (opts *ProcessorListOptions) print(processor *streamv1alpha1.Processor, _ printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+func (opts *ProcessorListOptions) print(processor *streamv1alpha1.Processor, printOpts printers.PrintOptions) ([]metav1beta1.TableRow, error) {
 	now := time.Now()
 	row := metav1beta1.TableRow{
 		Object: runtime.RawExtension{Object: processor},
@@ -125,6 +144,11 @@ func (opts *ProcessorListOptions) print(processor *streamv1alpha1.Processor, _ p
 		cli.FormatConditionStatus(processor.Status.GetCondition(streamv1alpha1.ProcessorConditionReady)),
 		cli.FormatTimestampSince(processor.CreationTimestamp, now),
 	)
+	if printOpts.Wide {
+		row.Cells = append(row.Cells,
+			cli.FormatEmptyString(processor.Status.LatestImage),
+		)
+	}
 	return []metav1beta1.TableRow{row}, nil
 }
 
@@ -144,6 +168,7 @@ func (opts *ProcessorListOptions) printColumns() []metav1beta1.TableColumnDefini
 		{Name: "Outputs", Type: "string"},
 		{Name: "Status", Type: "string"},
 		{Name: "Age", Type: "string"},
+		{Name: "Latest Image", Type: "string", Priority: 1},
 	}
 }
 
@@ -154,6 +154,37 @@ other-namespace   test-other-processor   <empty>    <empty>   <empty>   <unknown
 			ExpectOutput: `
 NAME     FUNCTION   INPUTS                       OUTPUTS     STATUS   AGE
 square   square     n1:numbers, n2:morenumbers   s:squares   Ready    <unknown>
+`,
+		},
+		{
+			Name: "wide output",
+			Args: []string{cli.OutputFlagName, "wide"},
+			GivenObjects: []runtime.Object{
+				&streamv1alpha1.Processor{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      processorName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+NAME             FUNCTION   INPUTS    OUTPUTS   STATUS      AGE         LATEST IMAGE
+test-processor   <empty>    <empty>   <empty>   <unknown>   <unknown>   <empty>
+`,
+		},
+		{
+			Name: "name output",
+			Args: []string{cli.OutputFlagName, "name"},
+			GivenObjects: []runtime.Object{
+				&streamv1alpha1.Processor{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      processorName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+processor/test-processor
 `,
 		},
 		{
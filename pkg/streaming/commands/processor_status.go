@@ -23,6 +23,8 @@ import (
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/projectriff/cli/pkg/validation"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -31,6 +33,8 @@ import (
 
 type ProcessorStatusOptions struct {
 	options.ResourceOptions
+
+	Output string
 }
 
 var (
@@ -43,6 +47,8 @@ func (opts *ProcessorStatusOptions) Validate(ctx context.Context) cli.FieldError
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
 
+	errs = errs.Also(validation.StructuredOutputFormat(opts.Output, cli.OutputFlagName))
+
 	return errs
 }
 
@@ -56,10 +62,25 @@ func (opts *ProcessorStatusOptions) Exec(ctx context.Context, c *cli.Config) err
 		return cli.SilenceError(err)
 	}
 
+	if opts.Output != "" {
+		return printers.PrintObject(opts.Output, processor, streamv1alpha1.SchemeGroupVersion.WithKind("Processor"), c.Stdout)
+	}
+
 	ready := processor.Status.GetCondition(streamv1alpha1.ProcessorConditionReady)
 	cli.PrintResourceStatus(c, processor.Name, ready)
 
-	return nil
+	return cli.PrintResourceStatusDetails(c, cli.ResourceStatusDetails{
+		GroupVersionKind: processor.GetGroupVersionKind(),
+		Namespace:        processor.Namespace,
+		Name:             processor.Name,
+		Conditions:       processor.Status.Conditions,
+		Details: []cli.StatusDetail{
+			{Name: "Latest Image", Value: processor.Status.LatestImage},
+			{Name: "Scaled Object", Value: cli.StatusRefName(processor.Status.ScaledObjectRef)},
+		},
+		PodSelector: fmt.Sprintf("%s=%s", streamv1alpha1.ProcessorLabelKey, processor.Name),
+		Deployment:  cli.StatusRefName(processor.Status.DeploymentRef),
+	})
 }
 
 func NewProcessorStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -75,9 +96,13 @@ The Ready condition is shown which should include a reason code and a
 descriptive message when the status is not "True". The status for the condition
 may be: "True", "False" or "Unknown". An "Unknown" status is common while the
 processor roll out is processed.
+
+Key fields from the status, every condition and the most recent events for
+the processor are shown after the Ready condition.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s streaming processor status my-processor", c.Name),
+			fmt.Sprintf("%s streaming processor status my-processor %s jsonpath={.status.latestImage}", c.Name, cli.OutputFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -88,6 +113,7 @@ processor roll out is processed.
 	)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cli.StructuredOutputFlag(cmd, &opts.Output)
 
 	return cmd
 }
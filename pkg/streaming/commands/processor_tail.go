@@ -19,19 +19,28 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/kail"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
+	"github.com/projectriff/system/pkg/refs"
 	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type ProcessorTailOptions struct {
 	options.ResourceOptions
+	options.TailOptions
 
-	Since string
+	Since            string
+	Follow           bool
+	Limit            int64
+	WithDependencies bool
 }
 
 var (
@@ -43,12 +52,19 @@ func (opts *ProcessorTailOptions) Validate(ctx context.Context) cli.FieldErrors
 	errs := cli.FieldErrors{}
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+	errs = errs.Also(opts.TailOptions.Validate(ctx, cli.OutputFlagName))
 
 	if opts.Since != "" {
 		if _, err := time.ParseDuration(opts.Since); err != nil {
 			errs = errs.Also(cli.ErrInvalidValue(opts.Since, cli.SinceFlagName))
 		}
 	}
+	if opts.Limit < 0 {
+		errs = errs.Also(cli.ErrInvalidValue(opts.Limit, cli.LimitFlagName))
+	}
+	if opts.Limit != 0 && opts.Follow {
+		errs = errs.Also(cli.ErrMultipleOneOf(cli.FollowFlagName, cli.LimitFlagName))
+	}
 
 	return errs
 }
@@ -59,11 +75,181 @@ func (opts *ProcessorTailOptions) Exec(ctx context.Context, c *cli.Config) error
 		return err
 	}
 	since := cli.TailSinceDefault
+	if !opts.Follow {
+		// print every existing log line unless bounded
+		since = 0
+	}
 	if opts.Since != "" {
 		// error is protected by Validate()
 		since, _ = time.ParseDuration(opts.Since)
 	}
-	return c.Kail.StreamingProcessorLogs(ctx, processor, since, c.Stdout)
+	logOptions := opts.LogOptions(since)
+	logOptions.Follow = opts.Follow
+	logOptions.Limit = opts.Limit
+	if !opts.WithDependencies {
+		return c.Kail.StreamingProcessorLogs(ctx, processor, logOptions, c.Stdout)
+	}
+
+	sources, err := processorLogSources(c, processor)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	out := kail.NewSyncWriter(c.Stdout)
+	errs := make(chan error, len(sources))
+	for _, source := range sources {
+		sourceOptions := logOptions
+		sourceOptions.Prefix = source.prefix
+		if !source.processor {
+			// selected containers only apply to the processor
+			sourceOptions.Containers = nil
+		}
+		go func(source processorLogSource, sourceOptions kail.LogOptions) {
+			errs <- source.logs(ctx, sourceOptions, out)
+		}(source, sourceOptions)
+	}
+	for range sources {
+		if sourceErr := <-errs; sourceErr != nil && err == nil {
+			err = sourceErr
+			// stop streaming from the remaining sources
+			cancel()
+		}
+	}
+	return err
+}
+
+// processorLogSource streams the logs for a processor or one of the resources
+// it depends on
+type processorLogSource struct {
+	prefix    string
+	processor bool
+	logs      func(ctx context.Context, opts kail.LogOptions, out io.Writer) error
+}
+
+// processorLogSources resolves the processor, the build for its function and
+// the gateways for its input and output streams. Dependencies that are not
+// found are skipped.
+func processorLogSources(c *cli.Config, processor *streamv1alpha1.Processor) ([]processorLogSource, error) {
+	sources := []processorLogSource{
+		{
+			prefix:    fmt.Sprintf("processor/%s", processor.Name),
+			processor: true,
+			logs: func(ctx context.Context, opts kail.LogOptions, out io.Writer) error {
+				return c.Kail.StreamingProcessorLogs(ctx, processor, opts, out)
+			},
+		},
+	}
+
+	if processor.Spec.Build != nil && processor.Spec.Build.FunctionRef != "" {
+		function, err := c.Build().Functions(processor.Namespace).Get(processor.Spec.Build.FunctionRef, metav1.GetOptions{})
+		if err != nil {
+			if !apierrs.IsNotFound(err) {
+				return nil, err
+			}
+			c.Einfof("Skipping logs for function %q, not found\n", processor.Spec.Build.FunctionRef)
+		} else {
+			sources = append(sources, processorLogSource{
+				prefix: fmt.Sprintf("function/%s", function.Name),
+				logs: func(ctx context.Context, opts kail.LogOptions, out io.Writer) error {
+					return c.Kail.FunctionLogs(ctx, function, opts, out)
+				},
+			})
+		}
+	}
+
+	streams := []string{}
+	for _, input := range processor.Spec.Inputs {
+		streams = append(streams, input.Stream)
+	}
+	for _, output := range processor.Spec.Outputs {
+		streams = append(streams, output.Stream)
+	}
+	gateways := map[string]bool{}
+	for _, name := range streams {
+		stream, err := c.StreamingRuntime().Streams(processor.Namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			if !apierrs.IsNotFound(err) {
+				return nil, err
+			}
+			c.Einfof("Skipping logs for stream %q, not found\n", name)
+			continue
+		}
+		gateway := stream.Spec.Gateway.Name
+		if gateways[gateway] {
+			continue
+		}
+		gateways[gateway] = true
+		source, err := gatewayLogSource(c, processor.Namespace, gateway)
+		if err != nil {
+			return nil, err
+		}
+		if source == nil {
+			c.Einfof("Skipping logs for gateway %q, not found\n", gateway)
+			continue
+		}
+		sources = append(sources, *source)
+	}
+
+	return sources, nil
+}
+
+// gatewayLogSource finds the gateway resource that provisions the named
+// gateway, returning nil if none match
+func gatewayLogSource(c *cli.Config, namespace, name string) (*processorLogSource, error) {
+	provisions := func(gateway metav1.Object, gatewayRef *refs.TypedLocalObjectReference) bool {
+		return gateway.GetName() == name || (gatewayRef != nil && gatewayRef.Name == name)
+	}
+
+	inmemoryGateways, err := c.StreamingRuntime().InMemoryGateways(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range inmemoryGateways.Items {
+		gateway := &inmemoryGateways.Items[i]
+		if provisions(gateway, gateway.Status.GatewayRef) {
+			return &processorLogSource{
+				prefix: fmt.Sprintf("inmemory-gateway/%s", gateway.Name),
+				logs: func(ctx context.Context, opts kail.LogOptions, out io.Writer) error {
+					return c.Kail.InMemoryGatewayLogs(ctx, gateway, opts, out)
+				},
+			}, nil
+		}
+	}
+
+	kafkaGateways, err := c.StreamingRuntime().KafkaGateways(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range kafkaGateways.Items {
+		gateway := &kafkaGateways.Items[i]
+		if provisions(gateway, gateway.Status.GatewayRef) {
+			return &processorLogSource{
+				prefix: fmt.Sprintf("kafka-gateway/%s", gateway.Name),
+				logs: func(ctx context.Context, opts kail.LogOptions, out io.Writer) error {
+					return c.Kail.KafkaGatewayLogs(ctx, gateway, opts, out)
+				},
+			}, nil
+		}
+	}
+
+	pulsarGateways, err := c.StreamingRuntime().PulsarGateways(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pulsarGateways.Items {
+		gateway := &pulsarGateways.Items[i]
+		if provisions(gateway, gateway.Status.GatewayRef) {
+			return &processorLogSource{
+				prefix: fmt.Sprintf("pulsar-gateway/%s", gateway.Name),
+				logs: func(ctx context.Context, opts kail.LogOptions, out io.Writer) error {
+					return c.Kail.PulsarGatewayLogs(ctx, gateway, opts, out)
+				},
+			}, nil
+		}
+	}
+
+	return nil, nil
 }
 
 func NewProcessorTailCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -78,10 +264,22 @@ the shell or kill the process.
 
 As new processor pods are started, the logs are displayed. To show historical
 logs use ` + cli.SinceFlagName + `.
+
+To print the existing logs and exit rather than waiting for new logs use
+` + cli.FollowFlagName + `=false.
+
+To debug the whole pipeline use ` + cli.WithDependenciesFlagName + `. The logs of the processor are
+merged with the logs of its function build and the gateways for its input and
+output streams.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s streaming processor tail my-processor", c.Name),
 			fmt.Sprintf("%s streaming processor tail my-processor %s 1h", c.Name, cli.SinceFlagName),
+			fmt.Sprintf("%s streaming processor tail my-processor %s=false %s 100", c.Name, cli.FollowFlagName, cli.LimitFlagName),
+			fmt.Sprintf("%s streaming processor tail my-processor %s json %s ERROR", c.Name, cli.OutputFlagName, cli.GrepFlagName),
+			fmt.Sprintf("%s streaming processor tail my-processor %s processor", c.Name, cli.ContainerFlagName),
+			fmt.Sprintf("%s streaming processor tail my-processor %s %s", c.Name, cli.ContainerFlagName, kail.AllContainers),
+			fmt.Sprintf("%s streaming processor tail my-processor %s", c.Name, cli.WithDependenciesFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -93,6 +291,11 @@ logs use ` + cli.SinceFlagName + `.
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().StringVar(&opts.Since, cli.StripDash(cli.SinceFlagName), "", "time `duration` to start reading logs from")
+	cmd.Flags().BoolVar(&opts.Follow, cli.StripDash(cli.FollowFlagName), true, "stream new log lines until canceled, when false the existing log lines are printed")
+	cmd.Flags().Int64Var(&opts.Limit, cli.StripDash(cli.LimitFlagName), 0, fmt.Sprintf("`number` of the most recent log lines to print for each container, requires %s=false", cli.FollowFlagName))
+	cmd.Flags().StringArrayVarP(&opts.Containers, cli.StripDash(cli.ContainerFlagName), "c", []string{}, fmt.Sprintf("`name` of the container to stream logs from, or %q for every container (may be set multiple times)", kail.AllContainers))
+	cmd.Flags().BoolVar(&opts.WithDependencies, cli.StripDash(cli.WithDependenciesFlagName), false, "merge the logs of the function build and the gateways for the input and output streams, each line is prefixed by its source")
+	cli.TailFlags(cmd, cli.OutputFlagName, &opts.Output, &opts.Grep, &opts.Timestamps)
 	cmd.Flag(cli.StripDash(cli.SinceFlagName)).Hidden = true
 
 	return cmd
@@ -23,11 +23,15 @@ import (
 	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
+	riffkail "github.com/projectriff/cli/pkg/kail"
 	"github.com/projectriff/cli/pkg/streaming/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	kailtesting "github.com/projectriff/cli/pkg/testing/kail"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -64,6 +68,26 @@ func TestProcessorTailOptions(t *testing.T) {
 			},
 			ExpectFieldErrors: cli.ErrInvalidValue("1", cli.SinceFlagName),
 		},
+		{
+			Name: "containers",
+			Options: &commands.ProcessorTailOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				TailOptions: options.TailOptions{
+					Containers: []string{"processor"},
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid container",
+			Options: &commands.ProcessorTailOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				TailOptions: options.TailOptions{
+					Containers: []string{"processor", "Not_A_Container"},
+				},
+			},
+			ExpectFieldErrors: cli.ErrInvalidArrayValue("Not_A_Container", cli.ContainerFlagName, 1),
+		},
 	}
 
 	table.Run(t)
@@ -78,6 +102,53 @@ func TestProcessorTailCommand(t *testing.T) {
 			Name:      processorName,
 		},
 	}
+	processorWithDependencies := &streamv1alpha1.Processor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      processorName,
+		},
+		Spec: streamv1alpha1.ProcessorSpec{
+			Build: &streamv1alpha1.Build{
+				FunctionRef: "my-function",
+			},
+			Inputs: []streamv1alpha1.InputStreamBinding{
+				{Stream: "my-input"},
+			},
+			Outputs: []streamv1alpha1.OutputStreamBinding{
+				{Stream: "my-output"},
+			},
+		},
+	}
+	function := &buildv1alpha1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      "my-function",
+		},
+	}
+	inputStream := &streamv1alpha1.Stream{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      "my-input",
+		},
+		Spec: streamv1alpha1.StreamSpec{
+			Gateway: corev1.LocalObjectReference{Name: "my-gateway"},
+		},
+	}
+	outputStream := &streamv1alpha1.Stream{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      "my-output",
+		},
+		Spec: streamv1alpha1.StreamSpec{
+			Gateway: corev1.LocalObjectReference{Name: "my-gateway"},
+		},
+	}
+	gateway := &streamv1alpha1.KafkaGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      "my-gateway",
+		},
+	}
 
 	table := rifftesting.CommandTable{
 		{
@@ -91,7 +162,7 @@ func TestProcessorTailCommand(t *testing.T) {
 			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
 				kail := &kailtesting.Logger{}
 				c.Kail = kail
-				kail.On("StreamingProcessorLogs", mock.Anything, processor, cli.TailSinceDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				kail.On("StreamingProcessorLogs", mock.Anything, processor, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -114,7 +185,7 @@ func TestProcessorTailCommand(t *testing.T) {
 			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
 				kail := &kailtesting.Logger{}
 				c.Kail = kail
-				kail.On("StreamingProcessorLogs", mock.Anything, processor, time.Hour, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				kail.On("StreamingProcessorLogs", mock.Anything, processor, riffkail.LogOptions{Since: time.Hour, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -131,6 +202,126 @@ func TestProcessorTailCommand(t *testing.T) {
 ...log output...
 `,
 		},
+		{
+			Name: "show logs for container",
+			Args: []string{processorName, cli.ContainerFlagName, "processor"},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("StreamingProcessorLogs", mock.Anything, processor, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true, Containers: []string{"processor"}}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fmt.Fprintf(c.Stdout, "...log output...\n")
+				})
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				processor,
+			},
+			ExpectOutput: `
+...log output...
+`,
+		},
+		{
+			Name: "show logs for all containers",
+			Args: []string{processorName, cli.ContainerFlagName, riffkail.AllContainers},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("StreamingProcessorLogs", mock.Anything, processor, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true, Containers: []string{riffkail.AllContainers}}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fmt.Fprintf(c.Stdout, "...log output...\n")
+				})
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				processor,
+			},
+			ExpectOutput: `
+...log output...
+`,
+		},
+		{
+			Name: "show logs with dependencies",
+			Args: []string{processorName, cli.WithDependenciesFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("StreamingProcessorLogs", mock.Anything, processorWithDependencies, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true, Prefix: "processor/my-processor"}, mock.Anything).Return(nil)
+				kail.On("FunctionLogs", mock.Anything, function, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true, Prefix: "function/my-function"}, mock.Anything).Return(nil)
+				kail.On("KafkaGatewayLogs", mock.Anything, gateway, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true, Prefix: "kafka-gateway/my-gateway"}, mock.Anything).Return(nil)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				processorWithDependencies,
+				function,
+				inputStream,
+				outputStream,
+				gateway,
+			},
+		},
+		{
+			Name: "show logs with missing dependencies",
+			Args: []string{processorName, cli.WithDependenciesFlagName, cli.ContainerFlagName, "processor"},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("StreamingProcessorLogs", mock.Anything, processorWithDependencies, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true, Containers: []string{"processor"}, Prefix: "processor/my-processor"}, mock.Anything).Return(nil)
+				kail.On("KafkaGatewayLogs", mock.Anything, gateway, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true, Prefix: "kafka-gateway/my-gateway"}, mock.Anything).Return(nil)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				processorWithDependencies,
+				inputStream,
+				gateway,
+			},
+			ExpectOutput: `
+Skipping logs for function "my-function", not found
+Skipping logs for stream "my-output", not found
+`,
+		},
+		{
+			Name: "dependency kail error",
+			Args: []string{processorName, cli.WithDependenciesFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				kail := &kailtesting.Logger{}
+				c.Kail = kail
+				kail.On("StreamingProcessorLogs", mock.Anything, processorWithDependencies, mock.Anything, mock.Anything).Return(nil)
+				kail.On("FunctionLogs", mock.Anything, function, mock.Anything, mock.Anything).Return(fmt.Errorf("kail error"))
+				kail.On("KafkaGatewayLogs", mock.Anything, gateway, mock.Anything, mock.Anything).Return(nil)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				kail := c.Kail.(*kailtesting.Logger)
+				kail.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				processorWithDependencies,
+				function,
+				inputStream,
+				outputStream,
+				gateway,
+			},
+			ShouldError: true,
+		},
 		{
 			Name:        "unknown processor",
 			Args:        []string{processorName},
@@ -142,7 +333,7 @@ func TestProcessorTailCommand(t *testing.T) {
 			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
 				kail := &kailtesting.Logger{}
 				c.Kail = kail
-				kail.On("StreamingProcessorLogs", mock.Anything, processor, cli.TailSinceDefault, mock.Anything).Return(fmt.Errorf("kail error"))
+				kail.On("StreamingProcessorLogs", mock.Anything, processor, riffkail.LogOptions{Since: cli.TailSinceDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
 				return ctx, nil
 			},
 			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
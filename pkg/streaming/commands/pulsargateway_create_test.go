@@ -29,6 +29,7 @@ import (
 	cachetesting "k8s.io/client-go/tools/cache/testing"
 
 	"github.com/projectriff/cli/pkg/cli"
+	riffkail "github.com/projectriff/cli/pkg/kail"
 	"github.com/projectriff/cli/pkg/streaming/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
@@ -115,6 +116,26 @@ func TestPulsarGatewayCreateCommand(t *testing.T) {
 			},
 			ExpectOutput: `
 Created pulsar gateway "my-pulsar-gateway"
+`,
+		},
+		{
+			Name: "create with labels and annotations",
+			Args: []string{pulsarGatewayName, cli.ServiceURLFlagName, serviceURL, cli.LabelFlagName, "team=payments", cli.AnnotationFlagName, "owner=jane@example.com"},
+			ExpectCreates: []runtime.Object{
+				&streamv1alpha1.PulsarGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   defaultNamespace,
+						Name:        pulsarGatewayName,
+						Labels:      map[string]string{"team": "payments"},
+						Annotations: map[string]string{"owner": "jane@example.com"},
+					},
+					Spec: streamv1alpha1.PulsarGatewaySpec{
+						ServiceURL: serviceURL,
+					},
+				},
+			},
+			ExpectOutput: `
+Created pulsar gateway "my-pulsar-gateway"
 `,
 		},
 		{
@@ -195,7 +216,7 @@ Created pulsar gateway "my-pulsar-gateway"
 					Spec: streamv1alpha1.PulsarGatewaySpec{
 						ServiceURL: "some-host",
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 				})
 				return ctx, nil
@@ -243,7 +264,7 @@ PulsarGateway "franz" is ready
 					Spec: streamv1alpha1.PulsarGatewaySpec{
 						ServiceURL: "some-host",
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fmt.Fprintf(c.Stdout, "...log output...\n")
 					// wait for context to be cancelled
 					<-args[0].(context.Context).Done()
@@ -300,7 +321,7 @@ To view status run: riff streaming pulsar-gateway list --namespace default
 					Spec: streamv1alpha1.PulsarGatewaySpec{
 						ServiceURL: "some-host",
 					},
-				}, cli.TailSinceCreateDefault, mock.Anything).Return(fmt.Errorf("kail error"))
+				}, riffkail.LogOptions{Since: cli.TailSinceCreateDefault, Follow: true}, mock.Anything).Return(fmt.Errorf("kail error"))
 				return ctx, nil
 			},
 			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
@@ -20,11 +20,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type PulsarGatewayDeleteOptions struct {
@@ -46,13 +47,23 @@ func (opts *PulsarGatewayDeleteOptions) Validate(ctx context.Context) cli.FieldE
 
 func (opts *PulsarGatewayDeleteOptions) Exec(ctx context.Context, c *cli.Config) error {
 	client := c.StreamingRuntime().PulsarGateways(opts.Namespace)
+	deleted := options.DeletedResource{
+		Client:      c.StreamingRuntime().RESTClient(),
+		Resource:    "pulsargateways",
+		ObjType:     &streamv1alpha1.PulsarGateway{},
+		PodLabelKey: streamv1alpha1.PulsarGatewayLabelKey,
+	}
 
 	if opts.All {
-		if err := client.DeleteCollection(nil, metav1.ListOptions{}); err != nil {
+		names, err := opts.DeletedNames(ctx, deleted, opts.K8sListOptions())
+		if err != nil {
+			return err
+		}
+		if err := client.DeleteCollection(nil, opts.K8sListOptions()); err != nil {
 			return err
 		}
 		c.Successf("Deleted pulsar gateways in namespace %q\n", opts.Namespace)
-		return nil
+		return opts.WaitForDeletion(ctx, c, deleted, names)
 	}
 
 	for _, name := range opts.Names {
@@ -62,7 +73,7 @@ func (opts *PulsarGatewayDeleteOptions) Exec(ctx context.Context, c *cli.Config)
 		c.Successf("Deleted pulsar gateway %q\n", name)
 	}
 
-	return nil
+	return opts.WaitForDeletion(ctx, c, deleted, opts.Names)
 }
 
 func NewPulsarGatewayDeleteCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -81,7 +92,9 @@ underlying pulsar broker, depending on the implementation.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s streaming pulsar-gateway delete my-pulsar-gateway", c.Name),
+			fmt.Sprintf("%s streaming pulsar-gateway delete my-pulsar-gateway %s", c.Name, cli.WaitFlagName),
 			fmt.Sprintf("%s streaming pulsar-gateway delete %s ", c.Name, cli.AllFlagName),
+			fmt.Sprintf("%s streaming pulsar-gateway delete %s %s team=payments", c.Name, cli.AllFlagName, cli.SelectorFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -93,6 +106,9 @@ underlying pulsar broker, depending on the implementation.
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all pulsar gateways within the namespace")
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
+	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(cli.WaitFlagName), false, "wait for the pulsar gateways and their pods to be removed")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), time.Minute, "`duration` to wait for the pulsar gateways to be removed")
 
 	return cmd
 }
@@ -17,14 +17,19 @@
 package commands_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
 	"github.com/projectriff/cli/pkg/streaming/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	cachetesting "k8s.io/client-go/tools/cache/testing"
 )
 
 func TestPulsarGatewayDeleteOptions(t *testing.T) {
@@ -53,6 +58,30 @@ func TestPulsarGatewayDeleteCommand(t *testing.T) {
 	pulsarGatewayOtherName := "test-other-pulsar-gateway"
 	defaultNamespace := "default"
 
+	gateway := &streamv1alpha1.PulsarGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pulsarGatewayName,
+			Namespace: defaultNamespace,
+		},
+	}
+
+	var gatewayLister, podLister *cachetesting.FakeControllerSource
+	listers := func(ctx context.Context, gateways []runtime.Object) context.Context {
+		gatewayLister = cachetesting.NewFakeControllerSource()
+		for _, gateway := range gateways {
+			gatewayLister.Add(gateway.DeepCopyObject())
+		}
+		podLister = cachetesting.NewFakeControllerSource()
+		// the lister watcher is found by the resource name used by the api server
+		ctx = k8s.WithResourceListerWatcher(ctx, "pulsargateways", gatewayLister)
+		return k8s.WithResourceListerWatcher(ctx, "pods", podLister)
+	}
+	shutdownListers := func(t *testing.T, ctx context.Context, c *cli.Config) error {
+		gatewayLister.Shutdown()
+		podLister.Shutdown()
+		return nil
+	}
+
 	table := rifftesting.CommandTable{
 		{
 			Name:        "invalid args",
@@ -152,6 +181,38 @@ Deleted pulsar gateway "test-pulsar-gateway"
 			ExpectOutput: `
 Deleted pulsar gateway "test-pulsar-gateway"
 Deleted pulsar gateway "test-other-pulsar-gateway"
+`,
+		},
+		{
+			Name: "delete pulsar gateway and wait",
+			Args: []string{pulsarGatewayName, cli.WaitFlagName, cli.WaitTimeoutFlagName, "5s"},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				return listers(ctx, []runtime.Object{gateway}), nil
+			},
+			CleanUp: shutdownListers,
+			GivenObjects: []runtime.Object{
+				gateway,
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				func(action clientgotesting.Action) (handled bool, ret runtime.Object, err error) {
+					if action.Matches("delete", "pulsargatewaies") {
+						gatewayLister := gatewayLister
+						go func() {
+							time.Sleep(150 * time.Millisecond)
+							gatewayLister.Delete(gateway.DeepCopy())
+						}()
+					}
+					return false, nil, nil
+				},
+			},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "streaming.projectriff.io",
+				Resource:  "pulsargatewaies",
+				Namespace: defaultNamespace,
+				Name:      pulsarGatewayName,
+			}},
+			ExpectOutput: `
+Deleted pulsar gateway "test-pulsar-gateway"
 `,
 		},
 		{
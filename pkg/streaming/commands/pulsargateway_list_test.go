@@ -152,6 +152,37 @@ other-namespace   test-other-pulsar-gateway   <empty>       <unknown>   <unknown
 			ExpectOutput: `
 NAME        SERVICE URL               STATUS   AGE
 my-pulsar   pulsar://localhost:6650   Ready    <unknown>
+`,
+		},
+		{
+			Name: "wide output",
+			Args: []string{cli.OutputFlagName, "wide"},
+			GivenObjects: []runtime.Object{
+				&streamv1alpha1.PulsarGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      pulsarGatewayName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+NAME                  SERVICE URL   STATUS      AGE         ADDRESS
+test-pulsar-gateway   <empty>       <unknown>   <unknown>   <empty>
+`,
+		},
+		{
+			Name: "name output",
+			Args: []string{cli.OutputFlagName, "name"},
+			GivenObjects: []runtime.Object{
+				&streamv1alpha1.PulsarGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      pulsarGatewayName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+pulsargateway/test-pulsar-gateway
 `,
 		},
 		{
@@ -25,6 +25,7 @@ import (
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
 	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/parsers"
 	"github.com/projectriff/cli/pkg/race"
 	"github.com/projectriff/cli/pkg/validation"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
@@ -36,6 +37,9 @@ import (
 type StreamCreateOptions struct {
 	options.ResourceOptions
 
+	Labels      []string
+	Annotations []string
+
 	Gateway     string
 	ContentType string
 
@@ -55,6 +59,8 @@ func (opts *StreamCreateOptions) Validate(ctx context.Context) cli.FieldErrors {
 	errs := cli.FieldErrors{}
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+	errs = errs.Also(validation.Labels(opts.Labels, cli.LabelFlagName))
+	errs = errs.Also(validation.Annotations(opts.Annotations, cli.AnnotationFlagName))
 
 	if opts.Gateway == "" {
 		errs = errs.Also(cli.ErrMissingField(cli.GatewayFlagName))
@@ -77,8 +83,10 @@ func (opts *StreamCreateOptions) Validate(ctx context.Context) cli.FieldErrors {
 func (opts *StreamCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
 	stream := &streamv1alpha1.Stream{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: opts.Namespace,
-			Name:      opts.Name,
+			Namespace:   opts.Namespace,
+			Name:        opts.Name,
+			Labels:      parsers.KeyValues(opts.Labels),
+			Annotations: parsers.KeyValues(opts.Annotations),
 		},
 		Spec: streamv1alpha1.StreamSpec{
 			Gateway:     corev1.LocalObjectReference{Name: opts.Gateway},
@@ -142,6 +150,8 @@ func NewStreamCreateCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	_ = cmd.MarkFlagCustom(cli.StripDash(cli.GatewayFlagName), "__"+c.Name+"_list_streaming_gateways")
 	cmd.Flags().StringVar(&opts.ContentType, cli.StripDash(cli.ContentTypeFlagName), "", "`MIME type` for message payloads accepted by the stream")
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().StringArrayVar(&opts.Labels, cli.StripDash(cli.LabelFlagName), []string{}, fmt.Sprintf("`label` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s team=payments", cli.LabelFlagName)))
+	cmd.Flags().StringArrayVar(&opts.Annotations, cli.StripDash(cli.AnnotationFlagName), []string{}, fmt.Sprintf("`annotation` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s owner=jane@example.com", cli.AnnotationFlagName)))
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch provisioning progress")
 	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), time.Second*10, "`duration` to wait for the stream to become ready when watching progress")
 
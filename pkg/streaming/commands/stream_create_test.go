@@ -143,6 +143,27 @@ func TestStreamCreateCommand(t *testing.T) {
 			},
 			ExpectOutput: `
 Created stream "my-stream"
+`,
+		},
+		{
+			Name: "stream with labels and annotations",
+			Args: []string{streamName, cli.GatewayFlagName, gateway, cli.LabelFlagName, "team=payments", cli.AnnotationFlagName, "owner=jane@example.com"},
+			ExpectCreates: []runtime.Object{
+				&streamv1alpha1.Stream{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   defaultNamespace,
+						Name:        streamName,
+						Labels:      map[string]string{"team": "payments"},
+						Annotations: map[string]string{"owner": "jane@example.com"},
+					},
+					Spec: streamv1alpha1.StreamSpec{
+						Gateway:     corev1.LocalObjectReference{Name: gateway},
+						ContentType: defaultContentType,
+					},
+				},
+			},
+			ExpectOutput: `
+Created stream "my-stream"
 `,
 		},
 		{
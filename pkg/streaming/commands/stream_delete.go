@@ -20,11 +20,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type StreamDeleteOptions struct {
@@ -46,13 +47,22 @@ func (opts *StreamDeleteOptions) Validate(ctx context.Context) cli.FieldErrors {
 
 func (opts *StreamDeleteOptions) Exec(ctx context.Context, c *cli.Config) error {
 	client := c.StreamingRuntime().Streams(opts.Namespace)
+	deleted := options.DeletedResource{
+		Client:   c.StreamingRuntime().RESTClient(),
+		Resource: "streams",
+		ObjType:  &streamv1alpha1.Stream{},
+	}
 
 	if opts.All {
-		if err := client.DeleteCollection(nil, metav1.ListOptions{}); err != nil {
+		names, err := opts.DeletedNames(ctx, deleted, opts.K8sListOptions())
+		if err != nil {
+			return err
+		}
+		if err := client.DeleteCollection(nil, opts.K8sListOptions()); err != nil {
 			return err
 		}
 		c.Successf("Deleted streams in namespace %q\n", opts.Namespace)
-		return nil
+		return opts.WaitForDeletion(ctx, c, deleted, names)
 	}
 
 	for _, name := range opts.Names {
@@ -62,7 +72,7 @@ func (opts *StreamDeleteOptions) Exec(ctx context.Context, c *cli.Config) error
 		c.Successf("Deleted stream %q\n", name)
 	}
 
-	return nil
+	return opts.WaitForDeletion(ctx, c, deleted, opts.Names)
 }
 
 func NewStreamDeleteCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -80,7 +90,9 @@ messaging middleware, depending on the implementation.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s streaming stream delete my-stream", c.Name),
+			fmt.Sprintf("%s streaming stream delete my-stream %s", c.Name, cli.WaitFlagName),
 			fmt.Sprintf("%s streaming stream delete %s ", c.Name, cli.AllFlagName),
+			fmt.Sprintf("%s streaming stream delete %s %s team=payments", c.Name, cli.AllFlagName, cli.SelectorFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -92,6 +104,9 @@ messaging middleware, depending on the implementation.
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all streams within the namespace")
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
+	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(cli.WaitFlagName), false, "wait for the streams to be removed")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), time.Minute, "`duration` to wait for the streams to be removed")
 
 	return cmd
 }
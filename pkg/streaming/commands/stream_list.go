@@ -27,7 +27,6 @@ import (
 	"github.com/projectriff/cli/pkg/cli/printers"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -50,18 +49,15 @@ func (opts *StreamListOptions) Validate(ctx context.Context) cli.FieldErrors {
 }
 
 func (opts *StreamListOptions) Exec(ctx context.Context, c *cli.Config) error {
-	streams, err := c.StreamingRuntime().Streams(opts.Namespace).List(metav1.ListOptions{})
+	listOptions := opts.K8sListOptions()
+	streams, err := c.StreamingRuntime().Streams(opts.Namespace).List(listOptions)
 	if err != nil {
 		return err
 	}
 
-	if len(streams.Items) == 0 {
-		c.Infof("No streams found.\n")
-		return nil
-	}
-
 	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{
 		WithNamespace: opts.AllNamespaces,
+		Wide:          opts.Output == printers.OutputFormatWide,
 	}).With(func(h printers.PrintHandler) {
 		columns := opts.printColumns()
 		h.TableHandler(columns, opts.printList)
@@ -71,7 +67,25 @@ func (opts *StreamListOptions) Exec(ctx context.Context, c *cli.Config) error {
 	streams = streams.DeepCopy()
 	cli.SortByNamespaceAndName(streams.Items)
 
-	return tablePrinter.PrintObj(streams, c.Stdout)
+	if len(streams.Items) == 0 && printers.IsHumanReadable(opts.Output) {
+		c.Infof("No streams found.\n")
+	} else if err := printers.PrintList(opts.Output, tablePrinter, streams, streamv1alpha1.SchemeGroupVersion.WithKind("Stream"), c.Stdout); err != nil {
+		return err
+	}
+
+	if opts.Watch {
+		return cli.WatchList(ctx, c, streams, cli.WatchListOptions{
+			Client:       c.StreamingRuntime().RESTClient(),
+			Resource:     "streams",
+			Namespace:    opts.Namespace,
+			ListOptions:  listOptions,
+			Output:       opts.Output,
+			TablePrinter: tablePrinter,
+			ItemKind:     streamv1alpha1.SchemeGroupVersion.WithKind("Stream"),
+		})
+	}
+
+	return nil
 }
 
 func NewStreamListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -90,12 +104,17 @@ For detail regarding the status of a single stream, run:
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s streaming stream list", c.Name),
 			fmt.Sprintf("%s streaming stream list %s", c.Name, cli.AllNamespacesFlagName),
+			fmt.Sprintf("%s streaming stream list %s json", c.Name, cli.OutputFlagName),
+			fmt.Sprintf("%s streaming stream list %s", c.Name, cli.WatchFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.AllNamespacesFlag(cmd, c, &opts.Namespace, &opts.AllNamespaces)
+	cli.OutputFlag(cmd, &opts.Output)
+	cli.WatchFlag(cmd, &opts.Watch)
+	cli.SelectorFlags(cmd, &opts.Selector, &opts.FieldSelector)
 
 	return cmd
 }
@@ -112,7 +131,7 @@ func (opts *StreamListOptions) printList(streams *streamv1alpha1.StreamList, pri
 	return rows, nil
 }
 
-func (opts *StreamListOptions) print(stream *streamv1alpha1.Stream, _ printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+func (opts *StreamListOptions) print(stream *streamv1alpha1.Stream, printOpts printers.PrintOptions) ([]metav1beta1.TableRow, error) {
 	now := time.Now()
 	row := metav1beta1.TableRow{
 		Object: runtime.RawExtension{Object: stream},
@@ -124,6 +143,12 @@ func (opts *StreamListOptions) print(stream *streamv1alpha1.Stream, _ printers.P
 		cli.FormatConditionStatus(stream.Status.GetCondition(streamv1alpha1.StreamConditionReady)),
 		cli.FormatTimestampSince(stream.CreationTimestamp, now),
 	)
+	if printOpts.Wide {
+		row.Cells = append(row.Cells,
+			cli.FormatEmptyString(stream.Status.Binding.MetadataRef.Name),
+			cli.FormatEmptyString(stream.Status.Binding.SecretRef.Name),
+		)
+	}
 	return []metav1beta1.TableRow{row}, nil
 }
 
@@ -134,5 +159,7 @@ func (opts *StreamListOptions) printColumns() []metav1beta1.TableColumnDefinitio
 		{Name: "Content-Type", Type: "string"},
 		{Name: "Status", Type: "string"},
 		{Name: "Age", Type: "string"},
+		{Name: "Binding Metadata", Type: "string", Priority: 1},
+		{Name: "Binding Secret", Type: "string", Priority: 1},
 	}
 }
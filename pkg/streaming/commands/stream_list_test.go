@@ -154,6 +154,37 @@ other-namespace   test-other-stream   <empty>   <empty>        <unknown>   <unkn
 			ExpectOutput: `
 NAME    GATEWAY   CONTENT-TYPE   STATUS   AGE
 words   kafka     text/csv       Ready    <unknown>
+`,
+		},
+		{
+			Name: "wide output",
+			Args: []string{cli.OutputFlagName, "wide"},
+			GivenObjects: []runtime.Object{
+				&streamv1alpha1.Stream{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      streamName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+NAME          GATEWAY   CONTENT-TYPE   STATUS      AGE         BINDING METADATA   BINDING SECRET
+test-stream   <empty>   <empty>        <unknown>   <unknown>   <empty>            <empty>
+`,
+		},
+		{
+			Name: "name output",
+			Args: []string{cli.OutputFlagName, "name"},
+			GivenObjects: []runtime.Object{
+				&streamv1alpha1.Stream{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      streamName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+stream/test-stream
 `,
 		},
 		{
@@ -23,6 +23,8 @@ import (
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/projectriff/cli/pkg/validation"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -31,6 +33,8 @@ import (
 
 type StreamStatusOptions struct {
 	options.ResourceOptions
+
+	Output string
 }
 
 var (
@@ -43,6 +47,8 @@ func (opts *StreamStatusOptions) Validate(ctx context.Context) cli.FieldErrors {
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
 
+	errs = errs.Also(validation.StructuredOutputFormat(opts.Output, cli.OutputFlagName))
+
 	return errs
 }
 
@@ -56,10 +62,24 @@ func (opts *StreamStatusOptions) Exec(ctx context.Context, c *cli.Config) error
 		return cli.SilenceError(err)
 	}
 
+	if opts.Output != "" {
+		return printers.PrintObject(opts.Output, stream, streamv1alpha1.SchemeGroupVersion.WithKind("Stream"), c.Stdout)
+	}
+
 	ready := stream.Status.GetCondition(streamv1alpha1.StreamConditionReady)
 	cli.PrintResourceStatus(c, stream.Name, ready)
 
-	return nil
+	return cli.PrintResourceStatusDetails(c, cli.ResourceStatusDetails{
+		GroupVersionKind: stream.GetGroupVersionKind(),
+		Namespace:        stream.Namespace,
+		Name:             stream.Name,
+		Conditions:       stream.Status.Conditions,
+		Details: []cli.StatusDetail{
+			{Name: "Gateway", Value: stream.Spec.Gateway.Name},
+			{Name: "Binding Metadata", Value: stream.Status.Binding.MetadataRef.Name},
+			{Name: "Binding Secret", Value: stream.Status.Binding.SecretRef.Name},
+		},
+	})
 }
 
 func NewStreamStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -75,9 +95,13 @@ The Ready condition is shown which should include a reason code and a
 descriptive message when the status is not "True". The status for the condition
 may be: "True", "False" or "Unknown". An "Unknown" status is common while the
 stream roll out is being processed.
+
+Key fields from the status, every condition and the most recent events for
+the stream are shown after the Ready condition.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s streaming stream status my-stream", c.Name),
+			fmt.Sprintf("%s streaming stream status my-stream %s jsonpath={.status.binding.secretRef.name}", c.Name, cli.OutputFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -88,6 +112,7 @@ stream roll out is being processed.
 	)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cli.StructuredOutputFlag(cmd, &opts.Output)
 
 	return cmd
 }
@@ -26,6 +26,7 @@ import (
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kubernetes "k8s.io/client-go/kubernetes/fake"
+	appsv1clientset "k8s.io/client-go/kubernetes/typed/apps/v1"
 	authv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	corev1clientset "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
@@ -38,6 +39,13 @@ type FakeClient struct {
 	FakeRiffClientset          *projectriffclientset.Clientset
 	FakeAPIExtensionsClientset *apiextensionsv1beta1clientset.Clientset
 	ActionRecorderList         ActionRecorderList
+	// CheckError is returned by Check, simulating a kube config that fails to
+	// load
+	CheckError error
+}
+
+func (c *FakeClient) Check() error {
+	return c.CheckError
 }
 
 func (c *FakeClient) DefaultNamespace() string {
@@ -52,6 +60,10 @@ func (c *FakeClient) Core() corev1clientset.CoreV1Interface {
 	return c.FakeKubeClientset.CoreV1()
 }
 
+func (c *FakeClient) Apps() appsv1clientset.AppsV1Interface {
+	return c.FakeKubeClientset.AppsV1()
+}
+
 func (c *FakeClient) Auth() authv1client.AuthorizationV1Interface {
 	return c.FakeKubeClientset.AuthorizationV1()
 }
@@ -415,6 +415,7 @@ type DeleteCollectionRef struct {
 	Resource      string
 	Namespace     string
 	LabelSelector string
+	FieldSelector string
 }
 
 func NewDeleteCollectionRef(action clientgotesting.DeleteCollectionAction) DeleteCollectionRef {
@@ -423,6 +424,7 @@ func NewDeleteCollectionRef(action clientgotesting.DeleteCollectionAction) Delet
 		Resource:      action.GetResource().Resource,
 		Namespace:     action.GetNamespace(),
 		LabelSelector: action.GetListRestrictions().Labels.String(),
+		FieldSelector: action.GetListRestrictions().Fields.String(),
 	}
 }
 
@@ -14,7 +14,7 @@ import (
 
 	streamingv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 
-	time "time"
+	kail "github.com/projectriff/cli/pkg/kail"
 
 	v1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 )
@@ -24,13 +24,13 @@ type Logger struct {
 	mock.Mock
 }
 
-// ApplicationLogs provides a mock function with given fields: ctx, application, since, out
-func (_m *Logger) ApplicationLogs(ctx context.Context, application *v1alpha1.Application, since time.Duration, out io.Writer) error {
-	ret := _m.Called(ctx, application, since, out)
+// ApplicationLogs provides a mock function with given fields: ctx, application, opts, out
+func (_m *Logger) ApplicationLogs(ctx context.Context, application *v1alpha1.Application, opts kail.LogOptions, out io.Writer) error {
+	ret := _m.Called(ctx, application, opts, out)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *v1alpha1.Application, time.Duration, io.Writer) error); ok {
-		r0 = rf(ctx, application, since, out)
+	if rf, ok := ret.Get(0).(func(context.Context, *v1alpha1.Application, kail.LogOptions, io.Writer) error); ok {
+		r0 = rf(ctx, application, opts, out)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -38,13 +38,13 @@ func (_m *Logger) ApplicationLogs(ctx context.Context, application *v1alpha1.App
 	return r0
 }
 
-// CoreDeployerLogs provides a mock function with given fields: ctx, deployer, since, out
-func (_m *Logger) CoreDeployerLogs(ctx context.Context, deployer *corev1alpha1.Deployer, since time.Duration, out io.Writer) error {
-	ret := _m.Called(ctx, deployer, since, out)
+// CoreDeployerLogs provides a mock function with given fields: ctx, deployer, opts, out
+func (_m *Logger) CoreDeployerLogs(ctx context.Context, deployer *corev1alpha1.Deployer, opts kail.LogOptions, out io.Writer) error {
+	ret := _m.Called(ctx, deployer, opts, out)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *corev1alpha1.Deployer, time.Duration, io.Writer) error); ok {
-		r0 = rf(ctx, deployer, since, out)
+	if rf, ok := ret.Get(0).(func(context.Context, *corev1alpha1.Deployer, kail.LogOptions, io.Writer) error); ok {
+		r0 = rf(ctx, deployer, opts, out)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -52,13 +52,13 @@ func (_m *Logger) CoreDeployerLogs(ctx context.Context, deployer *corev1alpha1.D
 	return r0
 }
 
-// FunctionLogs provides a mock function with given fields: ctx, function, since, out
-func (_m *Logger) FunctionLogs(ctx context.Context, function *v1alpha1.Function, since time.Duration, out io.Writer) error {
-	ret := _m.Called(ctx, function, since, out)
+// FunctionLogs provides a mock function with given fields: ctx, function, opts, out
+func (_m *Logger) FunctionLogs(ctx context.Context, function *v1alpha1.Function, opts kail.LogOptions, out io.Writer) error {
+	ret := _m.Called(ctx, function, opts, out)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *v1alpha1.Function, time.Duration, io.Writer) error); ok {
-		r0 = rf(ctx, function, since, out)
+	if rf, ok := ret.Get(0).(func(context.Context, *v1alpha1.Function, kail.LogOptions, io.Writer) error); ok {
+		r0 = rf(ctx, function, opts, out)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -66,13 +66,13 @@ func (_m *Logger) FunctionLogs(ctx context.Context, function *v1alpha1.Function,
 	return r0
 }
 
-// InMemoryGatewayLogs provides a mock function with given fields: ctx, gateway, since, out
-func (_m *Logger) InMemoryGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.InMemoryGateway, since time.Duration, out io.Writer) error {
-	ret := _m.Called(ctx, gateway, since, out)
+// InMemoryGatewayLogs provides a mock function with given fields: ctx, gateway, opts, out
+func (_m *Logger) InMemoryGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.InMemoryGateway, opts kail.LogOptions, out io.Writer) error {
+	ret := _m.Called(ctx, gateway, opts, out)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *streamingv1alpha1.InMemoryGateway, time.Duration, io.Writer) error); ok {
-		r0 = rf(ctx, gateway, since, out)
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingv1alpha1.InMemoryGateway, kail.LogOptions, io.Writer) error); ok {
+		r0 = rf(ctx, gateway, opts, out)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -80,13 +80,13 @@ func (_m *Logger) InMemoryGatewayLogs(ctx context.Context, gateway *streamingv1a
 	return r0
 }
 
-// KafkaGatewayLogs provides a mock function with given fields: ctx, gateway, since, out
-func (_m *Logger) KafkaGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.KafkaGateway, since time.Duration, out io.Writer) error {
-	ret := _m.Called(ctx, gateway, since, out)
+// KafkaGatewayLogs provides a mock function with given fields: ctx, gateway, opts, out
+func (_m *Logger) KafkaGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.KafkaGateway, opts kail.LogOptions, out io.Writer) error {
+	ret := _m.Called(ctx, gateway, opts, out)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *streamingv1alpha1.KafkaGateway, time.Duration, io.Writer) error); ok {
-		r0 = rf(ctx, gateway, since, out)
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingv1alpha1.KafkaGateway, kail.LogOptions, io.Writer) error); ok {
+		r0 = rf(ctx, gateway, opts, out)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -94,13 +94,13 @@ func (_m *Logger) KafkaGatewayLogs(ctx context.Context, gateway *streamingv1alph
 	return r0
 }
 
-// KnativeDeployerLogs provides a mock function with given fields: ctx, deployer, since, out
-func (_m *Logger) KnativeDeployerLogs(ctx context.Context, deployer *knativev1alpha1.Deployer, since time.Duration, out io.Writer) error {
-	ret := _m.Called(ctx, deployer, since, out)
+// KnativeDeployerLogs provides a mock function with given fields: ctx, deployer, opts, out
+func (_m *Logger) KnativeDeployerLogs(ctx context.Context, deployer *knativev1alpha1.Deployer, opts kail.LogOptions, out io.Writer) error {
+	ret := _m.Called(ctx, deployer, opts, out)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *knativev1alpha1.Deployer, time.Duration, io.Writer) error); ok {
-		r0 = rf(ctx, deployer, since, out)
+	if rf, ok := ret.Get(0).(func(context.Context, *knativev1alpha1.Deployer, kail.LogOptions, io.Writer) error); ok {
+		r0 = rf(ctx, deployer, opts, out)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -108,13 +108,13 @@ func (_m *Logger) KnativeDeployerLogs(ctx context.Context, deployer *knativev1al
 	return r0
 }
 
-// PulsarGatewayLogs provides a mock function with given fields: ctx, gateway, since, out
-func (_m *Logger) PulsarGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.PulsarGateway, since time.Duration, out io.Writer) error {
-	ret := _m.Called(ctx, gateway, since, out)
+// PulsarGatewayLogs provides a mock function with given fields: ctx, gateway, opts, out
+func (_m *Logger) PulsarGatewayLogs(ctx context.Context, gateway *streamingv1alpha1.PulsarGateway, opts kail.LogOptions, out io.Writer) error {
+	ret := _m.Called(ctx, gateway, opts, out)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *streamingv1alpha1.PulsarGateway, time.Duration, io.Writer) error); ok {
-		r0 = rf(ctx, gateway, since, out)
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingv1alpha1.PulsarGateway, kail.LogOptions, io.Writer) error); ok {
+		r0 = rf(ctx, gateway, opts, out)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -122,13 +122,13 @@ func (_m *Logger) PulsarGatewayLogs(ctx context.Context, gateway *streamingv1alp
 	return r0
 }
 
-// StreamingProcessorLogs provides a mock function with given fields: ctx, processor, since, out
-func (_m *Logger) StreamingProcessorLogs(ctx context.Context, processor *streamingv1alpha1.Processor, since time.Duration, out io.Writer) error {
-	ret := _m.Called(ctx, processor, since, out)
+// StreamingProcessorLogs provides a mock function with given fields: ctx, processor, opts, out
+func (_m *Logger) StreamingProcessorLogs(ctx context.Context, processor *streamingv1alpha1.Processor, opts kail.LogOptions, out io.Writer) error {
+	ret := _m.Called(ctx, processor, opts, out)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *streamingv1alpha1.Processor, time.Duration, io.Writer) error); ok {
-		r0 = rf(ctx, processor, since, out)
+	if rf, ok := ret.Get(0).(func(context.Context, *streamingv1alpha1.Processor, kail.LogOptions, io.Writer) error); ok {
+		r0 = rf(ctx, processor, opts, out)
 	} else {
 		r0 = ret.Error(0)
 	}
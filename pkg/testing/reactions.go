@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgotesting "k8s.io/client-go/testing"
 )
@@ -39,6 +40,18 @@ func InduceFailure(verb, resource string) clientgotesting.ReactionFunc {
 	}
 }
 
+// InduceForbidden makes calls matching the verb and resource fail as if the
+// user is not allowed access.
+func InduceForbidden(verb, resource string) clientgotesting.ReactionFunc {
+	return func(action clientgotesting.Action) (handled bool, ret runtime.Object, err error) {
+		if !action.Matches(verb, resource) {
+			return false, nil, nil
+		}
+		gr := action.GetResource().GroupResource()
+		return true, nil, apierrs.NewForbidden(gr, "", fmt.Errorf("inducing forbidden for %s %s", action.GetVerb(), gr.Resource))
+	}
+}
+
 func ValidateCreates(ctx context.Context, action clientgotesting.Action) (handled bool, ret runtime.Object, err error) {
 	// got := action.(clientgotesting.CreateAction).GetObject()
 	// obj, ok := got.(apis.Validatable)
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation
+
+import (
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func Label(label, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	parts := strings.SplitN(label, "=", 2)
+	if len(parts) != 2 {
+		errs = errs.Also(cli.ErrInvalidValue(label, field))
+	} else if out := validation.IsQualifiedName(parts[0]); len(out) != 0 {
+		errs = errs.Also(cli.ErrInvalidValue(label, field))
+	} else if out := validation.IsValidLabelValue(parts[1]); len(out) != 0 {
+		errs = errs.Also(cli.ErrInvalidValue(label, field))
+	}
+
+	return errs
+}
+
+func Labels(labels []string, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	for i, label := range labels {
+		errs = errs.Also(Label(label, cli.CurrentField).ViaFieldIndex(field, i))
+	}
+
+	return errs
+}
+
+func Annotation(annotation, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	parts := strings.SplitN(annotation, "=", 2)
+	if len(parts) != 2 {
+		errs = errs.Also(cli.ErrInvalidValue(annotation, field))
+	} else if out := validation.IsQualifiedName(parts[0]); len(out) != 0 {
+		errs = errs.Also(cli.ErrInvalidValue(annotation, field))
+	}
+
+	return errs
+}
+
+func Annotations(annotations []string, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	for i, annotation := range annotations {
+		errs = errs.Also(Annotation(annotation, cli.CurrentField).ViaFieldIndex(field, i))
+	}
+
+	return errs
+}
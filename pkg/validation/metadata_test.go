@@ -0,0 +1,187 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/cli"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"github.com/projectriff/cli/pkg/validation"
+)
+
+func TestLabel(t *testing.T) {
+	longValue := strings.Repeat("a", 64)
+
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		value    string
+	}{{
+		name:     "valid",
+		expected: cli.FieldErrors{},
+		value:    "team=payments",
+	}, {
+		name:     "valid, prefixed key",
+		expected: cli.FieldErrors{},
+		value:    "example.com/team=payments",
+	}, {
+		name:     "valid, empty value",
+		expected: cli.FieldErrors{},
+		value:    "team=",
+	}, {
+		name:     "empty",
+		expected: cli.ErrInvalidValue("", rifftesting.TestField),
+		value:    "",
+	}, {
+		name:     "missing value",
+		expected: cli.ErrInvalidValue("team", rifftesting.TestField),
+		value:    "team",
+	}, {
+		name:     "missing key",
+		expected: cli.ErrInvalidValue("=payments", rifftesting.TestField),
+		value:    "=payments",
+	}, {
+		name:     "invalid key",
+		expected: cli.ErrInvalidValue("my team=payments", rifftesting.TestField),
+		value:    "my team=payments",
+	}, {
+		name:     "invalid value",
+		expected: cli.ErrInvalidValue("team="+longValue, rifftesting.TestField),
+		value:    "team=" + longValue,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.Label(test.value, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		values   []string
+	}{{
+		name:     "valid, empty",
+		expected: cli.FieldErrors{},
+		values:   []string{},
+	}, {
+		name:     "valid, not empty",
+		expected: cli.FieldErrors{},
+		values:   []string{"team=payments", "cost-center=1234"},
+	}, {
+		name:     "invalid",
+		expected: cli.ErrInvalidValue("", cli.CurrentField).ViaFieldIndex(rifftesting.TestField, 0),
+		values:   []string{""},
+	}, {
+		name: "multiple invalid",
+		expected: cli.FieldErrors{}.Also(
+			cli.ErrInvalidValue("", cli.CurrentField).ViaFieldIndex(rifftesting.TestField, 0),
+			cli.ErrInvalidValue("", cli.CurrentField).ViaFieldIndex(rifftesting.TestField, 1),
+		),
+		values: []string{"", ""},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.Labels(test.values, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestAnnotation(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		value    string
+	}{{
+		name:     "valid",
+		expected: cli.FieldErrors{},
+		value:    "owner=jane@example.com",
+	}, {
+		name:     "valid, free form value",
+		expected: cli.FieldErrors{},
+		value:    "example.com/description=a value with spaces, and = signs",
+	}, {
+		name:     "empty",
+		expected: cli.ErrInvalidValue("", rifftesting.TestField),
+		value:    "",
+	}, {
+		name:     "missing key",
+		expected: cli.ErrInvalidValue("=jane", rifftesting.TestField),
+		value:    "=jane",
+	}, {
+		name:     "invalid key",
+		expected: cli.ErrInvalidValue("the owner=jane", rifftesting.TestField),
+		value:    "the owner=jane",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.Annotation(test.value, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestAnnotations(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		values   []string
+	}{{
+		name:     "valid, empty",
+		expected: cli.FieldErrors{},
+		values:   []string{},
+	}, {
+		name:     "valid, not empty",
+		expected: cli.FieldErrors{},
+		values:   []string{"owner=jane@example.com"},
+	}, {
+		name: "multiple invalid",
+		expected: cli.FieldErrors{}.Also(
+			cli.ErrInvalidValue("", cli.CurrentField).ViaFieldIndex(rifftesting.TestField, 0),
+			cli.ErrInvalidValue("", cli.CurrentField).ViaFieldIndex(rifftesting.TestField, 1),
+		),
+		values: []string{"", ""},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.Annotations(test.values, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation
+
+import (
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/printers"
+)
+
+func OutputFormat(format, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if printers.IsHumanReadable(format) {
+		return errs
+	}
+	if _, err := printers.NewPrinterForFormat(format); err != nil {
+		errs = errs.Also(cli.ErrInvalidValue(format, field))
+	}
+
+	return errs
+}
+
+func StructuredOutputFormat(format, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if format == "" {
+		return errs
+	}
+	if _, err := printers.NewPrinterForFormat(format); err != nil {
+		errs = errs.Also(cli.ErrInvalidValue(format, field))
+	}
+
+	return errs
+}
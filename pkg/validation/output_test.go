@@ -0,0 +1,138 @@
+/*
+ * Copyright 2019 The original author or authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/cli"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"github.com/projectriff/cli/pkg/validation"
+)
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		value    string
+	}{{
+		name:     "default",
+		expected: cli.FieldErrors{},
+		value:    "",
+	}, {
+		name:     "wide",
+		expected: cli.FieldErrors{},
+		value:    "wide",
+	}, {
+		name:     "json",
+		expected: cli.FieldErrors{},
+		value:    "json",
+	}, {
+		name:     "yaml",
+		expected: cli.FieldErrors{},
+		value:    "yaml",
+	}, {
+		name:     "name",
+		expected: cli.FieldErrors{},
+		value:    "name",
+	}, {
+		name:     "jsonpath",
+		expected: cli.FieldErrors{},
+		value:    "jsonpath={.items[*].status.latestImage}",
+	}, {
+		name:     "relaxed jsonpath",
+		expected: cli.FieldErrors{},
+		value:    "jsonpath=.status.url",
+	}, {
+		name:     "invalid jsonpath",
+		expected: cli.ErrInvalidValue("jsonpath={.status", rifftesting.TestField),
+		value:    "jsonpath={.status",
+	}, {
+		name:     "missing jsonpath",
+		expected: cli.ErrInvalidValue("jsonpath=", rifftesting.TestField),
+		value:    "jsonpath=",
+	}, {
+		name:     "go-template",
+		expected: cli.FieldErrors{},
+		value:    "go-template={{.status.url}}",
+	}, {
+		name:     "invalid go-template",
+		expected: cli.ErrInvalidValue("go-template={{.status.url", rifftesting.TestField),
+		value:    "go-template={{.status.url",
+	}, {
+		name:     "invalid",
+		expected: cli.ErrInvalidValue("xml", rifftesting.TestField),
+		value:    "xml",
+	}, {
+		name:     "template for non-template format",
+		expected: cli.ErrInvalidValue("json=foo", rifftesting.TestField),
+		value:    "json=foo",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.OutputFormat(test.value, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestStructuredOutputFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		value    string
+	}{{
+		name:     "default",
+		expected: cli.FieldErrors{},
+		value:    "",
+	}, {
+		name:     "wide",
+		expected: cli.ErrInvalidValue("wide", rifftesting.TestField),
+		value:    "wide",
+	}, {
+		name:     "json",
+		expected: cli.FieldErrors{},
+		value:    "json",
+	}, {
+		name:     "jsonpath",
+		expected: cli.FieldErrors{},
+		value:    "jsonpath={.status.url}",
+	}, {
+		name:     "go-template",
+		expected: cli.FieldErrors{},
+		value:    "go-template={{.status.url}}",
+	}, {
+		name:     "invalid",
+		expected: cli.ErrInvalidValue("xml", rifftesting.TestField),
+		value:    "xml",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.StructuredOutputFormat(test.value, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation
+
+import (
+	"github.com/projectriff/cli/pkg/cli"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func LabelSelector(selector, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if _, err := labels.Parse(selector); err != nil {
+		errs = errs.Also(cli.ErrInvalidValue(selector, field))
+	}
+
+	return errs
+}
+
+func FieldSelector(selector, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if _, err := fields.ParseSelector(selector); err != nil {
+		errs = errs.Also(cli.ErrInvalidValue(selector, field))
+	}
+
+	return errs
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2019 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/cli"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"github.com/projectriff/cli/pkg/validation"
+)
+
+func TestLabelSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		value    string
+	}{{
+		name:     "valid",
+		expected: cli.FieldErrors{},
+		value:    "team=payments",
+	}, {
+		name:     "valid, empty",
+		expected: cli.FieldErrors{},
+		value:    "",
+	}, {
+		name:     "valid, set based",
+		expected: cli.FieldErrors{},
+		value:    "team in (payments,billing),!canary",
+	}, {
+		name:     "invalid",
+		expected: cli.ErrInvalidValue("team in payments", rifftesting.TestField),
+		value:    "team in payments",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.LabelSelector(test.value, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestFieldSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		value    string
+	}{{
+		name:     "valid",
+		expected: cli.FieldErrors{},
+		value:    "metadata.name=my-function",
+	}, {
+		name:     "valid, empty",
+		expected: cli.FieldErrors{},
+		value:    "",
+	}, {
+		name:     "invalid",
+		expected: cli.ErrInvalidValue("metadata.name", rifftesting.TestField),
+		value:    "metadata.name",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.FieldSelector(test.value, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}